@@ -154,7 +154,7 @@ func Down20200605090535(tx *sql.Tx) error {
 		return err
 	}
 	for _, namespace := range namespaces {
-		if _, err := client.ArchiveWorkflowTemplate(namespace.Name, uid); err != nil {
+		if _, err := client.ArchiveWorkflowTemplate(namespace.Name, uid, false); err != nil {
 			log.Fatalf("error %v", err.Error())
 		}
 	}
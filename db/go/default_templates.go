@@ -0,0 +1,44 @@
+package migration
+
+import (
+	v1 "github.com/onepanelio/core/pkg"
+)
+
+// DefaultWorkflowTemplates returns the latest version of each workflow template the migrations add to namespaces.
+// Namespaces onboarded after the migrations ran get these templates instead.
+func DefaultWorkflowTemplates() ([]*v1.WorkflowTemplate, error) {
+	maskRCNNManifest, err := readDataFile("20201115145814_maskrcnn.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	tensorflowObjectDetectionManifest, err := readDataFile("20201115134934_tfod.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	return []*v1.WorkflowTemplate{
+		{
+			Name:     pytorchMnistWorkflowTemplateName,
+			Manifest: pytorchMnistWorkflowTemplate,
+		},
+		{
+			Name:     tensorflowWorkflowTemplateName,
+			Manifest: tensorflowWorkflowTemplate,
+		},
+		{
+			Name:     maskRCNNWorkflowTemplateName,
+			Manifest: maskRCNNManifest,
+			Labels: map[string]string{
+				"used-by": "cvat",
+			},
+		},
+		{
+			Name:     tensorflowObjectDetectionWorkflowTemplateName,
+			Manifest: tensorflowObjectDetectionManifest,
+			Labels: map[string]string{
+				"used-by": "cvat",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE workspace_events
+(
+    id              serial PRIMARY KEY,
+    workspace_id    integer NOT NULL REFERENCES workspaces ON DELETE CASCADE,
+    phase           varchar(50) NOT NULL,
+    message         text NOT NULL DEFAULT '',
+
+    -- auditing info
+    created_at      timestamp NOT NULL DEFAULT (NOW() at time zone 'utc')
+);
+
+CREATE INDEX workspace_events_workspace_id_idx ON workspace_events (workspace_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE workspace_events;
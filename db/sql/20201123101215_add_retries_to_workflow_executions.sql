@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_executions ADD COLUMN retries INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workflow_executions DROP COLUMN retries;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_executions ADD COLUMN idempotency_key TEXT;
+CREATE INDEX workflow_executions_namespace_idempotency_key_idx ON workflow_executions (namespace, idempotency_key);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX workflow_executions_namespace_idempotency_key_idx;
+ALTER TABLE workflow_executions DROP COLUMN idempotency_key;
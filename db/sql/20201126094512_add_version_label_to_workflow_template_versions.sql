@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_template_versions ADD COLUMN version_label TEXT;
+CREATE UNIQUE INDEX workflow_template_versions_version_label_idx ON workflow_template_versions (workflow_template_id, version_label) WHERE version_label IS NOT NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX workflow_template_versions_version_label_idx;
+ALTER TABLE workflow_template_versions DROP COLUMN version_label;
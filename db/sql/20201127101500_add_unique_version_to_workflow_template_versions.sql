@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE UNIQUE INDEX workflow_template_versions_version_idx ON workflow_template_versions (workflow_template_id, version);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX workflow_template_versions_version_idx;
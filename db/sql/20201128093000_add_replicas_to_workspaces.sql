@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workspaces ADD COLUMN replicas INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workspaces DROP COLUMN replicas;
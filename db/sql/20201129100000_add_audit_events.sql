@@ -0,0 +1,20 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE audit_events
+(
+    id              serial PRIMARY KEY,
+    namespace       varchar(30) NOT NULL,
+    action          varchar(50) NOT NULL,
+    identity        text NOT NULL DEFAULT '',
+    resource_uid    text NOT NULL DEFAULT '',
+    parameters      jsonb,
+
+    -- auditing info
+    created_at      timestamp NOT NULL DEFAULT (NOW() at time zone 'utc')
+);
+
+CREATE INDEX audit_events_namespace_created_at_idx ON audit_events (namespace, created_at);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE audit_events;
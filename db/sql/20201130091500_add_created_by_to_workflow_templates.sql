@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_templates ADD COLUMN created_by text NOT NULL DEFAULT '';
+ALTER TABLE workflow_templates ADD COLUMN updated_by text NOT NULL DEFAULT '';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workflow_templates DROP COLUMN updated_by;
+ALTER TABLE workflow_templates DROP COLUMN created_by;
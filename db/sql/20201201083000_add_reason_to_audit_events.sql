@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE audit_events ADD COLUMN reason text NOT NULL DEFAULT '';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE audit_events DROP COLUMN reason;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_templates ADD COLUMN category text NOT NULL DEFAULT '';
+CREATE INDEX workflow_templates_namespace_category_idx ON workflow_templates (namespace, category);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX workflow_templates_namespace_category_idx;
+ALTER TABLE workflow_templates DROP COLUMN category;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workspaces ADD COLUMN command TEXT[];
+ALTER TABLE workspaces ADD COLUMN args TEXT[];
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workspaces DROP COLUMN args;
+ALTER TABLE workspaces DROP COLUMN command;
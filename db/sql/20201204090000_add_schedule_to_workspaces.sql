@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workspaces ADD COLUMN schedule_start text NOT NULL DEFAULT '';
+ALTER TABLE workspaces ADD COLUMN schedule_stop text NOT NULL DEFAULT '';
+ALTER TABLE workspaces ADD COLUMN schedule_timezone text NOT NULL DEFAULT '';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workspaces DROP COLUMN schedule_timezone;
+ALTER TABLE workspaces DROP COLUMN schedule_stop;
+ALTER TABLE workspaces DROP COLUMN schedule_start;
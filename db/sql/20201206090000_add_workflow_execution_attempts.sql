@@ -0,0 +1,19 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE workflow_execution_attempts
+(
+    id                    serial PRIMARY KEY,
+    workflow_execution_id integer NOT NULL REFERENCES workflow_executions ON DELETE CASCADE,
+    phase                 varchar(50) NOT NULL,
+    started_at            timestamp DEFAULT NULL,
+    finished_at           timestamp DEFAULT NULL,
+
+    -- auditing info
+    created_at            timestamp NOT NULL DEFAULT (NOW() at time zone 'utc')
+);
+
+CREATE INDEX workflow_execution_attempts_workflow_execution_id_idx ON workflow_execution_attempts (workflow_execution_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE workflow_execution_attempts;
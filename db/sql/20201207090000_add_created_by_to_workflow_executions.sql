@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workflow_executions ADD COLUMN created_by text NOT NULL DEFAULT '';
+CREATE INDEX workflow_executions_namespace_created_by_idx ON workflow_executions (namespace, created_by);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX workflow_executions_namespace_created_by_idx;
+ALTER TABLE workflow_executions DROP COLUMN created_by;
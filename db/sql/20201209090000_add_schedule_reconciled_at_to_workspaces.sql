@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE workspaces ADD COLUMN schedule_reconciled_at timestamp DEFAULT NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE workspaces DROP COLUMN schedule_reconciled_at;
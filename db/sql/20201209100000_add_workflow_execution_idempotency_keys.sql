@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE workflow_execution_idempotency_keys
+(
+    namespace       varchar(30) NOT NULL,
+    idempotency_key text NOT NULL,
+    created_at      timestamp NOT NULL DEFAULT (NOW() at time zone 'utc'),
+
+    PRIMARY KEY (namespace, idempotency_key)
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE workflow_execution_idempotency_keys;
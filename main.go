@@ -14,6 +14,7 @@ import (
 	migrations "github.com/onepanelio/core/db/go"
 	v1 "github.com/onepanelio/core/pkg"
 	"github.com/onepanelio/core/pkg/util/env"
+	"github.com/onepanelio/core/pkg/util/metrics"
 	"github.com/onepanelio/core/server"
 	"github.com/onepanelio/core/server/auth"
 	"github.com/pressly/goose"
@@ -33,6 +34,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 var (
@@ -41,6 +43,9 @@ var (
 	recoveryFunc grpc_recovery.RecoveryHandlerFunc
 )
 
+// workspaceScheduleReconcileInterval is how often workspaces are paused or resumed according to their schedule
+const workspaceScheduleReconcileInterval = time.Minute
+
 func main() {
 	flag.Parse()
 
@@ -87,11 +92,19 @@ func main() {
 				db.Close()
 			}
 
-			s := startRPCServer(v1.NewDB(db), kubeConfig, sysConfig, stopCh)
+			watchGroup := v1.NewWatchGroup()
+			s := startRPCServer(v1.NewDB(db), kubeConfig, sysConfig, watchGroup, stopCh)
+
+			reconcilerCtx, stopReconciler := context.WithCancel(context.Background())
+			go startWorkspaceScheduleReconciler(reconcilerCtx, kubeConfig, v1.NewDB(db), sysConfig)
 
 			<-stopCh
 
+			stopReconciler()
 			s.Stop()
+			if err := watchGroup.Close(v1.WatchGroupCloseTimeout); err != nil {
+				log.Printf("[error] stopping watchers: %v", err)
+			}
 			if err := db.Close(); err != nil {
 				log.Printf("[error] closing db connection")
 			}
@@ -101,7 +114,7 @@ func main() {
 	startHTTPProxy()
 }
 
-func startRPCServer(db *v1.DB, kubeConfig *v1.Config, sysConfig v1.SystemConfig, stopCh chan struct{}) *grpc.Server {
+func startRPCServer(db *v1.DB, kubeConfig *v1.Config, sysConfig v1.SystemConfig, watchGroup *v1.WatchGroup, stopCh chan struct{}) *grpc.Server {
 	log.Printf("Starting RPC server on port %v", *rpcPort)
 	lis, err := net.Listen("tcp", *rpcPort)
 	if err != nil {
@@ -127,13 +140,14 @@ func startRPCServer(db *v1.DB, kubeConfig *v1.Config, sysConfig v1.SystemConfig,
 	s := grpc.NewServer(grpc.UnaryInterceptor(
 		grpc_middleware.ChainUnaryServer(
 			grpc_logrus.UnaryServerInterceptor(logEntry),
+			metrics.UnaryServerInterceptor(),
 			grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
-			auth.UnaryInterceptor(kubeConfig, db, sysConfig)),
+			auth.UnaryInterceptor(kubeConfig, db, sysConfig, watchGroup)),
 	), grpc.StreamInterceptor(
 		grpc_middleware.ChainStreamServer(
 			grpc_logrus.StreamServerInterceptor(logEntry),
 			grpc_recovery.StreamServerInterceptor(recoveryOpts...),
-			auth.StreamingInterceptor(kubeConfig, db, sysConfig)),
+			auth.StreamingInterceptor(kubeConfig, db, sysConfig, watchGroup)),
 	), grpc.MaxRecvMsgSize(math.MaxInt64), grpc.MaxSendMsgSize(math.MaxInt64))
 	api.RegisterWorkflowTemplateServiceServer(s, server.NewWorkflowTemplateServer())
 	api.RegisterCronWorkflowServiceServer(s, server.NewCronWorkflowServer())
@@ -158,6 +172,17 @@ func startRPCServer(db *v1.DB, kubeConfig *v1.Config, sysConfig v1.SystemConfig,
 	return s
 }
 
+// startWorkspaceScheduleReconciler pauses and resumes workspaces according to their schedule until ctx is done
+func startWorkspaceScheduleReconciler(ctx context.Context, kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig) {
+	client, err := v1.NewClient(kubeConfig, db, sysConfig)
+	if err != nil {
+		log.Printf("[error] starting workspace schedule reconciler: %v", err)
+		return
+	}
+
+	client.RunWorkspaceScheduleReconciler(ctx, workspaceScheduleReconcileInterval)
+}
+
 func startHTTPProxy() {
 	endpoint := "localhost" + *rpcPort
 	ctx := context.Background()
@@ -195,11 +220,15 @@ func startHTTPProxy() {
 	// Allow PUT. Have to include all others as it clears them out.
 	allowedMethods := handlers.AllowedMethods([]string{"HEAD", "GET", "POST", "PUT", "DELETE", "PATCH"})
 
-	if err := http.ListenAndServe(*httpPort, wsproxy.WebsocketProxy(
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/metrics", metrics.Handler())
+	httpMux.Handle("/", wsproxy.WebsocketProxy(
 		handlers.CORS(
 			handlers.AllowedOriginValidator(ogValidator), allowedHeaders, allowedMethods)(mux),
 		wsproxy.WithTokenCookieName("auth-token"),
-	)); err != nil {
+	))
+
+	if err := http.ListenAndServe(*httpPort, httpMux); err != nil {
 		log.Fatalf("Failed to serve HTTP listener: %v", err)
 	}
 }
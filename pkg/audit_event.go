@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"encoding/json"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/onepanelio/core/pkg/util/request"
+	log "github.com/sirupsen/logrus"
+)
+
+// createAuditEvent records that the client's caller made the call action on the resource with resourceUID, for reason.
+// The values of parameters with a SecretRef are not recorded.
+// Failing to record the event does not fail the call, which already happened, so the error is only logged.
+func (c *Client) createAuditEvent(namespace string, action AuditAction, resourceUID string, parameters []Parameter, reason string) {
+	err := c.createAuditEventDB(&AuditEvent{
+		Namespace:   namespace,
+		Action:      action,
+		Identity:    c.Identity,
+		ResourceUID: resourceUID,
+		Parameters:  redactParameterSecrets(parameters),
+		Reason:      reason,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace":   namespace,
+			"Action":      action,
+			"Identity":    c.Identity,
+			"ResourceUID": resourceUID,
+			"Error":       err.Error(),
+		}).Error("Unable to record audit event.")
+	}
+}
+
+// createAuditEventDB inserts the audit event, setting its ID and CreatedAt
+func (c *Client) createAuditEventDB(event *AuditEvent) error {
+	parametersJSON, err := json.Marshal(event.Parameters)
+	if err != nil {
+		return err
+	}
+
+	return sb.Insert("audit_events").
+		SetMap(sq.Eq{
+			"namespace":    event.Namespace,
+			"action":       event.Action,
+			"identity":     event.Identity,
+			"resource_uid": event.ResourceUID,
+			"parameters":   string(parametersJSON),
+			"reason":       event.Reason,
+		}).
+		Suffix("RETURNING id, created_at").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&event.ID, &event.CreatedAt)
+}
+
+// ListAuditEvents returns the audit events of the namespace, newest first.
+// If resourceUID is not empty, only the events of that resource are returned.
+func (c *Client) ListAuditEvents(namespace, resourceUID string, request *request.Request) (events []*AuditEvent, err error) {
+	query := auditEventsSelectBuilder(namespace, resourceUID).
+		Columns(getAuditEventColumns()...).
+		OrderBy("created_at DESC", "id DESC")
+	query = *request.ApplyPaginationToSelect(&query)
+
+	events = make([]*AuditEvent, 0)
+	if err = c.DB.Selectx(&events, query); err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if _, err = event.LoadParametersFromBytes(); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// CountAuditEvents returns the number of audit events of the namespace, see ListAuditEvents
+func (c *Client) CountAuditEvents(namespace, resourceUID string) (count int, err error) {
+	err = auditEventsSelectBuilder(namespace, resourceUID).
+		Columns("COUNT(*)").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&count)
+
+	return
+}
+
+// auditEventsSelectBuilder selects the audit events of the namespace, and of the resource if resourceUID is not empty, without columns
+func auditEventsSelectBuilder(namespace, resourceUID string) sq.SelectBuilder {
+	whereEq := sq.Eq{
+		"namespace": namespace,
+	}
+	if resourceUID != "" {
+		whereEq["resource_uid"] = resourceUID
+	}
+
+	return sb.Select().
+		From("audit_events").
+		Where(whereEq)
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"encoding/json"
+	"github.com/onepanelio/core/pkg/util/sql"
+	"time"
+)
+
+// AuditAction is a call that is recorded in the audit events
+type AuditAction string
+
+// Audit actions
+const (
+	AuditActionCreateWorkflowExecution           AuditAction = "CreateWorkflowExecution"
+	AuditActionTerminateWorkflowExecution        AuditAction = "TerminateWorkflowExecution"
+	AuditActionResubmitWorkflowExecution         AuditAction = "ResubmitWorkflowExecution"
+	AuditActionTransferWorkflowTemplateOwnership AuditAction = "TransferWorkflowTemplateOwnership"
+	AuditActionSetWorkflowTemplateStableVersion  AuditAction = "SetWorkflowTemplateStableVersion"
+)
+
+// AuditEvent records who made a call, when, and with which parameters
+type AuditEvent struct {
+	ID              uint64
+	CreatedAt       time.Time `db:"created_at"`
+	Namespace       string
+	Action          AuditAction
+	Identity        string      // the caller, see Client.Identity
+	ResourceUID     string      `db:"resource_uid"` // uid of the resource the call acted on
+	Parameters      []Parameter `db:"-"`            // parameters of the call, without the values of secrets
+	ParametersBytes []byte      `db:"parameters"`   // to load from database
+	Reason          string      // why the call was made, if the caller said
+}
+
+// LoadParametersFromBytes loads Parameters from the AuditEvent's ParametersBytes field.
+func (e *AuditEvent) LoadParametersFromBytes() ([]Parameter, error) {
+	e.Parameters = make([]Parameter, 0)
+	if len(e.ParametersBytes) == 0 {
+		return e.Parameters, nil
+	}
+
+	if err := json.Unmarshal(e.ParametersBytes, &e.Parameters); err != nil {
+		return nil, err
+	}
+	// "null" is stored in the database if there are no parameters.
+	if e.Parameters == nil {
+		e.Parameters = make([]Parameter, 0)
+	}
+
+	return e.Parameters, nil
+}
+
+// getAuditEventColumns returns all of the columns for audit events modified by alias, destination.
+// see formatColumnSelect
+func getAuditEventColumns(aliasAndDestination ...string) []string {
+	columns := []string{"id", "created_at", "namespace", "action", "identity", "resource_uid", "parameters", "reason"}
+	return sql.FormatColumnSelect(columns, aliasAndDestination...)
+}
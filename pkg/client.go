@@ -1,17 +1,23 @@
 package v1
 
 import (
+	"context"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	argoprojv1alpha1 "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/jmoiron/sqlx"
+	"github.com/onepanelio/core/pkg/util"
 	"github.com/onepanelio/core/pkg/util/gcs"
+	"github.com/onepanelio/core/pkg/util/metrics"
+	"github.com/onepanelio/core/pkg/util/ratelimit"
 	"github.com/onepanelio/core/pkg/util/router"
 	"github.com/onepanelio/core/pkg/util/s3"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"time"
 )
 
 type Config = rest.Config
@@ -19,17 +25,118 @@ type Config = rest.Config
 var sb = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 type Client struct {
-	Token string
+	Token    string
+	Identity string // who the client makes calls for, recorded in audit events
 	kubernetes.Interface
 	argoprojV1alpha1 argoprojv1alpha1.ArgoprojV1alpha1Interface
 	*DB
 	systemConfig SystemConfig
+	ctx          context.Context
+	watchGroup   *WatchGroup
+
+	podMetricsSource          PodMetricsSource
+	metricsRangeSource        MetricsRangeSource
+	parameterOptionsSource    ParameterOptionsSource
+	parameterOptionsCache     *parameterOptionsCache
+	volumeSnapshotSource      VolumeSnapshotSource
+	workflowExecutionLimiter  *ratelimit.KeyedLimiter
+	workflowTemplateLintRules []WorkflowTemplateLintRule
+	logStreamOptions          *LogStreamOptions
 }
 
 func (c *Client) ArgoprojV1alpha1() argoprojv1alpha1.ArgoprojV1alpha1Interface {
 	return c.argoprojV1alpha1
 }
 
+// WithContext returns a shallow copy of the client that uses ctx for kubernetes calls.
+// Once ctx is cancelled or its deadline passes, kubernetes calls return without waiting for a response.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+// WithWatchGroup returns a shallow copy of the client that runs its watchers in watchGroup,
+// so they are stopped when watchGroup is closed.
+func (c *Client) WithWatchGroup(watchGroup *WatchGroup) *Client {
+	client := *c
+	client.watchGroup = watchGroup
+
+	return &client
+}
+
+// Close stops the watchers of the client's watch group, waiting a bounded amount of time for them to finish.
+// Clients sharing the watch group have their watchers stopped as well.
+func (c *Client) Close() error {
+	return c.watchGroup.Close(WatchGroupCloseTimeout)
+}
+
+// Context returns the context of the client, or context.Background() if none was set
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// runKubeCall runs call, a read-only kubernetes call, recording it in the kubernetes call metrics under name.
+// The typed kubernetes clients do not accept a context, so if the client context is done first
+// the call is abandoned and a DeadlineExceeded or Canceled error is returned.
+// Calls that change something must use runKubeMutation instead.
+func (c *Client) runKubeCall(name string, call func() error) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveKubeCall(name, start, err)
+	}()
+
+	ctx := c.Context()
+	if ctx.Err() != nil {
+		return contextError(ctx)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- call()
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
+
+// runKubeMutation runs call, a kubernetes call that changes something, recording it in the kubernetes call metrics under name.
+// Unlike runKubeCall, once started the call is never abandoned: the typed kubernetes clients can not cancel it,
+// so abandoning it would leave the change made without the caller knowing, like a workflow created without its database row.
+// If the client context is done before the call starts, it is not made and a DeadlineExceeded or Canceled error is returned.
+// Otherwise the result of the call is returned, even if the context is done by the time it finishes.
+func (c *Client) runKubeMutation(name string, call func() error) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveKubeCall(name, start, err)
+	}()
+
+	ctx := c.Context()
+	if ctx.Err() != nil {
+		return contextError(ctx)
+	}
+
+	return call()
+}
+
+// contextError converts the error of a done context into a UserError with the matching gRPC code
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return util.NewUserError(codes.DeadlineExceeded, "Kubernetes request deadline exceeded.")
+	}
+
+	return util.NewUserError(codes.Canceled, "Kubernetes request canceled.")
+}
+
 func NewConfig() (config *Config) {
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).ClientConfig()
@@ -97,11 +97,28 @@ func DefaultTestClient() *Client {
 	return NewTestClient(database, mockSystemConfigMap, mockSystemSecret)
 }
 
+// closedDatabaseTestClient returns a client whose database connection is closed, so every query fails
+func closedDatabaseTestClient(t *testing.T) *Client {
+	db, err := sqlx.Open("postgres", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewTestClient(db, mockSystemConfigMap, mockSystemSecret)
+}
+
 func clearDatabase(t *testing.T) {
 	// We do not delete from goose_db_version as we need it to mark the migrations as ran.
 	query := `
+		DELETE FROM audit_events;
+		DELETE FROM workspace_events;
 		DELETE FROM workspaces;
+		DELETE FROM workflow_execution_attempts;
 		DELETE FROM workflow_executions;
+		DELETE FROM workflow_execution_idempotency_keys;
 		DELETE FROM cron_workflows;
 		DELETE FROM workspace_templates;
 		DELETE FROM workflow_templates;
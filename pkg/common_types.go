@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/onepanelio/core/pkg/util/ptr"
 	"gopkg.in/yaml.v2"
+	"strings"
 )
 
 // +genclient
@@ -23,10 +24,56 @@ type Parameter struct {
 	Hint        *string            `json:"hint,omitempty" protobuf:"bytes,5,opt,name=hint"`
 	Options     []*ParameterOption `json:"options,omitempty" protobuf:"bytes,6,opt,name=options"`
 	Required    bool               `json:"required,omitempty" protobuf:"bytes,7,opt,name=required"`
+	// SecretRef is a reference to the key of a secret, in the form name/key, to take the value from
+	SecretRef *string `json:"secretRef,omitempty"`
+	// OptionsFrom is where to take the options from when the workflow template is fetched, instead of Options
+	OptionsFrom *ParameterOptionsFrom `json:"optionsFrom,omitempty" yaml:"optionsFrom"`
+}
+
+// ParameterOptionsFrom points to an output of a workflow template's latest succeeded workflow execution.
+// The output is a JSON array of strings, or of objects with a name and value, see parseParameterOptions.
+type ParameterOptionsFrom struct {
+	WorkflowTemplate string `json:"workflowTemplate" yaml:"workflowTemplate"` // uid of the workflow template
+	Output           string `json:"output" yaml:"output"`                     // name of the output parameter
+}
+
+// SecretKeyRef is a reference to a key of a secret
+type SecretKeyRef struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// ParseSecretKeyRef parses a reference in the form name/key, or namespace/name/key.
+// If the reference has no namespace, namespace is used.
+func ParseSecretKeyRef(namespace, ref string) (*SecretKeyRef, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 2 {
+		parts = append([]string{namespace}, parts...)
+	}
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("secret reference '%v' should be in the form name/key", ref)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("secret reference '%v' should be in the form name/key", ref)
+		}
+	}
+
+	return &SecretKeyRef{
+		Namespace: parts[0],
+		Name:      parts[1],
+		Key:       parts[2],
+	}, nil
 }
 
 // IsValidParameter returns nil if the parameter is valid or an error otherwise
 func IsValidParameter(parameter Parameter) error {
+	if parameter.OptionsFrom != nil && (parameter.OptionsFrom.WorkflowTemplate == "" || parameter.OptionsFrom.Output == "") {
+		return fmt.Errorf("optionsFrom of parameter '%v' needs a workflowTemplate and an output", parameter.Name)
+	}
+
 	if parameter.Visibility == nil {
 		return nil
 	}
@@ -66,3 +66,19 @@ func TestParseParametersFromManifest(t *testing.T) {
 	// Make sure string values are correctly parsed
 	assert.Equal(t, *keyedParameters["extras"].Value, "none")
 }
+
+// TestParseSecretKeyRef makes sure references without a namespace use the given namespace
+func TestParseSecretKeyRef(t *testing.T) {
+	ref, err := ParseSecretKeyRef("onepanel", "api-keys/token")
+	assert.Nil(t, err)
+	assert.Equal(t, &SecretKeyRef{Namespace: "onepanel", Name: "api-keys", Key: "token"}, ref)
+
+	ref, err = ParseSecretKeyRef("onepanel", "other/api-keys/token")
+	assert.Nil(t, err)
+	assert.Equal(t, &SecretKeyRef{Namespace: "other", Name: "api-keys", Key: "token"}, ref)
+
+	for _, invalid := range []string{"", "api-keys", "api-keys/", "/token", "a/b/c/d"} {
+		_, err = ParseSecretKeyRef("onepanel", invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
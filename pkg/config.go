@@ -2,11 +2,20 @@ package v1
 
 import (
 	"encoding/base64"
+	"fmt"
 	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/ptr"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	yamlv3 "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/yaml"
+	"strconv"
+	"strings"
 )
 
 func (c *Client) getConfigMap(namespace, name string) (configMap *ConfigMap, err error) {
@@ -62,6 +71,269 @@ func (c *Client) GetDefaultConfig() (config *ConfigMap, err error) {
 	return
 }
 
+// GetNamespaceDefaultParameters returns the parameters set under the "defaultParameters" key of the onepanel config map in the namespace.
+// These are passed to every workflow executed in the namespace, unless the client sets a value for them.
+// If the config map or key does not exist, there are no default parameters.
+func (c *Client) GetNamespaceDefaultParameters(namespace string) (parameters []Parameter, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespaceDefaultParameters(namespace, configMap)
+}
+
+// parseNamespaceDefaultParameters parses the default parameters from the onepanel config map of the namespace, see GetNamespaceDefaultParameters
+func parseNamespaceDefaultParameters(namespace string, configMap *ConfigMap) (parameters []Parameter, err error) {
+	data, ok := configMap.Data["defaultParameters"]
+	if !ok {
+		return
+	}
+
+	if err = yaml.Unmarshal([]byte(data), &parameters); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("GetNamespaceDefaultParameters failed parsing default parameters.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace default parameters are not valid.")
+	}
+
+	return
+}
+
+// GetNamespaceWorkflowTTL returns the number of seconds finished workflows are kept in the namespace,
+// set under the "workflowTTLSecondsAfterFinished" key of the onepanel config map in the namespace.
+// If the config map or key does not exist, nil is returned and finished workflows are kept.
+func (c *Client) GetNamespaceWorkflowTTL(namespace string) (ttl *int32, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespaceWorkflowTTL(namespace, configMap)
+}
+
+// parseNamespaceWorkflowTTL parses the workflow TTL from the onepanel config map of the namespace, see GetNamespaceWorkflowTTL
+func parseNamespaceWorkflowTTL(namespace string, configMap *ConfigMap) (ttl *int32, err error) {
+	data, ok := configMap.Data["workflowTTLSecondsAfterFinished"]
+	if !ok {
+		return
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(data), 10, 32)
+	if err != nil || seconds < 0 {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Value":     data,
+		}).Error("GetNamespaceWorkflowTTL failed parsing the workflow TTL.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace workflow TTL is not valid.")
+	}
+
+	return ptr.Int32(int32(seconds)), nil
+}
+
+// GetNamespaceCostLabels returns the cost-center labels set under the "costLabels" key of the onepanel config map in the namespace.
+// Every workflow created in the namespace is labelled with them, so its usage can be charged back.
+// If the config map or key does not exist, there are no cost labels.
+func (c *Client) GetNamespaceCostLabels(namespace string) (labels map[string]string, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespaceCostLabels(namespace, configMap)
+}
+
+// parseNamespaceCostLabels parses the cost labels from the onepanel config map of the namespace, see GetNamespaceCostLabels
+func parseNamespaceCostLabels(namespace string, configMap *ConfigMap) (labels map[string]string, err error) {
+	data, ok := configMap.Data["costLabels"]
+	if !ok {
+		return
+	}
+
+	err = yaml.Unmarshal([]byte(data), &labels)
+	if err == nil {
+		err = metav1validation.ValidateLabels(labels, field.NewPath("costLabels")).ToAggregate()
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("GetNamespaceCostLabels failed parsing cost labels.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace cost labels are not valid.")
+	}
+
+	return
+}
+
+// GetNamespacePriorityClasses returns the names of the priority classes workflows can be created with,
+// set as a list under the "priorityClasses" key of the onepanel config map in the namespace.
+// If the config map or key does not exist, there are none.
+func (c *Client) GetNamespacePriorityClasses(namespace string) (priorityClasses []string, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespacePriorityClasses(namespace, configMap)
+}
+
+// parseNamespacePriorityClasses parses the priority classes from the onepanel config map of the namespace, see GetNamespacePriorityClasses
+func parseNamespacePriorityClasses(namespace string, configMap *ConfigMap) (priorityClasses []string, err error) {
+	data, ok := configMap.Data["priorityClasses"]
+	if !ok {
+		return
+	}
+
+	if err = yaml.Unmarshal([]byte(data), &priorityClasses); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("GetNamespacePriorityClasses failed parsing priority classes.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace priority classes are not valid.")
+	}
+
+	return
+}
+
+// GetNamespaceDefaultResources returns the resource requests and limits set under the "defaultResources" key of the onepanel config map in the namespace.
+// Containers of workflows created in the namespace get them for every resource they do not declare, see applyDefaultResources.
+// If the config map or key does not exist, nil is returned and there are no default resources.
+func (c *Client) GetNamespaceDefaultResources(namespace string) (resources *corev1.ResourceRequirements, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespaceDefaultResources(namespace, configMap)
+}
+
+// parseNamespaceDefaultResources parses the default resources from the onepanel config map of the namespace, see GetNamespaceDefaultResources
+func parseNamespaceDefaultResources(namespace string, configMap *ConfigMap) (resources *corev1.ResourceRequirements, err error) {
+	data, ok := configMap.Data["defaultResources"]
+	if !ok {
+		return
+	}
+
+	resources = &corev1.ResourceRequirements{}
+	err = yaml.Unmarshal([]byte(data), resources)
+	if err == nil {
+		err = validateDefaultResources(resources)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("GetNamespaceDefaultResources failed parsing default resources.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace default resources are not valid.")
+	}
+
+	return
+}
+
+// GetNamespacePricing returns the price of resources set under the "pricing" key of the onepanel config map in the namespace,
+// used to estimate the cost of workflow executions.
+// If the config map or key does not exist, nil is returned and costs are not estimated.
+func (c *Client) GetNamespacePricing(namespace string) (pricing *ResourcePricing, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	data, ok := configMap.Data["pricing"]
+	if !ok {
+		return
+	}
+
+	pricing = &ResourcePricing{}
+	err = yaml.Unmarshal([]byte(data), pricing)
+	if err == nil && (pricing.CPUHour < 0 || pricing.MemoryGBHour < 0) {
+		err = fmt.Errorf("prices can not be negative")
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("GetNamespacePricing failed parsing pricing.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace pricing is not valid.")
+	}
+
+	return
+}
+
+// namespaceManifestPlaceholders are the placeholders of workflow manifests that are replaced with the value
+// of the key of the onepanel config map in the namespace, see GetNamespaceManifestSubstitutions
+var namespaceManifestPlaceholders = map[string]string{
+	"{{.Host}}":   "ONEPANEL_HOST",
+	"{{.Domain}}": "ONEPANEL_DOMAIN",
+	"{{.FQDN}}":   "ONEPANEL_FQDN",
+	"{{.APIURL}}": "ONEPANEL_API_URL",
+}
+
+// GetNamespaceManifestSubstitutions returns the values the placeholders of workflow manifests are replaced with when workflows
+// are created in the namespace, from the onepanel config map in the namespace. See namespaceManifestPlaceholders.
+// Placeholders whose key is not set have no value. If the config map does not exist, there are no substitutions.
+func (c *Client) GetNamespaceManifestSubstitutions(namespace string) (substitutions map[string]string, err error) {
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	return parseNamespaceManifestSubstitutions(configMap), nil
+}
+
+// parseNamespaceManifestSubstitutions parses the manifest substitutions from the onepanel config map of the namespace, see GetNamespaceManifestSubstitutions
+func parseNamespaceManifestSubstitutions(configMap *ConfigMap) (substitutions map[string]string) {
+	substitutions = make(map[string]string)
+	for placeholder, key := range namespaceManifestPlaceholders {
+		if value, ok := configMap.Data[key]; ok {
+			substitutions[placeholder] = value
+		}
+	}
+
+	return
+}
+
+// validateDefaultResources makes sure the quantities of resources are not negative and that no request is above the limit of the same resource
+func validateDefaultResources(resources *corev1.ResourceRequirements) error {
+	for name, quantity := range resources.Requests {
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("request of %v is negative", name)
+		}
+		if limit, ok := resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			return fmt.Errorf("request of %v is above the limit", name)
+		}
+	}
+	for name, quantity := range resources.Limits {
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("limit of %v is negative", name)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) GetNamespaceConfig(namespace string) (config *NamespaceConfig, err error) {
 	configMap, err := c.getConfigMap(namespace, "onepanel")
 	if err != nil {
@@ -73,6 +345,7 @@ func (c *Client) GetNamespaceConfig(namespace string) (config *NamespaceConfig,
 	}
 	config = &NamespaceConfig{
 		ArtifactRepository: ArtifactRepositoryProvider{},
+		configMap:          configMap,
 	}
 
 	err = yaml.Unmarshal([]byte(configMap.Data["artifactRepository"]), &config.ArtifactRepository)
@@ -108,3 +381,137 @@ func (c *Client) GetNamespaceConfig(namespace string) (config *NamespaceConfig,
 
 	return
 }
+
+// defaultArtifactRepositoryKeyFormat is the key format of artifact repositories that are set without one
+const defaultArtifactRepositoryKeyFormat = "artifacts/{{workflow.namespace}}/{{workflow.name}}/{{pod.name}}"
+
+// GetArtifactRepositoryConfig returns the artifact repository set under the "artifactRepository" key of the onepanel config map in the namespace.
+// Workflows created in the namespace store their artifacts in it.
+// Only the secrets holding the credentials are referenced, the credentials are not returned.
+func (c *Client) GetArtifactRepositoryConfig(namespace string) (config *ArtifactRepositoryProvider, err error) {
+	configMap, err := c.getConfigMap(namespace, namespaceConfigName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewUserError(codes.NotFound, "Artifact repository config not found.")
+		}
+		return
+	}
+
+	config = &ArtifactRepositoryProvider{}
+	err = yaml.Unmarshal([]byte(configMap.Data["artifactRepository"]), config)
+	if err != nil || (config.S3 == nil && config.GCS == nil) {
+		return nil, util.NewUserError(codes.NotFound, "Artifact repository config not found.")
+	}
+
+	if config.S3 != nil {
+		config.S3.AccessKey = ""
+		config.S3.Secretkey = ""
+	}
+	if config.GCS != nil {
+		config.GCS.ServiceAccountKey = ""
+		config.GCS.ServiceAccountJSON = ""
+	}
+
+	return
+}
+
+// SetArtifactRepositoryConfig sets the artifact repository of the namespace under the "artifactRepository" key of its onepanel config map.
+// Only references to the secrets holding the credentials are stored, any credential set in config is dropped.
+// If config has no key format, defaultArtifactRepositoryKeyFormat is used.
+// Settings of the current artifact repository that config does not have, like archiveLogs, are kept.
+func (c *Client) SetArtifactRepositoryConfig(namespace string, config *ArtifactRepositoryProvider) error {
+	if err := validateArtifactRepositoryConfig(config); err != nil {
+		return err
+	}
+
+	configMap, err := c.CoreV1().ConfigMaps(namespace).Get(namespaceConfigName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return util.NewUserError(codes.NotFound, "Namespace config not found.")
+		}
+		return err
+	}
+
+	current := &ArtifactRepositoryProvider{}
+	if data, ok := configMap.Data["artifactRepository"]; ok {
+		if err := yaml.Unmarshal([]byte(data), current); err != nil {
+			current = &ArtifactRepositoryProvider{}
+		}
+	}
+
+	artifactRepository := &ArtifactRepositoryProvider{
+		ArchiveLogs: config.ArchiveLogs,
+	}
+	if artifactRepository.ArchiveLogs == nil {
+		artifactRepository.ArchiveLogs = current.ArchiveLogs
+	}
+	if config.S3 != nil {
+		s3 := *config.S3
+		s3.AccessKey = ""
+		s3.Secretkey = ""
+		if s3.KeyFormat == "" {
+			s3.KeyFormat = defaultArtifactRepositoryKeyFormat
+		}
+		artifactRepository.S3 = &s3
+	}
+	if config.GCS != nil {
+		gcs := *config.GCS
+		gcs.ServiceAccountKey = ""
+		gcs.ServiceAccountJSON = ""
+		if gcs.KeyFormat == "" {
+			gcs.KeyFormat = defaultArtifactRepositoryKeyFormat
+		}
+		artifactRepository.GCS = &gcs
+	}
+
+	data, err := yamlv3.Marshal(artifactRepository)
+	if err != nil {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data["artifactRepository"] = string(data)
+
+	if _, err := c.CoreV1().ConfigMaps(namespace).Update(configMap); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Error":     err.Error(),
+		}).Error("SetArtifactRepositoryConfig failed updating config map.")
+		return util.NewUserError(codes.Internal, "Unable to set artifact repository config.")
+	}
+
+	return nil
+}
+
+// validateArtifactRepositoryConfig makes sure exactly one of S3 or GCS is set, with a bucket and the secrets holding its credentials
+func validateArtifactRepositoryConfig(config *ArtifactRepositoryProvider) error {
+	if config == nil || (config.S3 == nil) == (config.GCS == nil) {
+		return util.NewUserError(codes.InvalidArgument, "Exactly one of s3 or gcs must be set.")
+	}
+
+	validSecret := func(secret ArtifactRepositorySecret) bool {
+		return secret.Name != "" && secret.Key != ""
+	}
+
+	if config.S3 != nil {
+		if config.S3.Bucket == "" || config.S3.Endpoint == "" {
+			return util.NewUserError(codes.InvalidArgument, "The s3 bucket and endpoint are required.")
+		}
+		if !validSecret(config.S3.AccessKeySecret) || !validSecret(config.S3.SecretKeySecret) {
+			return util.NewUserError(codes.InvalidArgument, "The s3 access key and secret key secrets are required.")
+		}
+	}
+
+	if config.GCS != nil {
+		if config.GCS.Bucket == "" {
+			return util.NewUserError(codes.InvalidArgument, "The gcs bucket is required.")
+		}
+		if !validSecret(config.GCS.ServiceAccountKeySecret) {
+			return util.NewUserError(codes.InvalidArgument, "The gcs service account key secret is required.")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const artifactKeyWorkflowTemplate = `entrypoint: main
+templates:
+- name: main
+  container:
+    image: alpine:latest
+    command: [sh, -c]
+    args: ["echo hello > /tmp/output.txt"]
+  outputs:
+    artifacts:
+    - name: output
+      path: /tmp/output.txt
+      s3:
+        key: outputs/output.txt
+`
+
+// Test_validateArtifactRepositoryConfig makes sure exactly one provider is set, with a bucket and the secrets of its credentials
+func Test_validateArtifactRepositoryConfig(t *testing.T) {
+	secret := ArtifactRepositorySecret{Name: "onepanel", Key: "key"}
+
+	assert.Nil(t, validateArtifactRepositoryConfig(&ArtifactRepositoryProvider{
+		S3: &ArtifactRepositoryS3Provider{Bucket: "bucket", Endpoint: "s3.amazonaws.com", AccessKeySecret: secret, SecretKeySecret: secret},
+	}))
+	assert.Nil(t, validateArtifactRepositoryConfig(&ArtifactRepositoryProvider{
+		GCS: &ArtifactRepositoryGCSProvider{Bucket: "bucket", ServiceAccountKeySecret: secret},
+	}))
+
+	invalid := []*ArtifactRepositoryProvider{
+		nil,
+		{},
+		{
+			S3:  &ArtifactRepositoryS3Provider{Bucket: "bucket", Endpoint: "s3.amazonaws.com", AccessKeySecret: secret, SecretKeySecret: secret},
+			GCS: &ArtifactRepositoryGCSProvider{Bucket: "bucket", ServiceAccountKeySecret: secret},
+		},
+		{S3: &ArtifactRepositoryS3Provider{Endpoint: "s3.amazonaws.com", AccessKeySecret: secret, SecretKeySecret: secret}},
+		{S3: &ArtifactRepositoryS3Provider{Bucket: "bucket", Endpoint: "s3.amazonaws.com", AccessKeySecret: secret}},
+		{GCS: &ArtifactRepositoryGCSProvider{ServiceAccountKeySecret: secret}},
+		{GCS: &ArtifactRepositoryGCSProvider{Bucket: "bucket", ServiceAccountKeySecret: ArtifactRepositorySecret{Name: "onepanel"}}},
+	}
+	for _, config := range invalid {
+		assertUserErrorCode(t, validateArtifactRepositoryConfig(config), codes.InvalidArgument)
+	}
+}
+
+// TestClient_SetArtifactRepositoryConfig makes sure the config is stored without credentials, keeps archiveLogs,
+// and is injected into the artifacts of workflows created afterwards
+func TestClient_SetArtifactRepositoryConfig(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	err := c.SetArtifactRepositoryConfig(namespace, &ArtifactRepositoryProvider{
+		S3: &ArtifactRepositoryS3Provider{
+			Bucket:          "my-bucket",
+			Endpoint:        "minio.example.com",
+			Region:          "us-east-1",
+			AccessKeySecret: ArtifactRepositorySecret{Name: "onepanel", Key: "accessKey"},
+			SecretKeySecret: ArtifactRepositorySecret{Name: "onepanel", Key: "secretKey"},
+			AccessKey:       "access",
+			Secretkey:       "secret",
+		},
+	})
+	assert.Nil(t, err)
+
+	configMap, err := c.CoreV1().ConfigMaps(namespace).Get(namespaceConfigName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, configMap.Data["artifactRepository"], "accessKey: ")
+	assert.NotContains(t, configMap.Data["artifactRepository"], "secretKey: ")
+	assert.Contains(t, configMap.Data["artifactRepository"], "archiveLogs: true")
+
+	config, err := c.GetArtifactRepositoryConfig(namespace)
+	assert.Nil(t, err)
+	assert.Nil(t, config.GCS)
+	assert.Equal(t, "my-bucket", config.S3.Bucket)
+	assert.Equal(t, "minio.example.com", config.S3.Endpoint)
+	assert.Equal(t, defaultArtifactRepositoryKeyFormat, config.S3.KeyFormat)
+	assert.Equal(t, "accessKey", config.S3.AccessKeySecret.Key)
+	assert.Empty(t, config.S3.AccessKey)
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: artifactKeyWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "artifactRepository", wf.Spec.ArtifactRepositoryRef.Key)
+
+	artifact := wf.Spec.Templates[0].Outputs.Artifacts[0]
+	assert.Equal(t, "outputs/output.txt", artifact.S3.Key)
+	assert.Equal(t, "my-bucket", artifact.S3.Bucket)
+	assert.Equal(t, "minio.example.com", artifact.S3.Endpoint)
+	assert.Equal(t, "accessKey", artifact.S3.AccessKeySecret.Key)
+}
+
+// TestClient_SetArtifactRepositoryConfig_NotFound makes sure the config can not be set in a namespace without a config map
+func TestClient_SetArtifactRepositoryConfig_NotFound(t *testing.T) {
+	c := DefaultTestClient()
+
+	err := c.SetArtifactRepositoryConfig("other", &ArtifactRepositoryProvider{
+		GCS: &ArtifactRepositoryGCSProvider{
+			Bucket:                  "my-bucket",
+			ServiceAccountKeySecret: ArtifactRepositorySecret{Name: "onepanel", Key: "serviceAccountKey"},
+		},
+	})
+	assertUserErrorCode(t, err, codes.NotFound)
+
+	_, err = c.GetArtifactRepositoryConfig("other")
+	assertUserErrorCode(t, err, codes.NotFound)
+}
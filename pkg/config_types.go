@@ -232,8 +232,9 @@ type ArtifactRepositoryGCSProvider struct {
 // Right now, either the S3 or GCS struct will be filled in. Multiple cloud
 // providers are not supported at the same time in params.yaml (manifests deployment).
 type ArtifactRepositoryProvider struct {
-	S3  *ArtifactRepositoryS3Provider  `yaml:"s3,omitempty"`
-	GCS *ArtifactRepositoryGCSProvider `yaml:"gcs,omitempty"`
+	ArchiveLogs *bool                          `yaml:"archiveLogs,omitempty"`
+	S3          *ArtifactRepositoryS3Provider  `yaml:"s3,omitempty"`
+	GCS         *ArtifactRepositoryGCSProvider `yaml:"gcs,omitempty"`
 }
 
 // ArtifactRepositorySecret holds information about a kubernetes Secret.
@@ -334,4 +335,6 @@ func (g *ArtifactRepositoryGCSProvider) FormatKey(namespace, workflowName, podNa
 
 type NamespaceConfig struct {
 	ArtifactRepository ArtifactRepositoryProvider
+	// configMap is the onepanel config map of the namespace, so the rest of its settings are parsed without loading it again
+	configMap *ConfigMap
 }
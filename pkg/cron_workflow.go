@@ -36,7 +36,6 @@ func (c *Client) UpdateCronWorkflow(namespace string, uid string, cronWorkflow *
 		return nil, util.NewUserError(codes.NotFound, "Error with getting workflow template.")
 	}
 
-	// TODO: Need to pull system parameters from k8s config/secret here, example: HOST
 	opts := &WorkflowExecutionOptions{}
 	opts.GenerateName, err = uid2.GenerateUID(workflowTemplate.Name, 63)
 	if err != nil {
@@ -50,6 +49,12 @@ func (c *Client) UpdateCronWorkflow(namespace string, uid string, cronWorkflow *
 		})
 	}
 
+	defaultParameters, err := c.GetNamespaceDefaultParameters(namespace)
+	if err != nil {
+		return nil, err
+	}
+	opts.Parameters = mergeDefaultParameters(opts.Parameters, defaultParameters)
+
 	if err := workflowTemplate.ReplaceManifestParameters(workflow.Parameters); err != nil {
 		return nil, err
 	}
@@ -131,7 +136,6 @@ func (c *Client) CreateCronWorkflow(namespace string, cronWorkflow *CronWorkflow
 		return nil, util.NewUserError(codes.NotFound, "Error with getting workflow template.")
 	}
 
-	// TODO: Need to pull system parameters from k8s config/secret here, example: HOST
 	opts := &WorkflowExecutionOptions{
 		Labels: make(map[string]string),
 	}
@@ -147,6 +151,12 @@ func (c *Client) CreateCronWorkflow(namespace string, cronWorkflow *CronWorkflow
 		})
 	}
 
+	defaultParameters, err := c.GetNamespaceDefaultParameters(namespace)
+	if err != nil {
+		return nil, err
+	}
+	opts.Parameters = mergeDefaultParameters(opts.Parameters, defaultParameters)
+
 	if err := workflowTemplate.ReplaceManifestParameters(workflow.Parameters); err != nil {
 		return nil, err
 	}
@@ -381,7 +391,11 @@ func (c *Client) buildCronWorkflowDefinition(namespace string, workflowTemplateI
 	if err != nil {
 		return nil, err
 	}
-	if err = c.injectAutomatedFields(namespace, wf, opts); err != nil {
+	namespaceConfig, err := c.GetNamespaceConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.injectAutomatedFields(namespace, namespaceConfig, wf, opts); err != nil {
 		return nil, err
 	}
 
@@ -4,13 +4,55 @@ import (
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/pkg/util"
 	"github.com/onepanelio/core/pkg/util/label"
 	"github.com/onepanelio/core/pkg/util/mapping"
 	"github.com/onepanelio/core/pkg/util/types"
+	"google.golang.org/grpc/codes"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sort"
 	"strings"
 )
 
+// reservedLabelKeyPrefixes are the prefixes of label keys set by onepanel, argo and kubernetes, which clients can not set
+var reservedLabelKeyPrefixes = []string{
+	label.OnepanelPrefix,
+	label.TagPrefix,
+	"workflows.argoproj.io/",
+	"kubernetes.io/",
+	"k8s.io/",
+}
+
+// validateLabels makes sure the labels passed by a client have no reserved keys and are valid kubernetes labels
+// once their keys are prefixed with label.TagPrefix, which is how they are added to resources.
+// The error is a codes.InvalidArgument error with the first invalid key, in key order.
+func validateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, prefix := range reservedLabelKeyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Label key '%v' is reserved.", key))
+			}
+		}
+
+		if errs := validation.IsQualifiedName(label.TagPrefix + key); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Label key '%v' is not valid: %v", key, strings.Join(errs, "; ")))
+		}
+
+		if errs := validation.IsValidLabelValue(labels[key]); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Label value of key '%v' is not valid: %v", key, strings.Join(errs, "; ")))
+		}
+	}
+
+	return nil
+}
+
 // SelectLabelsQuery represents the options available to filter a select labels query
 type SelectLabelsQuery struct {
 	Table     string
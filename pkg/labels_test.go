@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// Test_validateLabels makes sure labels with reserved or invalid keys, or invalid values, are rejected with the key
+func Test_validateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		invalid string // key the error is expected to mention, empty if the labels are valid
+	}{
+		{"none", nil, ""},
+		{"valid", map[string]string{"stage": "dev", "dataset-version": "v1.2_3", "empty": ""}, ""},
+		{"onepanel key", map[string]string{"onepanel.io/workflow-template-uid": "test"}, "onepanel.io/workflow-template-uid"},
+		{"tag key", map[string]string{"tags.onepanel.io/stage": "dev"}, "tags.onepanel.io/stage"},
+		{"argo key", map[string]string{"workflows.argoproj.io/phase": "Running"}, "workflows.argoproj.io/phase"},
+		{"kubernetes key", map[string]string{"kubernetes.io/hostname": "node"}, "kubernetes.io/hostname"},
+		{"empty key", map[string]string{"": "dev"}, "''"},
+		{"invalid key characters", map[string]string{"stage!": "dev"}, "stage!"},
+		{"key with prefix", map[string]string{"example.com/stage": "dev"}, "example.com/stage"},
+		{"too long key", map[string]string{strings.Repeat("a", 64): "dev"}, strings.Repeat("a", 64)},
+		{"invalid value characters", map[string]string{"stage": "dev env"}, "stage"},
+		{"too long value", map[string]string{"stage": strings.Repeat("a", 64)}, "stage"},
+		{"first invalid key", map[string]string{"b!": "dev", "a!": "dev"}, "a!"},
+	}
+
+	for _, test := range tests {
+		err := validateLabels(test.labels)
+		if test.invalid == "" {
+			assert.Nil(t, err, test.name)
+			continue
+		}
+
+		userErr, ok := err.(*util.UserError)
+		if !assert.True(t, ok, test.name) {
+			continue
+		}
+		assert.Equal(t, codes.InvalidArgument, userErr.Code, test.name)
+		assert.Contains(t, userErr.Message, test.invalid, test.name)
+	}
+}
+
+// TestClient_CreateWorkflowExecution_InvalidLabels makes sure workflow executions with invalid labels are not created
+func TestClient_CreateWorkflowExecution_InvalidLabels(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name:   "test",
+		Labels: map[string]string{"onepanel.io/workflow-template-uid": "other"},
+	}, wt)
+	userErr, ok := err.(*util.UserError)
+	if assert.True(t, ok) {
+		assert.Equal(t, codes.InvalidArgument, userErr.Code)
+		assert.Equal(t, "Label key 'onepanel.io/workflow-template-uid' is reserved.", userErr.Message)
+	}
+
+	count, err := c.CountWorkflowExecutions(namespace, wt.UID, "", false, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,307 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/env"
+	"github.com/onepanelio/core/pkg/util/metrics"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogBackpressurePolicy is what a log stream does with new log entries while its buffer is full,
+// because the consumer reads them slower than they are produced
+type LogBackpressurePolicy string
+
+const (
+	// LogBackpressureBlock waits for the consumer to make room, until the client context is done
+	LogBackpressureBlock LogBackpressurePolicy = "block"
+	// LogBackpressureDrop drops the log entry, counting its lines in the dropped log lines metric
+	LogBackpressureDrop LogBackpressurePolicy = "drop"
+)
+
+// fallbackLogStreamBufferSize is the buffer size if LOG_STREAM_BUFFER_SIZE is not set
+const fallbackLogStreamBufferSize = 256
+
+// fallbackLogStreamHeartbeatInterval is the heartbeat interval if LOG_STREAM_HEARTBEAT_SECONDS is not set
+const fallbackLogStreamHeartbeatInterval = 15 * time.Second
+
+// LogStreamOptions configures how log entries are buffered between the log stream and its consumer
+type LogStreamOptions struct {
+	BufferSize int // how many log entries are buffered
+	Policy     LogBackpressurePolicy
+	// HeartbeatInterval is how long the stream can go without log entries before a heartbeat log entry is sent,
+	// so consumers know it is still alive. Heartbeats are not sent if it is not positive.
+	HeartbeatInterval time.Duration
+}
+
+// defaultLogStreamOptions buffers LOG_STREAM_BUFFER_SIZE log entries and applies the LOG_STREAM_BACKPRESSURE_POLICY
+// once the buffer is full, blocking by default. A heartbeat is sent after LOG_STREAM_HEARTBEAT_SECONDS without logs.
+var defaultLogStreamOptions = newLogStreamOptions(
+	env.GetEnv("LOG_STREAM_BUFFER_SIZE", ""),
+	env.GetEnv("LOG_STREAM_BACKPRESSURE_POLICY", ""),
+	env.GetEnv("LOG_STREAM_HEARTBEAT_SECONDS", ""),
+)
+
+// newLogStreamOptions parses the configured buffer size, policy and heartbeat seconds. Invalid values use
+// fallbackLogStreamBufferSize, LogBackpressureBlock or fallbackLogStreamHeartbeatInterval respectively.
+// A heartbeat of 0 seconds turns heartbeats off.
+func newLogStreamOptions(bufferSizeValue, policyValue, heartbeatSecondsValue string) LogStreamOptions {
+	options := LogStreamOptions{
+		BufferSize:        fallbackLogStreamBufferSize,
+		Policy:            LogBackpressureBlock,
+		HeartbeatInterval: fallbackLogStreamHeartbeatInterval,
+	}
+
+	if bufferSizeValue != "" {
+		parsed, err := strconv.Atoi(bufferSizeValue)
+		if err != nil || parsed <= 0 {
+			log.WithFields(log.Fields{
+				"Value": bufferSizeValue,
+			}).Warn("Invalid LOG_STREAM_BUFFER_SIZE, using the fallback buffer size.")
+		} else {
+			options.BufferSize = parsed
+		}
+	}
+
+	switch policy := LogBackpressurePolicy(policyValue); policy {
+	case "", LogBackpressureBlock:
+	case LogBackpressureDrop:
+		options.Policy = policy
+	default:
+		log.WithFields(log.Fields{
+			"Value": policyValue,
+		}).Warn("Invalid LOG_STREAM_BACKPRESSURE_POLICY, log streams block on slow consumers.")
+	}
+
+	if heartbeatSecondsValue != "" {
+		parsed, err := strconv.Atoi(heartbeatSecondsValue)
+		if err != nil || parsed < 0 {
+			log.WithFields(log.Fields{
+				"Value": heartbeatSecondsValue,
+			}).Warn("Invalid LOG_STREAM_HEARTBEAT_SECONDS, using the fallback heartbeat interval.")
+		} else {
+			options.HeartbeatInterval = time.Duration(parsed) * time.Second
+		}
+	}
+
+	return options
+}
+
+// WithLogStreamOptions returns a shallow copy of the client that streams logs with options
+// instead of the ones configured from the environment
+func (c *Client) WithLogStreamOptions(options LogStreamOptions) *Client {
+	client := *c
+	client.logStreamOptions = &options
+
+	return &client
+}
+
+// getLogStreamOptions returns the log stream options of the client, or the ones configured from the environment
+func (c *Client) getLogStreamOptions() LogStreamOptions {
+	if c.logStreamOptions == nil {
+		return defaultLogStreamOptions
+	}
+
+	return *c.logStreamOptions
+}
+
+// ParseLogSinceDuration returns the time the duration, like 10m, is ago, to stream only the logs of the last duration.
+// A codes.InvalidArgument error is returned if duration is not a positive duration.
+func ParseLogSinceDuration(duration string) (time.Time, error) {
+	parsed, err := time.ParseDuration(duration)
+	if err != nil || parsed <= 0 {
+		return time.Time{}, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Invalid since duration '%v', use a positive duration like 10m.", duration))
+	}
+
+	return time.Now().UTC().Add(-parsed), nil
+}
+
+// sinceLogReader reads the timestamped log lines of a stream, skipping the ones logged before since.
+// Lines without a timestamp are kept if the line before them was, and lines before the first timestamp are kept.
+type sinceLogReader struct {
+	stream  io.ReadCloser
+	reader  *bufio.Reader
+	since   time.Time
+	pending []byte
+	keep    bool
+}
+
+// newSinceLogReader returns a reader of the lines of stream logged at or after since
+func newSinceLogReader(stream io.ReadCloser, since time.Time) io.ReadCloser {
+	return &sinceLogReader{
+		stream: stream,
+		reader: bufio.NewReader(stream),
+		since:  since,
+		keep:   true,
+	}
+}
+
+func (r *sinceLogReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if end := bytes.IndexByte(line, ' '); end > 0 {
+				if timestamp, parseErr := time.Parse(time.RFC3339, string(line[:end])); parseErr == nil {
+					r.keep = !timestamp.Before(r.since)
+				}
+			}
+			if r.keep {
+				r.pending = line
+			}
+		}
+
+		if err != nil {
+			// A kept line is read first, the error is returned by the next read
+			if len(r.pending) == 0 {
+				return 0, err
+			}
+			break
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+func (r *sinceLogReader) Close() error {
+	return r.stream.Close()
+}
+
+// countLogLines returns the number of lines content has, counting a trailing partial line
+func countLogLines(content string) int {
+	return strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+}
+
+// streamLogEntries reads the timestamped logs of stream into log entries, sending them to the returned channel.
+// The channel buffers options.BufferSize entries, after which options.Policy decides whether the reading waits
+// for the consumer or drops entries. If no log entry is read for options.HeartbeatInterval, a heartbeat log entry
+// is sent instead. The channel is closed and the stream is closed once the stream ends, fails, or ctx is done.
+func streamLogEntries(ctx context.Context, stream io.ReadCloser, options LogStreamOptions) <-chan *LogEntry {
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = fallbackLogStreamBufferSize
+	}
+	logWatcher := make(chan *LogEntry, bufferSize)
+
+	// send returns false if ctx is done and the reading should stop
+	send := func(le *LogEntry) bool {
+		if options.Policy == LogBackpressureDrop {
+			select {
+			case logWatcher <- le:
+			case <-ctx.Done():
+				return false
+			default:
+				if !le.Heartbeat {
+					metrics.AddDroppedLogLines(countLogLines(le.Content))
+				}
+			}
+
+			return true
+		}
+
+		select {
+		case logWatcher <- le:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// The stream is read separately, so heartbeats can be sent while reading waits for logs
+	entries := make(chan *LogEntry)
+	done := make(chan struct{})
+	go func() {
+		defer close(entries)
+
+		buffer := make([]byte, 4096)
+		reader := bufio.NewReader(stream)
+
+		newLine := true
+		for {
+			bytesRead, err := reader.Read(buffer)
+			if err != nil && err != io.EOF {
+				break
+			}
+			content := string(buffer[:bytesRead])
+
+			le := &LogEntry{Content: content}
+			if newLine {
+				parts := strings.Split(content, " ")
+				if timestamp, err := time.Parse(time.RFC3339, parts[0]); err == nil {
+					le = &LogEntry{
+						Timestamp: timestamp,
+						Content:   strings.Join(parts[1:], " "),
+					}
+				}
+			}
+
+			if bytesRead > 0 {
+				select {
+				case entries <- le:
+				case <-done:
+					return
+				}
+			}
+
+			if err == io.EOF {
+				break
+			}
+
+			newLine = strings.Contains(content, "\n")
+		}
+	}()
+
+	go func() {
+		defer close(logWatcher)
+		// Closing the stream stops a read waiting for logs, and the reading is done once entries is closed
+		defer func() {
+			close(done)
+			stream.Close()
+			for range entries {
+			}
+		}()
+
+		// A nil heartbeat channel never fires, so no heartbeats are sent
+		var heartbeat <-chan time.Time
+		var heartbeatTimer *time.Timer
+		if options.HeartbeatInterval > 0 {
+			heartbeatTimer = time.NewTimer(options.HeartbeatInterval)
+			defer heartbeatTimer.Stop()
+			heartbeat = heartbeatTimer.C
+		}
+
+		for {
+			select {
+			case le, ok := <-entries:
+				if !ok || !send(le) {
+					return
+				}
+				if heartbeatTimer != nil {
+					if !heartbeatTimer.Stop() {
+						<-heartbeatTimer.C
+					}
+					heartbeatTimer.Reset(options.HeartbeatInterval)
+				}
+			case <-heartbeat:
+				if !send(&LogEntry{Timestamp: time.Now().UTC(), Heartbeat: true}) {
+					return
+				}
+				heartbeatTimer.Reset(options.HeartbeatInterval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return logWatcher
+}
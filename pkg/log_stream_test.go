@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lineLogStream returns one timestamped log line per read, like a followed pod log stream
+type lineLogStream struct {
+	lines  int
+	read   int
+	mutex  sync.Mutex
+	closed bool
+}
+
+func (s *lineLogStream) Read(p []byte) (n int, err error) {
+	if s.read == s.lines {
+		return 0, io.EOF
+	}
+	s.read++
+
+	return copy(p, fmt.Sprintf("2020-09-01T10:00:00Z line %v\n", s.read)), nil
+}
+
+func (s *lineLogStream) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+
+	return nil
+}
+
+func (s *lineLogStream) isClosed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.closed
+}
+
+// blockingLogStream never returns from a read until it is closed
+type blockingLogStream struct {
+	done chan struct{}
+}
+
+func (s *blockingLogStream) Read(p []byte) (n int, err error) {
+	<-s.done
+	return 0, io.EOF
+}
+
+func (s *blockingLogStream) Close() error {
+	return nil
+}
+
+// waitClosed drains logWatcher, waiting at most timeout for it to be closed, and returns the entries read
+func waitClosed(t *testing.T, logWatcher <-chan *LogEntry, timeout time.Duration) (entries []*LogEntry) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case le, ok := <-logWatcher:
+			if !ok {
+				return
+			}
+			entries = append(entries, le)
+		case <-deadline:
+			t.Fatal("log stream was not closed")
+			return
+		}
+	}
+}
+
+// Test_streamLogEntries_DropSlowConsumer makes sure the log stream is read to the end while the consumer is not reading,
+// dropping the log entries that do not fit the buffer
+func Test_streamLogEntries_DropSlowConsumer(t *testing.T) {
+	stream := &lineLogStream{lines: 100}
+	logWatcher := streamLogEntries(context.Background(), stream, LogStreamOptions{
+		BufferSize: 10,
+		Policy:     LogBackpressureDrop,
+	})
+
+	// The consumer does not read until the whole stream was produced
+	deadline := time.Now().Add(5 * time.Second)
+	for !stream.isClosed() {
+		if time.Now().After(deadline) {
+			t.Fatal("log stream producer is blocked by the consumer")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	entries := waitClosed(t, logWatcher, time.Second)
+	assert.Len(t, entries, 10)
+	assert.Equal(t, "line 1\n", entries[0].Content)
+	assert.Equal(t, time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC), entries[0].Timestamp.UTC())
+}
+
+// Test_streamLogEntries_BlockSlowConsumer makes sure a slow consumer gets every log entry
+func Test_streamLogEntries_BlockSlowConsumer(t *testing.T) {
+	stream := &lineLogStream{lines: 20}
+	logWatcher := streamLogEntries(context.Background(), stream, LogStreamOptions{
+		BufferSize: 2,
+		Policy:     LogBackpressureBlock,
+	})
+
+	var entries []*LogEntry
+	for le := range logWatcher {
+		time.Sleep(time.Millisecond)
+		entries = append(entries, le)
+	}
+
+	assert.Len(t, entries, 20)
+	assert.Equal(t, "line 20\n", entries[19].Content)
+	assert.True(t, stream.isClosed())
+}
+
+// Test_streamLogEntries_BlockContextDone makes sure a producer blocked by a consumer that stopped reading
+// stops once the context is done
+func Test_streamLogEntries_BlockContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream := &lineLogStream{lines: 1000}
+	logWatcher := streamLogEntries(ctx, stream, LogStreamOptions{
+		BufferSize: 1,
+		Policy:     LogBackpressureBlock,
+	})
+
+	le := <-logWatcher
+	assert.Equal(t, "line 1\n", le.Content)
+
+	cancel()
+	waitClosed(t, logWatcher, 5*time.Second)
+	assert.True(t, stream.isClosed())
+	assert.True(t, stream.read < 1000)
+}
+
+// Test_streamLogEntries_NoLogs makes sure the channel is only closed once the stream ends
+func Test_streamLogEntries_NoLogs(t *testing.T) {
+	stream := &blockingLogStream{done: make(chan struct{})}
+	logWatcher := streamLogEntries(context.Background(), stream, LogStreamOptions{})
+
+	select {
+	case <-logWatcher:
+		t.Fatal("log stream closed before the stream ended")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(stream.done)
+	assert.Len(t, waitClosed(t, logWatcher, time.Second), 0)
+}
+
+// Test_streamLogEntries_Heartbeat makes sure heartbeats are sent while there are no logs, and not while logs flow
+func Test_streamLogEntries_Heartbeat(t *testing.T) {
+	reader, writer := io.Pipe()
+	logWatcher := streamLogEntries(context.Background(), reader, LogStreamOptions{
+		BufferSize:        10,
+		HeartbeatInterval: 50 * time.Millisecond,
+	})
+
+	select {
+	case le := <-logWatcher:
+		assert.True(t, le.Heartbeat)
+		assert.Empty(t, le.Content)
+	case <-time.After(time.Second):
+		t.Fatal("no heartbeat was sent during the log gap")
+	}
+
+	go func() {
+		for i := 1; i <= 20; i++ {
+			fmt.Fprintf(writer, "2020-09-01T10:00:00Z line %v\n", i)
+			time.Sleep(5 * time.Millisecond)
+		}
+		writer.Close()
+	}()
+
+	// Heartbeats sent before the first log line are skipped, none should be sent once logs flow
+	logged := false
+	for le := range logWatcher {
+		if !le.Heartbeat {
+			logged = true
+			continue
+		}
+		assert.False(t, logged, "heartbeat sent while logs flow")
+	}
+	assert.True(t, logged)
+}
+
+func Test_newLogStreamOptions(t *testing.T) {
+	assert.Equal(t, LogStreamOptions{BufferSize: fallbackLogStreamBufferSize, Policy: LogBackpressureBlock, HeartbeatInterval: fallbackLogStreamHeartbeatInterval}, newLogStreamOptions("", "", ""))
+	assert.Equal(t, LogStreamOptions{BufferSize: 16, Policy: LogBackpressureDrop, HeartbeatInterval: 5 * time.Second}, newLogStreamOptions("16", "drop", "5"))
+	assert.Equal(t, LogStreamOptions{BufferSize: fallbackLogStreamBufferSize, Policy: LogBackpressureBlock, HeartbeatInterval: fallbackLogStreamHeartbeatInterval}, newLogStreamOptions("-1", "sometimes", "often"))
+	assert.Equal(t, time.Duration(0), newLogStreamOptions("", "", "0").HeartbeatInterval)
+}
+
+func Test_countLogLines(t *testing.T) {
+	assert.Equal(t, 1, countLogLines("one\n"))
+	assert.Equal(t, 2, countLogLines("one\ntwo"))
+	assert.Equal(t, 3, countLogLines("one\ntwo\nthree\n"))
+}
+
+func TestParseLogSinceDuration(t *testing.T) {
+	since, err := ParseLogSinceDuration("10m")
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now().Add(-10*time.Minute), since, time.Second)
+
+	for _, duration := range []string{"", "10", "ten minutes", "-10m", "0s"} {
+		_, err := ParseLogSinceDuration(duration)
+		userErr, ok := err.(*util.UserError)
+		if assert.True(t, ok, duration) {
+			assert.Equal(t, codes.InvalidArgument, userErr.Code)
+		}
+	}
+}
+
+// Test_newSinceLogReader makes sure the lines logged before since are skipped, along with the lines without
+// a timestamp that follow them
+func Test_newSinceLogReader(t *testing.T) {
+	logs := `starting
+2020-09-01T10:00:00Z old line
+continued old line
+2020-09-01T10:10:00.123456789Z recent line
+continued recent line
+2020-09-01T10:05:00Z out of order line
+2020-09-01T10:15:00Z last line without a line break`
+
+	since := time.Date(2020, 9, 1, 10, 10, 0, 0, time.UTC)
+	reader := newSinceLogReader(ioutil.NopCloser(strings.NewReader(logs)), since)
+
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, `starting
+2020-09-01T10:10:00.123456789Z recent line
+continued recent line
+2020-09-01T10:15:00Z last line without a line break`, string(content))
+	assert.Nil(t, reader.Close())
+}
@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/env"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxMetricSeriesPoints is the most points a series can have, Prometheus rejects range queries with more than 11,000
+const maxMetricSeriesPoints = 11000
+
+// minMetricRateWindow is the shortest window rates, like cpu usage, are calculated over.
+// Prometheus scrapes about every 30 seconds, so shorter windows have too few samples.
+const minMetricRateWindow = time.Minute
+
+// MetricPoint is the value of a metric at a point in time
+type MetricPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// MetricSeries are the values of a metric over a time range, oldest first
+type MetricSeries struct {
+	Name   string
+	Format string
+	Points []*MetricPoint
+}
+
+// MetricsRange is the time range metrics are queried for, with a point every Step
+type MetricsRange struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// MetricsRangeSource queries the metrics backend for the values of a query over a time range
+type MetricsRangeSource interface {
+	QueryRange(query string, metricsRange MetricsRange) ([]*MetricPoint, error)
+}
+
+// prometheusRangeSource queries the range query API of Prometheus at url
+type prometheusRangeSource struct {
+	url    string
+	client *http.Client
+}
+
+// prometheusRangeResponse is the part of the response of the Prometheus range query API that is used
+type prometheusRangeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Values [][2]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// QueryRange runs the query over the range. The query is expected to return at most one series,
+// if there is none, there are no points.
+func (s *prometheusRangeSource) QueryRange(query string, metricsRange MetricsRange) ([]*MetricPoint, error) {
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("start", strconv.FormatInt(metricsRange.Start.Unix(), 10))
+	values.Set("end", strconv.FormatInt(metricsRange.End.Unix(), 10))
+	values.Set("step", strconv.FormatFloat(metricsRange.Step.Seconds(), 'f', -1, 64))
+
+	res, err := s.client.Get(s.url + "/api/v1/query_range?" + values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	response := &prometheusRangeResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %v", response.Error)
+	}
+
+	points := make([]*MetricPoint, 0)
+	if len(response.Data.Result) == 0 {
+		return points, nil
+	}
+
+	// Values are pairs of a unix timestamp in seconds and the value as a string
+	for _, value := range response.Data.Result[0].Values {
+		timestamp, ok := value[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid timestamp %v", value[0])
+		}
+		valueString, ok := value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v", value[1])
+		}
+		parsed, err := strconv.ParseFloat(valueString, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		seconds, fraction := math.Modf(timestamp)
+		points = append(points, &MetricPoint{
+			Timestamp: time.Unix(int64(seconds), int64(fraction*1e9)).UTC(),
+			Value:     parsed,
+		})
+	}
+
+	return points, nil
+}
+
+// WithMetricsRangeSource returns a shallow copy of the client that queries metrics over time from source instead of Prometheus
+func (c *Client) WithMetricsRangeSource(source MetricsRangeSource) *Client {
+	client := *c
+	client.metricsRangeSource = source
+
+	return &client
+}
+
+// getMetricsRangeSource returns the source metrics over time are queried from.
+// By default, that is the Prometheus at PROMETHEUS_URL. If it is not set, there is no source and nil is returned.
+func (c *Client) getMetricsRangeSource() MetricsRangeSource {
+	if c.metricsRangeSource != nil {
+		return c.metricsRangeSource
+	}
+
+	prometheusURL := env.GetEnv("PROMETHEUS_URL", "")
+	if prometheusURL == "" {
+		return nil
+	}
+
+	return &prometheusRangeSource{
+		url:    prometheusURL,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// validateMetricsRange makes sure the range ends after it starts and does not have too many points
+func validateMetricsRange(metricsRange MetricsRange) error {
+	if metricsRange.Step <= 0 {
+		return util.NewUserError(codes.InvalidArgument, "Step must be positive.")
+	}
+	if !metricsRange.End.After(metricsRange.Start) {
+		return util.NewUserError(codes.InvalidArgument, "End must be after start.")
+	}
+	if metricsRange.End.Sub(metricsRange.Start)/metricsRange.Step >= maxMetricSeriesPoints {
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("The range can have at most %v points, use a larger step.", maxMetricSeriesPoints))
+	}
+
+	return nil
+}
+
+// podMetricQuery is the Prometheus query of a metric of a pod
+type podMetricQuery struct {
+	name   string
+	format string
+	query  string
+}
+
+// podMetricQueries returns the queries of the metrics of the pod that can be queried over time.
+// Rates are calculated over the step, or minMetricRateWindow if the step is shorter.
+func podMetricQueries(namespace, podName string, step time.Duration) []podMetricQuery {
+	window := step
+	if window < minMetricRateWindow {
+		window = minMetricRateWindow
+	}
+	selector := fmt.Sprintf(`namespace=%q,pod=%q,container!="",container!="POD"`, namespace, podName)
+
+	return []podMetricQuery{
+		{"cpu", "%", fmt.Sprintf(`sum(rate(container_cpu_usage_seconds_total{%v}[%vs])) * 100`, selector, int64(window.Seconds()))},
+		{"memory", "bytes", fmt.Sprintf(`sum(container_memory_working_set_bytes{%v})`, selector)},
+	}
+}
+
+// GetWorkflowExecutionMetricsRange returns the cpu and memory usage of the pod of the workflow execution over the range,
+// a series per metric. A codes.Unavailable error is returned if there is no metrics backend to query.
+func (c *Client) GetWorkflowExecutionMetricsRange(namespace, uid, podName string, metricsRange MetricsRange) (series []*MetricSeries, err error) {
+	if err := validateMetricsRange(metricsRange); err != nil {
+		return nil, err
+	}
+
+	workflow, err := c.GetWorkflowExecution(namespace, uid)
+	if err != nil || workflow == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
+
+	// Argo names the pod of a node after the node's ID. Only pods of the workflow can be queried.
+	node, ok := workflow.ArgoWorkflow.Status.Nodes[podName]
+	if !ok || node.Type != wfv1.NodeTypePod {
+		return nil, util.NewUserError(codes.NotFound, "Pod not found.")
+	}
+
+	source := c.getMetricsRangeSource()
+	if source == nil {
+		return nil, util.NewUserError(codes.Unavailable, "Metrics over time are not available. Make sure PROMETHEUS_URL is set.")
+	}
+
+	for _, metric := range podMetricQueries(namespace, podName, metricsRange.Step) {
+		points, err := source.QueryRange(metric.query, metricsRange)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"PodName":   podName,
+				"Metric":    metric.name,
+				"Error":     err.Error(),
+			}).Error("Unable to query metrics over time.")
+			return nil, util.NewUserError(codes.Unavailable, "Metrics over time are not available.")
+		}
+
+		series = append(series, &MetricSeries{
+			Name:   metric.name,
+			Format: metric.format,
+			Points: points,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMetricsRangeSource returns a point per step of the range for every query, recording the queries
+type fakeMetricsRangeSource struct {
+	queries []string
+}
+
+func (f *fakeMetricsRangeSource) QueryRange(query string, metricsRange MetricsRange) ([]*MetricPoint, error) {
+	f.queries = append(f.queries, query)
+
+	points := make([]*MetricPoint, 0)
+	for ts := metricsRange.Start; !ts.After(metricsRange.End); ts = ts.Add(metricsRange.Step) {
+		points = append(points, &MetricPoint{Timestamp: ts, Value: float64(len(points))})
+	}
+
+	return points, nil
+}
+
+// Test_validateMetricsRange makes sure ranges that are empty or have too many points are rejected
+func Test_validateMetricsRange(t *testing.T) {
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, validateMetricsRange(MetricsRange{Start: start, End: start.Add(time.Hour), Step: time.Minute}))
+
+	invalid := []MetricsRange{
+		{Start: start, End: start.Add(time.Hour), Step: 0},
+		{Start: start, End: start, Step: time.Minute},
+		{Start: start, End: start.Add(-time.Hour), Step: time.Minute},
+		{Start: start, End: start.Add(24 * time.Hour), Step: time.Second},
+	}
+	for _, metricsRange := range invalid {
+		err := validateMetricsRange(metricsRange)
+		userErr, ok := err.(*util.UserError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, userErr.Code)
+	}
+}
+
+// TestClient_GetWorkflowExecutionMetricsRange makes sure a series is returned for each metric of a pod of the workflow execution
+func TestClient_GetWorkflowExecutionMetricsRange(t *testing.T) {
+	source := &fakeMetricsRangeSource{}
+	c := DefaultTestClient().WithMetricsRangeSource(source)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podName := we.UID + "-1234"
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Nodes = wfv1.Nodes{
+		we.UID:  {ID: we.UID, Name: we.UID, Type: wfv1.NodeTypeSteps},
+		podName: {ID: podName, Name: we.UID + "[0].main", Type: wfv1.NodeTypePod},
+	}
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	metricsRange := MetricsRange{Start: start, End: start.Add(10 * time.Minute), Step: time.Minute}
+
+	series, err := c.GetWorkflowExecutionMetricsRange(namespace, we.UID, podName, metricsRange)
+	assert.Nil(t, err)
+	assert.Len(t, series, 2)
+	assert.Equal(t, "cpu", series[0].Name)
+	assert.Equal(t, "%", series[0].Format)
+	assert.Equal(t, "memory", series[1].Name)
+	assert.Equal(t, "bytes", series[1].Format)
+	assert.Len(t, series[0].Points, 11)
+	assert.Equal(t, start, series[0].Points[0].Timestamp)
+
+	assert.Len(t, source.queries, 2)
+	for _, query := range source.queries {
+		assert.True(t, strings.Contains(query, `pod="`+podName+`"`))
+	}
+
+	// Only pods of the workflow can be queried
+	_, err = c.GetWorkflowExecutionMetricsRange(namespace, we.UID, we.UID, metricsRange)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+
+	_, err = c.GetWorkflowExecutionMetricsRange(namespace, we.UID, "other-pod", metricsRange)
+	assert.NotNil(t, err)
+}
@@ -2,13 +2,25 @@ package v1
 
 import (
 	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/onepanelio/core/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var onepanelEnabledLabelKey = "onepanel.io/enabled"
 
+// systemNamespace is the namespace onepanel is installed in. Its configuration is the default for new namespaces.
+const systemNamespace = "onepanel"
+
+// namespaceConfigName is the name of the ConfigMap and Secret with the configuration of a namespace
+const namespaceConfigName = "onepanel"
+
 func (c *Client) ListOnepanelEnabledNamespaces() (namespaces []*Namespace, err error) {
 	namespaceList, err := c.CoreV1().Namespaces().List(metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("%s=%s", onepanelEnabledLabelKey, "true"),
@@ -67,3 +79,159 @@ func (c *Client) CreateNamespace(name string) (namespace *Namespace, err error)
 
 	return
 }
+
+// OnboardNamespace sets up the namespace for onepanel, creating it if it does not exist.
+// The namespace is labeled as onepanel enabled, gets a copy of the configuration of the system namespace,
+// and gets each of the workflowTemplates.
+// Anything that already exists is left as is, so onboarding a namespace again is a no-op.
+func (c *Client) OnboardNamespace(name string, workflowTemplates []*WorkflowTemplate) (namespace *Namespace, err error) {
+	namespace, err = c.enableNamespace(name)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": name,
+			"Error":     err.Error(),
+		}).Error("Unable to enable namespace.")
+		return nil, util.NewUserError(codes.Internal, "Unable to create namespace.")
+	}
+
+	if err := c.copyNamespaceConfig(name); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": name,
+			"Error":     err.Error(),
+		}).Error("Unable to copy namespace configuration.")
+		return nil, util.NewUserError(codes.Internal, "Unable to configure namespace.")
+	}
+
+	artifactRepositoryType := "s3"
+	config, err := c.GetNamespaceConfig(name)
+	if err != nil {
+		return nil, util.NewUserError(codes.FailedPrecondition, "Artifact repository config not found.")
+	}
+	if config.ArtifactRepository.GCS != nil {
+		artifactRepositoryType = "gcs"
+	}
+
+	for _, workflowTemplate := range workflowTemplates {
+		// The templates are shared between namespaces, so each namespace gets its own copy
+		wt := &WorkflowTemplate{
+			Name:     workflowTemplate.Name,
+			Manifest: strings.ReplaceAll(workflowTemplate.Manifest, "{{.ArtifactRepositoryType}}", artifactRepositoryType),
+			Labels:   workflowTemplate.Labels,
+		}
+		if err := wt.GenerateUID(wt.Name); err != nil {
+			return nil, util.NewUserError(codes.InvalidArgument, err.Error())
+		}
+
+		// Templates the namespace already has, or had and archived, are not created again
+		count := 0
+		err := c.DB.Getx(&count, sb.Select("COUNT(*)").
+			From("workflow_templates").
+			Where(sq.Eq{
+				"namespace": name,
+				"uid":       wt.UID,
+			}))
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace":        name,
+				"WorkflowTemplate": wt.Name,
+				"Error":            err.Error(),
+			}).Error("Unable to check for workflow template.")
+			return nil, util.NewUserError(codes.Internal, "Unable to create workflow templates.")
+		}
+		if count > 0 {
+			continue
+		}
+
+		if _, err := c.CreateWorkflowTemplate(name, wt); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// enableNamespace creates the namespace with the onepanel labels, or adds the labels to the existing namespace
+func (c *Client) enableNamespace(name string) (namespace *Namespace, err error) {
+	k8sNamespace, err := c.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return c.CreateNamespace(name)
+	}
+	if err != nil {
+		return
+	}
+
+	if k8sNamespace.Labels[onepanelEnabledLabelKey] != "true" || k8sNamespace.Labels["istio-injection"] != "enabled" {
+		if k8sNamespace.Labels == nil {
+			k8sNamespace.Labels = make(map[string]string)
+		}
+		k8sNamespace.Labels[onepanelEnabledLabelKey] = "true"
+		k8sNamespace.Labels["istio-injection"] = "enabled"
+
+		k8sNamespace, err = c.CoreV1().Namespaces().Update(k8sNamespace)
+		if err != nil {
+			return
+		}
+	}
+
+	namespace = &Namespace{
+		Name:   k8sNamespace.Name,
+		Labels: k8sNamespace.Labels,
+	}
+
+	return
+}
+
+// copyNamespaceConfig copies the configuration ConfigMap and Secret of the system namespace to the namespace,
+// unless the namespace already has them.
+func (c *Client) copyNamespaceConfig(name string) error {
+	if name == systemNamespace {
+		return nil
+	}
+
+	_, err := c.CoreV1().ConfigMaps(name).Get(namespaceConfigName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		configMap, err := c.CoreV1().ConfigMaps(systemNamespace).Get(namespaceConfigName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		_, err = c.CoreV1().ConfigMaps(name).Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      namespaceConfigName,
+				Namespace: name,
+				Labels:    configMap.Labels,
+			},
+			Data: configMap.Data,
+		})
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	_, err = c.CoreV1().Secrets(name).Get(namespaceConfigName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		secret, err := c.CoreV1().Secrets(systemNamespace).Get(namespaceConfigName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		_, err = c.CoreV1().Secrets(name).Create(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      namespaceConfigName,
+				Namespace: name,
+				Labels:    secret.Labels,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		})
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
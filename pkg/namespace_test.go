@@ -32,3 +32,69 @@ func TestClient_ListNamespace(t *testing.T) {
 	assert.NotEmpty(t, n)
 	assert.Equal(t, len(n), 5)
 }
+
+// TestClient_OnboardNamespace makes sure onboarding sets up the namespace, and that onboarding it again is a no-op
+func TestClient_OnboardNamespace(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	name := "research"
+	workflowTemplates := []*WorkflowTemplate{
+		{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		namespace, err := c.OnboardNamespace(name, workflowTemplates)
+		assert.Nil(t, err)
+		assert.Equal(t, name, namespace.Name)
+	}
+
+	namespaces, err := c.ListOnepanelEnabledNamespaces()
+	assert.Nil(t, err)
+	assert.Len(t, namespaces, 1)
+
+	configMap, err := c.CoreV1().ConfigMaps(name).Get(namespaceConfigName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, mockSystemConfigMap.Data, configMap.Data)
+
+	_, err = c.CoreV1().Secrets(name).Get(namespaceConfigName, metav1.GetOptions{})
+	assert.Nil(t, err)
+
+	count, err := c.CountWorkflowTemplatesByName(name, "test", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	versions, err := c.CountWorkflowTemplateVersions(name, "test")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), versions)
+}
+
+// TestClient_OnboardNamespace_Existing makes sure an existing namespace is enabled without changing its other labels
+func TestClient_OnboardNamespace_Existing(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	name := "research"
+	_, err := c.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"team": "research",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.OnboardNamespace(name, nil)
+	assert.Nil(t, err)
+
+	namespace, err := c.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "true", namespace.Labels[onepanelEnabledLabelKey])
+	assert.Equal(t, "research", namespace.Labels["team"])
+}
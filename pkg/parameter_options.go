@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// parameterOptionsCacheTTL is how long resolved parameter options are used before they are resolved again
+const parameterOptionsCacheTTL = 30 * time.Second
+
+// ParameterOptionsSource gets the options of a parameter from where its optionsFrom points to
+type ParameterOptionsSource interface {
+	GetParameterOptions(namespace string, from *ParameterOptionsFrom) ([]*ParameterOption, error)
+}
+
+// workflowOutputOptionsSource takes parameter options from an output of the latest succeeded workflow execution of a workflow template
+type workflowOutputOptionsSource struct {
+	client *Client
+}
+
+// GetParameterOptions gets the options from the output of the latest succeeded workflow execution.
+// If the workflow template has not succeeded yet, there are no options.
+func (s *workflowOutputOptionsSource) GetParameterOptions(namespace string, from *ParameterOptionsFrom) ([]*ParameterOption, error) {
+	uid, err := s.client.getLatestSucceededWorkflowExecutionUID(namespace, from.WorkflowTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if uid == "" {
+		return make([]*ParameterOption, 0), nil
+	}
+
+	outputs, err := s.client.GetWorkflowExecutionOutputs(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, output := range outputs {
+		if output.Name == from.Output {
+			return parseParameterOptions(output.Value)
+		}
+	}
+
+	return nil, fmt.Errorf("workflow execution '%v' has no output '%v'", uid, from.Output)
+}
+
+// parseParameterOptions parses options from a JSON array of strings, each being both the name and value of an option,
+// or of objects with a name and value.
+func parseParameterOptions(value string) ([]*ParameterOption, error) {
+	items := make([]json.RawMessage, 0)
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, fmt.Errorf("options should be a JSON array: %v", err)
+	}
+
+	options := make([]*ParameterOption, 0, len(items))
+	for _, item := range items {
+		name := ""
+		if err := json.Unmarshal(item, &name); err == nil {
+			options = append(options, &ParameterOption{Name: name, Value: name})
+			continue
+		}
+
+		option := &ParameterOption{}
+		if err := json.Unmarshal(item, option); err != nil {
+			return nil, fmt.Errorf("option %v should be a string or an object with a name and value", string(item))
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// parameterOptionsCacheEntry is the options resolved for a parameter, and when they should be resolved again
+type parameterOptionsCacheEntry struct {
+	options   []*ParameterOption
+	expiresAt time.Time
+}
+
+// parameterOptionsCache keeps resolved parameter options for parameterOptionsCacheTTL,
+// so getting a workflow template does not resolve them every time
+type parameterOptionsCache struct {
+	mutex   sync.Mutex
+	entries map[string]parameterOptionsCacheEntry
+}
+
+// defaultParameterOptionsCache is the cache used by clients that do not have their own
+var defaultParameterOptionsCache = newParameterOptionsCache()
+
+func newParameterOptionsCache() *parameterOptionsCache {
+	return &parameterOptionsCache{
+		entries: make(map[string]parameterOptionsCacheEntry),
+	}
+}
+
+// copyParameterOptions returns a copy of options, so the cached options are not changed through the copy
+func copyParameterOptions(options []*ParameterOption) []*ParameterOption {
+	if options == nil {
+		return nil
+	}
+
+	result := make([]*ParameterOption, len(options))
+	for i, option := range options {
+		if option != nil {
+			copied := *option
+			result[i] = &copied
+		}
+	}
+
+	return result
+}
+
+// get returns a copy of the options cached for key, if they have not expired
+func (c *parameterOptionsCache) get(key string) (options []*ParameterOption, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return copyParameterOptions(entry.options), true
+}
+
+// set caches a copy of the options for key for parameterOptionsCacheTTL
+func (c *parameterOptionsCache) set(key string, options []*ParameterOption) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = parameterOptionsCacheEntry{
+		options:   copyParameterOptions(options),
+		expiresAt: time.Now().Add(parameterOptionsCacheTTL),
+	}
+}
+
+// WithParameterOptionsSource returns a shallow copy of the client that resolves parameter options from source
+// instead of workflow execution outputs. The copy has its own cache of resolved options.
+func (c *Client) WithParameterOptionsSource(source ParameterOptionsSource) *Client {
+	client := *c
+	client.parameterOptionsSource = source
+	client.parameterOptionsCache = newParameterOptionsCache()
+
+	return &client
+}
+
+// ResolveParameterOptions sets the options of the parameters with an optionsFrom to the options it points to.
+// Resolved options are cached for parameterOptionsCacheTTL.
+// A parameter whose options can not be resolved keeps the options from the manifest, the error is only logged.
+func (c *Client) ResolveParameterOptions(namespace string, parameters []Parameter) {
+	source := c.parameterOptionsSource
+	if source == nil {
+		source = &workflowOutputOptionsSource{client: c}
+	}
+	cache := c.parameterOptionsCache
+	if cache == nil {
+		cache = defaultParameterOptionsCache
+	}
+
+	for i := range parameters {
+		from := parameters[i].OptionsFrom
+		if from == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%v/%v/%v", namespace, from.WorkflowTemplate, from.Output)
+		options, ok := cache.get(key)
+		if !ok {
+			var err error
+			options, err = source.GetParameterOptions(namespace, from)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"Namespace":        namespace,
+					"Parameter":        parameters[i].Name,
+					"WorkflowTemplate": from.WorkflowTemplate,
+					"Output":           from.Output,
+					"Error":            err.Error(),
+				}).Warn("Unable to resolve parameter options.")
+				continue
+			}
+			cache.set(key, options)
+		}
+
+		parameters[i].Options = options
+	}
+}
+
+// getLatestSucceededWorkflowExecutionUID returns the uid of the workflow execution of the workflow template
+// that succeeded last. If there is none, an empty uid is returned.
+func (c *Client) getLatestSucceededWorkflowExecutionUID(namespace, workflowTemplateUID string) (uid string, err error) {
+	query := sb.Select("we.uid").
+		From("workflow_executions we").
+		Join("workflow_template_versions wtv ON wtv.id = we.workflow_template_version_id").
+		Join("workflow_templates wt ON wt.id = wtv.workflow_template_id").
+		Where(sq.Eq{
+			"wt.namespace": namespace,
+			"wt.uid":       workflowTemplateUID,
+			"we.phase":     wfv1.NodeSucceeded,
+		}).
+		OrderBy("we.finished_at DESC").
+		Limit(1)
+
+	err = c.DB.Getx(&uid, query)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return
+}
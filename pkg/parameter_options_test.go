@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeParameterOptionsSource returns the same options for every parameter, counting how many times it is called
+type fakeParameterOptionsSource struct {
+	options []*ParameterOption
+	calls   int
+}
+
+func (f *fakeParameterOptionsSource) GetParameterOptions(namespace string, from *ParameterOptionsFrom) ([]*ParameterOption, error) {
+	f.calls++
+	return f.options, nil
+}
+
+const optionsFromWorkflowTemplate = `entrypoint: main
+arguments:
+  parameters:
+  - name: model
+    value: s3://models/resnet-50
+    type: select.select
+    optionsFrom:
+      workflowTemplate: train-model
+      output: models
+  - name: epochs
+    value: "1"
+templates:
+- name: main
+  container:
+    image: alpine
+    command: [sh, -c]
+    args: ["echo {{workflow.parameters.model}}"]
+`
+
+// TestClient_ResolveParameterOptions makes sure parameters with optionsFrom get their options from the source, which are cached
+func TestClient_ResolveParameterOptions(t *testing.T) {
+	source := &fakeParameterOptionsSource{
+		options: []*ParameterOption{
+			{Name: "resnet-50", Value: "s3://models/resnet-50"},
+			{Name: "vgg-16", Value: "s3://models/vgg-16"},
+		},
+	}
+	c := DefaultTestClient().WithParameterOptionsSource(source)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: optionsFromWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, created.UID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameters := MapParametersByName(workflowTemplate.Parameters)
+	if assert.NotNil(t, parameters["model"].OptionsFrom) {
+		assert.Equal(t, "train-model", parameters["model"].OptionsFrom.WorkflowTemplate)
+		assert.Equal(t, "models", parameters["model"].OptionsFrom.Output)
+	}
+
+	c.ResolveParameterOptions(namespace, workflowTemplate.Parameters)
+	parameters = MapParametersByName(workflowTemplate.Parameters)
+	assert.Equal(t, source.options, parameters["model"].Options)
+	assert.Empty(t, parameters["epochs"].Options)
+
+	c.ResolveParameterOptions(namespace, workflowTemplate.Parameters)
+	assert.Equal(t, 1, source.calls)
+}
+
+// Test_parameterOptionsCache_Copy makes sure changing the options set in or got from the cache does not change the cached ones
+func Test_parameterOptionsCache_Copy(t *testing.T) {
+	cache := newParameterOptionsCache()
+
+	options := []*ParameterOption{
+		{Name: "resnet-50", Value: "s3://models/resnet-50"},
+	}
+	cache.set("onepanel/train-model/models", options)
+	options[0].Name = "changed"
+
+	cached, ok := cache.get("onepanel/train-model/models")
+	if !assert.True(t, ok) {
+		return
+	}
+	cached[0].Value = "changed"
+	_ = append(cached[:0], &ParameterOption{Name: "vgg-16", Value: "s3://models/vgg-16"})
+
+	cached, ok = cache.get("onepanel/train-model/models")
+	assert.True(t, ok)
+	assert.Equal(t, []*ParameterOption{{Name: "resnet-50", Value: "s3://models/resnet-50"}}, cached)
+}
+
+func Test_parseParameterOptions(t *testing.T) {
+	tests := []struct {
+		value   string
+		options []*ParameterOption
+		isError bool
+	}{
+		{
+			value: `["resnet-50", "vgg-16"]`,
+			options: []*ParameterOption{
+				{Name: "resnet-50", Value: "resnet-50"},
+				{Name: "vgg-16", Value: "vgg-16"},
+			},
+		},
+		{
+			value: `[{"name": "ResNet 50", "value": "resnet-50"}]`,
+			options: []*ParameterOption{
+				{Name: "ResNet 50", Value: "resnet-50"},
+			},
+		},
+		{
+			value:   `[]`,
+			options: []*ParameterOption{},
+		},
+		{value: `resnet-50`, isError: true},
+		{value: `[1]`, isError: true},
+	}
+
+	for _, test := range tests {
+		options, err := parseParameterOptions(test.value)
+		if test.isError {
+			assert.NotNil(t, err, test.value)
+			continue
+		}
+		assert.Nil(t, err, test.value)
+		assert.Equal(t, test.options, options, test.value)
+	}
+}
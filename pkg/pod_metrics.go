@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"github.com/onepanelio/core/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// metricsGroupVersion is the group version of the kubernetes metrics API, served by the metrics server
+const metricsGroupVersion = "metrics.k8s.io/v1beta1"
+
+// ContainerMetrics is the current resource usage of a container
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// PodMetrics is the current resource usage of the containers of a pod, as reported by the metrics API
+type PodMetrics struct {
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// PodMetricsSource gets the current resource usage of a pod
+type PodMetricsSource interface {
+	GetPodMetrics(namespace, name string) (*PodMetrics, error)
+}
+
+// metricsAPISource reads pod metrics from the kubernetes metrics API
+type metricsAPISource struct {
+	client rest.Interface
+}
+
+// GetPodMetrics gets the metrics of the pod from the metrics API
+func (s *metricsAPISource) GetPodMetrics(namespace, name string) (*PodMetrics, error) {
+	data, err := s.client.Get().
+		AbsPath("/apis", metricsGroupVersion, "namespaces", namespace, "pods", name).
+		DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	podMetrics := &PodMetrics{}
+	if err := json.Unmarshal(data, podMetrics); err != nil {
+		return nil, err
+	}
+
+	return podMetrics, nil
+}
+
+// WithPodMetricsSource returns a shallow copy of the client that gets pod metrics from source instead of the metrics API
+func (c *Client) WithPodMetricsSource(source PodMetricsSource) *Client {
+	client := *c
+	client.podMetricsSource = source
+
+	return &client
+}
+
+// getPodMetrics gets the metrics of the pod. A codes.Unavailable error is returned if the metrics API is not installed.
+func (c *Client) getPodMetrics(namespace, name string) (*PodMetrics, error) {
+	if _, err := c.Discovery().ServerResourcesForGroupVersion(metricsGroupVersion); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Name":      name,
+			"Error":     err.Error(),
+		}).Error("Metrics API not found.")
+		return nil, util.NewUserError(codes.Unavailable, "Metrics are not available. Make sure the metrics server is installed.")
+	}
+
+	source := c.podMetricsSource
+	if source == nil {
+		source = &metricsAPISource{client: c.Discovery().RESTClient()}
+	}
+
+	podMetrics, err := source.GetPodMetrics(namespace, name)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Name":      name,
+			"Error":     err.Error(),
+		}).Error("Unable to get pod metrics.")
+		return nil, util.NewUserError(codes.Unavailable, "Metrics are not available for the pod yet.")
+	}
+
+	return podMetrics, nil
+}
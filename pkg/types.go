@@ -1,12 +1,20 @@
 package v1
 
 import (
+	"bytes"
+	"compress/gzip"
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/pkg/util"
+	"google.golang.org/grpc/codes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,9 +64,109 @@ type ConfigMap struct {
 	Data map[string]string
 }
 
+// LogEntryContentEncodingGzip indicates the LogEntry content is base64 encoded gzip data
+const LogEntryContentEncodingGzip = "gzip"
+
 type LogEntry struct {
-	Timestamp time.Time
-	Content   string
+	Timestamp       time.Time
+	Content         string
+	ContentEncoding string
+	Heartbeat       bool // true if the entry has no logs and is only sent so the consumer knows the stream is alive
+}
+
+// Compress gzips the content of the log entry and base64 encodes the result so it can be sent as a string.
+// ContentEncoding is set to LogEntryContentEncodingGzip. Entries that are already compressed are left unchanged.
+func (l *LogEntry) Compress() error {
+	if l.ContentEncoding == LogEntryContentEncodingGzip {
+		return nil
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	if _, err := writer.Write([]byte(l.Content)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	l.Content = base64.StdEncoding.EncodeToString(buffer.Bytes())
+	l.ContentEncoding = LogEntryContentEncodingGzip
+
+	return nil
+}
+
+// LogFilter keeps the lines of log entries that match a regular expression.
+// Log entries do not always end at a line break, so the start of a line is kept until the rest of it is read.
+type LogFilter struct {
+	pattern *regexp.Regexp
+	partial string
+}
+
+// NewLogFilter compiles pattern into a LogFilter. A codes.InvalidArgument error is returned if pattern is not a valid regular expression.
+func NewLogFilter(pattern string) (*LogFilter, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Invalid log filter: %v", err.Error()))
+	}
+
+	return &LogFilter{
+		pattern: compiled,
+	}, nil
+}
+
+// Filter returns a log entry with the matching lines of entry that are complete, or nil if there are none.
+// Lines are matched without the timestamp kubernetes starts them with, but are returned with it.
+// entry should not be compressed.
+func (f *LogFilter) Filter(entry *LogEntry) *LogEntry {
+	lines := strings.Split(f.partial+entry.Content, "\n")
+	f.partial = lines[len(lines)-1]
+
+	return f.match(entry.Timestamp, lines[:len(lines)-1])
+}
+
+// Flush returns a log entry with the last line if it did not end with a line break and it matches, or nil otherwise.
+func (f *LogFilter) Flush() *LogEntry {
+	if f.partial == "" {
+		return nil
+	}
+
+	entry := f.match(time.Time{}, []string{f.partial})
+	f.partial = ""
+	if entry != nil {
+		entry.Content = strings.TrimSuffix(entry.Content, "\n")
+	}
+
+	return entry
+}
+
+func (f *LogFilter) match(timestamp time.Time, lines []string) *LogEntry {
+	var matched []string
+	for _, line := range lines {
+		if f.pattern.MatchString(trimLogTimestamp(line)) {
+			matched = append(matched, line+"\n")
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	return &LogEntry{
+		Timestamp: timestamp,
+		Content:   strings.Join(matched, ""),
+	}
+}
+
+// trimLogTimestamp returns line without the RFC3339 timestamp it starts with, if any
+func trimLogTimestamp(line string) string {
+	if end := strings.IndexByte(line, ' '); end > 0 {
+		if _, err := time.Parse(time.RFC3339, line[:end]); err == nil {
+			return line[end+1:]
+		}
+	}
+
+	return line
 }
 
 type Metric struct {
@@ -96,6 +204,12 @@ type WorkspaceStatisticReport struct {
 	Total             int32
 }
 
+// NamespaceWorkspaceStatisticReport is a WorkspaceStatisticReport for a single namespace
+type NamespaceWorkspaceStatisticReport struct {
+	Namespace string
+	WorkspaceStatisticReport
+}
+
 type CronWorkflowStatisticReport struct {
 	WorkflowTemplateId uint64 `db:"workflow_template_id"`
 	Total              int32
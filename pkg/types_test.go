@@ -1,8 +1,15 @@
 package v1
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"github.com/onepanelio/core/pkg/util"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"io/ioutil"
 	"testing"
+	"time"
 )
 
 // TestMetrics_Add tests the Add method of the Metrics type
@@ -70,3 +77,84 @@ func TestMetrics_Merge(t *testing.T) {
 	assert.Len(t, initial, 3)
 	assert.True(t, initial[0].Value == 0.00)
 }
+
+// TestLogEntry_Compress tests that compressed log content decompresses to the original lines
+func TestLogEntry_Compress(t *testing.T) {
+	lines := "epoch 1 loss 0.42\nepoch 2 loss 0.31\n"
+	le := &LogEntry{
+		Content: lines,
+	}
+
+	err := le.Compress()
+	assert.Nil(t, err)
+	assert.Equal(t, LogEntryContentEncodingGzip, le.ContentEncoding)
+	assert.NotEqual(t, lines, le.Content)
+
+	data, err := base64.StdEncoding.DecodeString(le.Content)
+	assert.Nil(t, err)
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+
+	decompressed, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, lines, string(decompressed))
+
+	// Compressing again should not change the content
+	compressed := le.Content
+	assert.Nil(t, le.Compress())
+	assert.Equal(t, compressed, le.Content)
+}
+
+// TestLogFilter makes sure only the matching lines are kept, including lines split across log entries
+func TestLogFilter(t *testing.T) {
+	filter, err := NewLogFilter("^ERROR")
+	assert.Nil(t, err)
+
+	var filtered []string
+	entries := []string{
+		"INFO starting\nERROR first\nINFO running\nERR",
+		"OR second\nINFO done\n",
+		"ERROR last",
+	}
+	for _, content := range entries {
+		if le := filter.Filter(&LogEntry{Content: content}); le != nil {
+			filtered = append(filtered, le.Content)
+		}
+	}
+	if le := filter.Flush(); le != nil {
+		filtered = append(filtered, le.Content)
+	}
+
+	assert.Equal(t, []string{"ERROR first\n", "ERROR second\n", "ERROR last"}, filtered)
+}
+
+// TestLogFilter_Timestamps makes sure the timestamp of each line of a log entry is ignored when matching,
+// so patterns anchored at the start of a line match the lines after the first one
+func TestLogFilter_Timestamps(t *testing.T) {
+	filter, err := NewLogFilter("^ERROR")
+	assert.Nil(t, err)
+
+	timestamp := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	content := "ERROR first\n" +
+		"2020-12-01T10:00:01.123456789Z INFO running\n" +
+		"2020-12-01T10:00:02.123456789Z ERROR second\n" +
+		"2020-12-01T10:00:03.123456789Z ERROR third\n"
+
+	le := filter.Filter(&LogEntry{Timestamp: timestamp, Content: content})
+	if assert.NotNil(t, le) {
+		assert.Equal(t, timestamp, le.Timestamp)
+		assert.Equal(t, "ERROR first\n"+
+			"2020-12-01T10:00:02.123456789Z ERROR second\n"+
+			"2020-12-01T10:00:03.123456789Z ERROR third\n", le.Content)
+	}
+	assert.Nil(t, filter.Flush())
+}
+
+// TestNewLogFilter_Invalid makes sure an invalid pattern is rejected with codes.InvalidArgument
+func TestNewLogFilter_Invalid(t *testing.T) {
+	_, err := NewLogFilter("(unclosed")
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"net/http"
+	"path"
+	"time"
+)
+
+// Outcomes an observed request is labelled with
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "onepanel",
+		Name:      "rpc_requests_total",
+		Help:      "Number of rpc requests handled, by rpc name and outcome.",
+	}, []string{"rpc", "outcome"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "onepanel",
+		Name:      "rpc_duration_seconds",
+		Help:      "Duration of rpc requests, by rpc name and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"rpc", "outcome"})
+
+	kubeRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "onepanel",
+		Name:      "kube_requests_total",
+		Help:      "Number of calls made to the kubernetes api, by call name and outcome.",
+	}, []string{"call", "outcome"})
+
+	kubeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "onepanel",
+		Name:      "kube_request_duration_seconds",
+		Help:      "Duration of calls made to the kubernetes api, by call name and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"call", "outcome"})
+
+	droppedLogLines = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "onepanel",
+		Name:      "log_lines_dropped_total",
+		Help:      "Number of log lines dropped because the client streaming them was too slow.",
+	})
+)
+
+func init() {
+	registry.MustRegister(rpcRequests, rpcDuration, kubeRequests, kubeDuration, droppedLogLines)
+}
+
+// Outcome returns OutcomeError if err is not nil, OutcomeSuccess otherwise
+func Outcome(err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+
+	return OutcomeSuccess
+}
+
+// ObserveRPC records an rpc request that started at start and finished with err
+func ObserveRPC(rpc string, start time.Time, err error) {
+	outcome := Outcome(err)
+	rpcRequests.WithLabelValues(rpc, outcome).Inc()
+	rpcDuration.WithLabelValues(rpc, outcome).Observe(time.Since(start).Seconds())
+}
+
+// ObserveKubeCall records a kubernetes api call that started at start and finished with err
+func ObserveKubeCall(call string, start time.Time, err error) {
+	outcome := Outcome(err)
+	kubeRequests.WithLabelValues(call, outcome).Inc()
+	kubeDuration.WithLabelValues(call, outcome).Observe(time.Since(start).Seconds())
+}
+
+// AddDroppedLogLines records lines log lines that were dropped instead of streamed
+func AddDroppedLogLines(lines int) {
+	droppedLogLines.Add(float64(lines))
+}
+
+// UnaryServerInterceptor records the duration and outcome of every unary rpc.
+// The rpc is labelled by its method name, e.g. CreateWorkflowExecution
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+		resp, err = handler(ctx, req)
+		ObserveRPC(path.Base(info.FullMethod), start, err)
+
+		return
+	}
+}
+
+// Handler returns the http handler that exposes the collected metrics to prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{
+		FullMethod: "/api.WorkflowService/GetWorkflowExecution",
+	}
+
+	success := testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeSuccess))
+	failure := testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeError))
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, success+1, testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeSuccess)))
+	assert.Equal(t, failure, testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeError)))
+
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("not found")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, success+1, testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeSuccess)))
+	assert.Equal(t, failure+1, testutil.ToFloat64(rpcRequests.WithLabelValues("GetWorkflowExecution", OutcomeError)))
+}
+
+func TestObserveKubeCall(t *testing.T) {
+	success := testutil.ToFloat64(kubeRequests.WithLabelValues("CreateWorkflow", OutcomeSuccess))
+	failure := testutil.ToFloat64(kubeRequests.WithLabelValues("CreateWorkflow", OutcomeError))
+
+	ObserveKubeCall("CreateWorkflow", time.Now(), nil)
+	assert.Equal(t, success+1, testutil.ToFloat64(kubeRequests.WithLabelValues("CreateWorkflow", OutcomeSuccess)))
+
+	ObserveKubeCall("CreateWorkflow", time.Now(), errors.New("forbidden"))
+	assert.Equal(t, failure+1, testutil.ToFloat64(kubeRequests.WithLabelValues("CreateWorkflow", OutcomeError)))
+}
+
+func TestAddDroppedLogLines(t *testing.T) {
+	dropped := testutil.ToFloat64(droppedLogLines)
+
+	AddDroppedLogLines(3)
+	assert.Equal(t, dropped+3, testutil.ToFloat64(droppedLogLines))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveRPC("ListWorkflowExecutions", time.Now(), nil)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.True(t, strings.Contains(recorder.Body.String(), `onepanel_rpc_requests_total{outcome="success",rpc="ListWorkflowExecutions"}`))
+}
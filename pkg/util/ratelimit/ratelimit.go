@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"golang.org/x/time/rate"
+	"sync"
+	"time"
+)
+
+// minIdleTimeout is the shortest time a key has to be unused before its bucket is removed
+const minIdleTimeout = time.Minute
+
+// bucket is the token bucket of a key and when it was last used
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// KeyedLimiter is a token bucket rate limiter with a separate bucket for each key, like a namespace.
+// Buckets are created the first time a key is used, and removed once the key has been idle long enough
+// for its bucket to be full again, so removing it does not change the outcome of later calls.
+type KeyedLimiter struct {
+	limit       rate.Limit
+	burst       int
+	idleTimeout time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewKeyedLimiter creates a limiter that allows limit events per second for each key, with bursts of up to burst events.
+// A limit <= 0 allows every event.
+func NewKeyedLimiter(limit float64, burst int) *KeyedLimiter {
+	l := &KeyedLimiter{
+		limit:       rate.Inf,
+		burst:       burst,
+		idleTimeout: minIdleTimeout,
+		buckets:     make(map[string]*bucket),
+	}
+
+	if limit > 0 {
+		l.limit = rate.Limit(limit)
+
+		refill := time.Duration(float64(burst) / limit * float64(time.Second))
+		if refill > l.idleTimeout {
+			l.idleTimeout = refill
+		}
+	}
+
+	return l
+}
+
+// Allow reports whether an event for key may happen now, taking a token from its bucket if so.
+func (l *KeyedLimiter) Allow(key string) bool {
+	return l.allowAt(key, time.Now())
+}
+
+// allowAt is Allow at the time now
+func (l *KeyedLimiter) allowAt(key string, now time.Time) bool {
+	if l.limit == rate.Inf {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+
+	return b.limiter.AllowN(now, 1)
+}
+
+// sweep removes the buckets of keys that have been idle for idleTimeout.
+// It runs at most once per idleTimeout, so the cost is spread over many calls.
+func (l *KeyedLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTimeout {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastUsed) >= l.idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// len returns the number of keys with a bucket
+func (l *KeyedLimiter) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.buckets)
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestKeyedLimiter_Burst(t *testing.T) {
+	l := NewKeyedLimiter(1, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.allowAt("onepanel", now), "event %v of the burst", i)
+	}
+	assert.False(t, l.allowAt("onepanel", now))
+
+	// one token is added back every second
+	assert.True(t, l.allowAt("onepanel", now.Add(time.Second)))
+	assert.False(t, l.allowAt("onepanel", now.Add(time.Second)))
+}
+
+func TestKeyedLimiter_SeparateKeys(t *testing.T) {
+	l := NewKeyedLimiter(1, 2)
+	now := time.Now()
+
+	assert.True(t, l.allowAt("first", now))
+	assert.True(t, l.allowAt("first", now))
+	assert.False(t, l.allowAt("first", now))
+
+	assert.True(t, l.allowAt("second", now))
+	assert.True(t, l.allowAt("second", now))
+	assert.False(t, l.allowAt("second", now))
+}
+
+func TestKeyedLimiter_Unlimited(t *testing.T) {
+	l := NewKeyedLimiter(0, 1)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow("onepanel"))
+	}
+	assert.Equal(t, 0, l.len())
+}
+
+func TestKeyedLimiter_RemovesIdleKeys(t *testing.T) {
+	l := NewKeyedLimiter(1, 2)
+	now := time.Now()
+
+	assert.True(t, l.allowAt("idle", now))
+	assert.True(t, l.allowAt("busy", now))
+	assert.Equal(t, 2, l.len())
+
+	assert.True(t, l.allowAt("busy", now.Add(minIdleTimeout/2)))
+	assert.True(t, l.allowAt("busy", now.Add(minIdleTimeout)))
+	assert.Equal(t, 1, l.len())
+
+	// the removed key starts over with a full bucket
+	assert.True(t, l.allowAt("idle", now.Add(minIdleTimeout)))
+	assert.True(t, l.allowAt("idle", now.Add(minIdleTimeout)))
+	assert.False(t, l.allowAt("idle", now.Add(minIdleTimeout)))
+}
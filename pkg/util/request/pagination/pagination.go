@@ -2,9 +2,45 @@ package pagination
 
 import (
 	"github.com/Masterminds/squirrel"
+	"github.com/onepanelio/core/pkg/util/env"
+	log "github.com/sirupsen/logrus"
 	"math"
+	"strconv"
 )
 
+const (
+	// fallbackPageSize is the default page size if PAGINATION_DEFAULT_PAGE_SIZE is not set
+	fallbackPageSize = 15
+	// MaxPageSize is the largest page size that can be requested. Larger page sizes are clamped to it.
+	MaxPageSize = 1000
+)
+
+// DefaultPageSize is the page size used when a request does not set one.
+// It is configured with the PAGINATION_DEFAULT_PAGE_SIZE environment variable.
+var DefaultPageSize = parseDefaultPageSize(env.GetEnv("PAGINATION_DEFAULT_PAGE_SIZE", ""))
+
+// parseDefaultPageSize parses the configured default page size, clamped to MaxPageSize.
+// If value is empty or not a positive number, fallbackPageSize is used.
+func parseDefaultPageSize(value string) int32 {
+	if value == "" {
+		return fallbackPageSize
+	}
+
+	pageSize, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || pageSize <= 0 {
+		log.WithFields(log.Fields{
+			"Value": value,
+		}).Warn("Invalid PAGINATION_DEFAULT_PAGE_SIZE, using the fallback page size.")
+		return fallbackPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		return MaxPageSize
+	}
+
+	return int32(pageSize)
+}
+
 type PaginationRequest struct {
 	Page     uint64
 	PageSize uint64
@@ -17,14 +53,19 @@ func New(page, pageSize int32) *PaginationRequest {
 	return &pr
 }
 
-// NewRequest creates a new pagination request (not pointer) from the page and page size
+// NewRequest creates a new pagination request (not pointer) from the page and page size.
+// If pageSize is not set, DefaultPageSize is used. A pageSize larger than MaxPageSize is clamped to MaxPageSize.
 func NewRequest(page, pageSize int32) PaginationRequest {
-	if page == 0 {
+	if page <= 0 {
 		page = 1
 	}
 
-	if pageSize == 0 {
-		pageSize = 15
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
 	}
 
 	return PaginationRequest{
@@ -34,7 +75,7 @@ func NewRequest(page, pageSize int32) PaginationRequest {
 }
 
 // Start creates a new PaginationRequest that starts at the first page.
-// You can provide an optional pageSize argument. If none is provided, 15 is used.
+// You can provide an optional pageSize argument. If none is provided, DefaultPageSize is used.
 // All arguments apart from the first one are ignored.
 func Start(pageSize ...int32) *PaginationRequest {
 	if len(pageSize) > 0 {
@@ -42,7 +83,7 @@ func Start(pageSize ...int32) *PaginationRequest {
 		return &pr
 	}
 
-	pr := NewRequest(1, 15)
+	pr := NewRequest(1, DefaultPageSize)
 
 	return &pr
 }
@@ -52,10 +93,42 @@ func (pr *PaginationRequest) Offset() uint64 {
 	return (pr.Page - 1) * pr.PageSize
 }
 
+// CalculatePages returns how many pages count results take. A request without a page size has all of the results on one page.
 func (pr *PaginationRequest) CalculatePages(count int) int32 {
+	if count <= 0 {
+		return 0
+	}
+	if pr == nil || pr.PageSize == 0 {
+		return 1
+	}
+
 	return int32(math.Ceil(float64(count) / float64(pr.PageSize)))
 }
 
+// Metadata describes a page of results, so clients know where they are in the list
+type Metadata struct {
+	Count      int32 // number of results on the page
+	Page       int32
+	Pages      int32
+	TotalCount int32 // number of results on all pages
+}
+
+// NewMetadata returns the Metadata of a page with count results, out of totalCount results, for the request.
+// If pr is nil, all of the results are on the first page.
+func NewMetadata(pr *PaginationRequest, count, totalCount int) Metadata {
+	page := int32(1)
+	if pr != nil && pr.Page > 0 {
+		page = int32(pr.Page)
+	}
+
+	return Metadata{
+		Count:      int32(count),
+		Page:       page,
+		Pages:      pr.CalculatePages(totalCount),
+		TotalCount: int32(totalCount),
+	}
+}
+
 func (pr *PaginationRequest) ApplyToSelect(sb *squirrel.SelectBuilder) *squirrel.SelectBuilder {
 	if pr == nil {
 		return sb
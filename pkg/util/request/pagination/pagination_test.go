@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewRequest(t *testing.T) {
+	pr := NewRequest(0, 0)
+	assert.Equal(t, uint64(1), pr.Page)
+	assert.Equal(t, uint64(DefaultPageSize), pr.PageSize)
+
+	pr = NewRequest(2, 50)
+	assert.Equal(t, uint64(2), pr.Page)
+	assert.Equal(t, uint64(50), pr.PageSize)
+
+	pr = NewRequest(1, MaxPageSize+1)
+	assert.Equal(t, uint64(MaxPageSize), pr.PageSize)
+}
+
+func TestParseDefaultPageSize(t *testing.T) {
+	assert.Equal(t, int32(fallbackPageSize), parseDefaultPageSize(""))
+	assert.Equal(t, int32(fallbackPageSize), parseDefaultPageSize("abc"))
+	assert.Equal(t, int32(fallbackPageSize), parseDefaultPageSize("-5"))
+	assert.Equal(t, int32(50), parseDefaultPageSize("50"))
+	assert.Equal(t, int32(MaxPageSize), parseDefaultPageSize("5000"))
+}
+
+func TestNewMetadata(t *testing.T) {
+	tests := []struct {
+		name       string
+		pr         *PaginationRequest
+		count      int
+		totalCount int
+		expected   Metadata
+	}{
+		{"empty", New(1, 10), 0, 0, Metadata{Count: 0, Page: 1, Pages: 0, TotalCount: 0}},
+		{"single page", New(1, 10), 7, 7, Metadata{Count: 7, Page: 1, Pages: 1, TotalCount: 7}},
+		{"full pages", New(2, 10), 10, 20, Metadata{Count: 10, Page: 2, Pages: 2, TotalCount: 20}},
+		{"last partial page", New(3, 10), 5, 25, Metadata{Count: 5, Page: 3, Pages: 3, TotalCount: 25}},
+		{"past last page", New(4, 10), 0, 25, Metadata{Count: 0, Page: 4, Pages: 3, TotalCount: 25}},
+		{"no pagination", nil, 25, 25, Metadata{Count: 25, Page: 1, Pages: 1, TotalCount: 25}},
+		{"no page size", &PaginationRequest{}, 25, 25, Metadata{Count: 25, Page: 1, Pages: 1, TotalCount: 25}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, NewMetadata(test.pr, test.count, test.totalCount), test.name)
+	}
+}
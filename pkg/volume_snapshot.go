@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/ptr"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"strings"
+)
+
+const (
+	// volumeSnapshotGroup is the api group of kubernetes volume snapshots
+	volumeSnapshotGroup = "snapshot.storage.k8s.io"
+	// volumeSnapshotGroupVersion is the group version of kubernetes volume snapshots
+	volumeSnapshotGroupVersion = volumeSnapshotGroup + "/v1beta1"
+)
+
+// VolumeSnapshot is a kubernetes snapshot of a persistent volume claim
+type VolumeSnapshot struct {
+	Name                      string
+	Namespace                 string
+	PersistentVolumeClaimName string // name of the claim the snapshot was taken from
+}
+
+// VolumeSnapshotSource gets volume snapshots
+type VolumeSnapshotSource interface {
+	// GetVolumeSnapshot returns the snapshot, or nil if it does not exist
+	GetVolumeSnapshot(namespace, name string) (*VolumeSnapshot, error)
+}
+
+// volumeSnapshotAPISource reads volume snapshots from the kubernetes api
+type volumeSnapshotAPISource struct {
+	client rest.Interface
+}
+
+// GetVolumeSnapshot gets the snapshot from the kubernetes api
+func (s *volumeSnapshotAPISource) GetVolumeSnapshot(namespace, name string) (*VolumeSnapshot, error) {
+	data, err := s.client.Get().
+		AbsPath("/apis", volumeSnapshotGroupVersion, "namespaces", namespace, "volumesnapshots", name).
+		DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshot := &struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Source struct {
+				PersistentVolumeClaimName *string `json:"persistentVolumeClaimName"`
+			} `json:"source"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+
+	result := &VolumeSnapshot{
+		Name:      snapshot.Metadata.Name,
+		Namespace: snapshot.Metadata.Namespace,
+	}
+	if snapshot.Spec.Source.PersistentVolumeClaimName != nil {
+		result.PersistentVolumeClaimName = *snapshot.Spec.Source.PersistentVolumeClaimName
+	}
+
+	return result, nil
+}
+
+// WithVolumeSnapshotSource returns a shallow copy of the client that gets volume snapshots from source instead of the kubernetes api
+func (c *Client) WithVolumeSnapshotSource(source VolumeSnapshotSource) *Client {
+	client := *c
+	client.volumeSnapshotSource = source
+
+	return &client
+}
+
+// getVolumeSnapshot gets the snapshot with the id, which is either the name of the snapshot or namespace/name.
+// The snapshot has to be in namespace.
+func (c *Client) getVolumeSnapshot(namespace, id string) (*VolumeSnapshot, error) {
+	name := id
+	if parts := strings.SplitN(id, "/", 2); len(parts) == 2 {
+		if parts[0] != namespace {
+			return nil, util.NewUserError(codes.InvalidArgument, "Snapshot must be in the same namespace as the workspace.")
+		}
+		name = parts[1]
+	}
+
+	source := c.volumeSnapshotSource
+	if source == nil {
+		source = &volumeSnapshotAPISource{client: c.Discovery().RESTClient()}
+	}
+
+	snapshot, err := source.GetVolumeSnapshot(namespace, name)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Snapshot":  id,
+			"Error":     err.Error(),
+		}).Error("Unable to get volume snapshot.")
+		return nil, util.NewUserError(codes.Unknown, "Unable to get snapshot.")
+	}
+	if snapshot == nil {
+		return nil, util.NewUserError(codes.NotFound, "Snapshot not found.")
+	}
+	if snapshot.Namespace != "" && snapshot.Namespace != namespace {
+		return nil, util.NewUserError(codes.InvalidArgument, "Snapshot must be in the same namespace as the workspace.")
+	}
+
+	return snapshot, nil
+}
+
+// getWorkspaceVolumeClaims returns the persistent volume claims of the workspace, the same way they are generated
+// for the workspace stateful set, with sizes set from the workspace parameters.
+func getWorkspaceVolumeClaims(spec *WorkspaceSpec, parameters []Parameter) (claims []corev1.PersistentVolumeClaim, err error) {
+	volumeSize := func(name string) (resource.Quantity, error) {
+		size := "20480"
+		for _, p := range parameters {
+			if p.Name == fmt.Sprintf("sys-%v-volume-size", name) && p.Value != nil {
+				size = *p.Value
+			}
+		}
+		return resource.ParseQuantity(size + "Mi")
+	}
+
+	volumeClaimsMapped := make(map[string]bool)
+	for _, v := range spec.VolumeClaimTemplates {
+		if volumeClaimsMapped[v.Name] {
+			continue
+		}
+
+		claim := *v.DeepCopy()
+		if claim.Spec.StorageClassName == nil {
+			claim.Spec.StorageClassName = ptr.String("onepanel")
+		}
+		if claim.Spec.Resources.Requests == nil {
+			size, err := volumeSize(v.Name)
+			if err != nil {
+				return nil, err
+			}
+			claim.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: size}
+		}
+		claims = append(claims, claim)
+
+		volumeClaimsMapped[v.Name] = true
+	}
+
+	volumeClaimsMapped["sys-dshm"] = true
+	volumeClaimsMapped["sys-namespace-config"] = true
+	for _, container := range spec.Containers {
+		for _, v := range container.VolumeMounts {
+			if volumeClaimsMapped[v.Name] {
+				continue
+			}
+
+			size, err := volumeSize(v.Name)
+			if err != nil {
+				return nil, err
+			}
+			claims = append(claims, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: v.Name,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: ptr.String("onepanel"),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+					},
+				},
+			})
+
+			volumeClaimsMapped[v.Name] = true
+		}
+	}
+
+	return
+}
+
+// createWorkspaceVolumeFromSnapshot creates the persistent volume claim of the workspace volume the snapshot was taken from,
+// with the snapshot as its data source. The claim is named the way the workspace stateful set names its claims,
+// so the stateful set uses it instead of creating an empty one.
+// The volume is the one whose claim name is the prefix of the snapshot's claim, or the only volume of the workspace.
+// The name of the created claim is returned, so it can be deleted if the workspace can not be created.
+func (c *Client) createWorkspaceVolumeFromSnapshot(namespace string, workspace *Workspace, snapshot *VolumeSnapshot) (claimName string, err error) {
+	spec, err := parseWorkspaceSpec(workspace.WorkspaceTemplate.Manifest)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := getWorkspaceVolumeClaims(spec, workspace.Parameters)
+	if err != nil {
+		return "", util.NewUserError(codes.InvalidArgument, err.Error())
+	}
+
+	var claim *corev1.PersistentVolumeClaim
+	for i := range claims {
+		if strings.HasPrefix(snapshot.PersistentVolumeClaimName, claims[i].Name+"-") {
+			claim = &claims[i]
+			break
+		}
+	}
+	if claim == nil && len(claims) == 1 {
+		claim = &claims[0]
+	}
+	if claim == nil {
+		return "", util.NewUserError(codes.InvalidArgument, "Snapshot does not match any volume of the workspace.")
+	}
+
+	claim.Name = fmt.Sprintf("%v-%v-0", claim.Name, workspace.UID)
+	claim.Namespace = namespace
+	claim.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.String(volumeSnapshotGroup),
+		Kind:     "VolumeSnapshot",
+		Name:     snapshot.Name,
+	}
+
+	if _, err := c.CoreV1().PersistentVolumeClaims(namespace).Create(claim); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       workspace.UID,
+			"Snapshot":  snapshot.Name,
+			"Error":     err.Error(),
+		}).Error("Unable to create volume from snapshot.")
+		return "", util.NewUserError(codes.Unknown, "Unable to create volume from snapshot.")
+	}
+
+	return claim.Name, nil
+}
+
+// deleteWorkspaceVolume deletes the persistent volume claim created by createWorkspaceVolumeFromSnapshot
+// for a workspace that could not be created. The workspace already failed, so an error is only logged.
+func (c *Client) deleteWorkspaceVolume(namespace, claimName string) {
+	if err := c.CoreV1().PersistentVolumeClaims(namespace).Delete(claimName, &metav1.DeleteOptions{}); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"ClaimName": claimName,
+			"Error":     err.Error(),
+		}).Error("Unable to delete volume of workspace that was not created.")
+	}
+}
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onepanelio/core/pkg/util"
+	"google.golang.org/grpc/codes"
+)
+
+// WatchGroupCloseTimeout is how long to wait for watchers to stop on shutdown
+const WatchGroupCloseTimeout = 10 * time.Second
+
+// WatchGroup tracks the goroutines started by watch methods, such as WatchWorkflowExecution,
+// so they can all be stopped when the server shuts down.
+// A WatchGroup is shared by all of the clients created for a server.
+type WatchGroup struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	mutex     sync.Mutex
+	closed    bool // set by Close, once no more watchers are accepted
+	wg        sync.WaitGroup
+	workflows *workflowWatchBroker
+}
+
+// NewWatchGroup creates a WatchGroup with no active watchers
+func NewWatchGroup() *WatchGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WatchGroup{
+		ctx:       ctx,
+		cancel:    cancel,
+		workflows: newWorkflowWatchBroker(),
+	}
+}
+
+// Go runs watch in a new goroutine. The context passed to watch is done once parent is done or the group is closed.
+// Once the group is closing, watch is not run and a codes.Unavailable error is returned.
+// A nil WatchGroup only uses parent.
+func (g *WatchGroup) Go(parent context.Context, watch func(ctx context.Context)) error {
+	ctx, cancel := context.WithCancel(parent)
+
+	if g == nil {
+		go func() {
+			defer cancel()
+			watch(ctx)
+		}()
+		return nil
+	}
+
+	// Watchers are only added while the group is open, so none are added once Close waits for them
+	g.mutex.Lock()
+	if g.closed {
+		g.mutex.Unlock()
+		cancel()
+		return util.NewUserError(codes.Unavailable, "Server is shutting down.")
+	}
+	g.wg.Add(1)
+	g.mutex.Unlock()
+
+	go func() {
+		select {
+		case <-g.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		defer g.wg.Done()
+		defer cancel()
+		watch(ctx)
+	}()
+
+	return nil
+}
+
+// Close signals all watchers to stop and waits up to timeout for them to finish. No new watchers are started after.
+// An error is returned if some watchers are still running after timeout.
+func (g *WatchGroup) Close(timeout time.Duration) error {
+	if g == nil {
+		return nil
+	}
+
+	g.mutex.Lock()
+	g.closed = true
+	g.cancel()
+	g.mutex.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("watchers did not stop within %v", timeout)
+	}
+}
+
+// workflowWatchKey identifies a watched workflow
+type workflowWatchKey struct {
+	namespace string
+	uid       string
+}
+
+// sharedWorkflowWatch is a watch of a workflow shared by its subscribers
+type sharedWorkflowWatch struct {
+	subscribers map[chan *WorkflowExecution]struct{}
+	latest      *WorkflowExecution // the last state broadcast, sent first to new subscribers
+	cancel      context.CancelFunc
+	done        chan struct{} // closed once the watch ends and the subscribers are closed
+}
+
+// workflowWatchBroker keeps a single watch per workflow, broadcasting each state of the workflow to all of its subscribers.
+// The watch is opened by the first subscriber and stopped once the last one unsubscribes.
+type workflowWatchBroker struct {
+	mutex   sync.Mutex
+	watches map[workflowWatchKey]*sharedWorkflowWatch
+}
+
+// newWorkflowWatchBroker creates a workflowWatchBroker without any watches
+func newWorkflowWatchBroker() *workflowWatchBroker {
+	return &workflowWatchBroker{
+		watches: make(map[workflowWatchKey]*sharedWorkflowWatch),
+	}
+}
+
+// subscribe returns a channel with the states of the workflow, until it finishes or ctx is done.
+// If the workflow is not watched yet, open is called to watch it. The watch it opens must stop once its context is done.
+func (b *workflowWatchBroker) subscribe(ctx context.Context, namespace, uid string, open func(ctx context.Context, namespace, uid string) (<-chan *WorkflowExecution, error)) (<-chan *WorkflowExecution, error) {
+	key := workflowWatchKey{namespace: namespace, uid: uid}
+
+	b.mutex.Lock()
+	if shared, ok := b.watches[key]; ok {
+		subscriber := b.addSubscriber(ctx, key, shared)
+		b.mutex.Unlock()
+		return subscriber, nil
+	}
+	b.mutex.Unlock()
+
+	// The watch is opened without holding the lock, as it calls kubernetes
+	watchCtx, cancel := context.WithCancel(context.Background())
+	states, err := open(watchCtx, namespace, uid)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	shared, ok := b.watches[key]
+	if ok {
+		// Another subscriber opened a watch in the meantime, so ours is not needed
+		cancel()
+	} else {
+		shared = &sharedWorkflowWatch{
+			subscribers: make(map[chan *WorkflowExecution]struct{}),
+			cancel:      cancel,
+			done:        make(chan struct{}),
+		}
+		b.watches[key] = shared
+		go b.broadcast(key, shared, states)
+	}
+
+	return b.addSubscriber(ctx, key, shared), nil
+}
+
+// addSubscriber adds a subscriber to the watch, sending it the latest state of the workflow if there is one.
+// The subscriber is removed once ctx is done. The broker lock must be held.
+func (b *workflowWatchBroker) addSubscriber(ctx context.Context, key workflowWatchKey, shared *sharedWorkflowWatch) chan *WorkflowExecution {
+	subscriber := make(chan *WorkflowExecution, 1)
+	shared.subscribers[subscriber] = struct{}{}
+	if shared.latest != nil {
+		sendLatestWorkflowExecution(subscriber, shared.latest)
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(key, shared, subscriber)
+		case <-shared.done:
+		}
+	}()
+
+	return subscriber
+}
+
+// broadcast sends each state of the workflow to the subscribers of the watch.
+// Once there are no more states, the subscribers are closed.
+func (b *workflowWatchBroker) broadcast(key workflowWatchKey, shared *sharedWorkflowWatch, states <-chan *WorkflowExecution) {
+	for state := range states {
+		b.mutex.Lock()
+		shared.latest = state
+		for subscriber := range shared.subscribers {
+			sendLatestWorkflowExecution(subscriber, state)
+		}
+		b.mutex.Unlock()
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.watches[key] == shared {
+		delete(b.watches, key)
+	}
+	for subscriber := range shared.subscribers {
+		close(subscriber)
+	}
+	shared.subscribers = nil
+	shared.cancel()
+	close(shared.done)
+}
+
+// unsubscribe closes the subscriber and stops the watch if it was the last subscriber
+func (b *workflowWatchBroker) unsubscribe(key workflowWatchKey, shared *sharedWorkflowWatch, subscriber chan *WorkflowExecution) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// The subscriber was already closed if the watch ended
+	if _, ok := shared.subscribers[subscriber]; !ok {
+		return
+	}
+
+	delete(shared.subscribers, subscriber)
+	close(subscriber)
+
+	if len(shared.subscribers) == 0 {
+		if b.watches[key] == shared {
+			delete(b.watches, key)
+		}
+		shared.cancel()
+	}
+}
+
+// sendLatestWorkflowExecution sends the state to the subscriber, replacing the state it has not received yet, if any.
+// Only the latest state of a workflow matters, so a slow subscriber skips states instead of holding up the others.
+// The subscriber must have a buffer of one and only be sent to while holding the broker lock.
+func sendLatestWorkflowExecution(subscriber chan *WorkflowExecution, state *WorkflowExecution) {
+	select {
+	case subscriber <- state:
+	default:
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- state
+	}
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	argoFake "github.com/argoproj/argo/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_Close makes sure all of the active workflow execution watchers exit once the client is closed
+func TestClient_Close(t *testing.T) {
+	c := DefaultTestClient().WithWatchGroup(NewWatchGroup())
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var watchers []<-chan *WorkflowExecution
+	for i := 0; i < 3; i++ {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		watcher, err := c.WatchWorkflowExecution(namespace, we.UID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		watchers = append(watchers, watcher)
+	}
+
+	assert.Nil(t, c.Close())
+
+	for _, watcher := range watchers {
+		select {
+		case _, ok := <-watcher:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("watcher did not stop after Close")
+		}
+	}
+}
+
+// TestWatchGroup_Close_Timeout makes sure Close reports watchers that do not stop in time
+func TestWatchGroup_Close_Timeout(t *testing.T) {
+	g := NewWatchGroup()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	g.Go(context.Background(), func(ctx context.Context) {
+		<-release
+	})
+
+	assert.NotNil(t, g.Close(10*time.Millisecond))
+}
+
+// TestWatchGroup_Go_Closed makes sure no watchers are started once the group is closing
+func TestWatchGroup_Go_Closed(t *testing.T) {
+	g := NewWatchGroup()
+	assert.Nil(t, g.Close(10*time.Millisecond))
+
+	ran := make(chan struct{}, 1)
+	err := g.Go(context.Background(), func(ctx context.Context) {
+		ran <- struct{}{}
+	})
+	assertUserErrorCode(t, err, codes.Unavailable)
+
+	select {
+	case <-ran:
+		t.Error("watcher started after the group was closed")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// TestClient_WatchWorkflowExecution_Shared makes sure clients sharing a watch group share a single watch of a workflow,
+// which keeps going until the last subscriber is gone
+func TestClient_WatchWorkflowExecution_Shared(t *testing.T) {
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	var watches int32
+	fakeWatcher := watch.NewFakeWithChanSize(1, false)
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependWatchReactor("workflows", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		atomic.AddInt32(&watches, 1)
+		return true, fakeWatcher, nil
+	})
+
+	c := DefaultTestClient().WithWatchGroup(NewWatchGroup())
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+	defer c.Close()
+
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	receive := func(watcher <-chan *WorkflowExecution) *WorkflowExecution {
+		select {
+		case workflow, ok := <-watcher:
+			if !ok {
+				t.Fatal("watcher closed")
+			}
+			return workflow
+		case <-time.After(time.Second):
+			t.Fatal("no state received")
+		}
+		return nil
+	}
+	assertClosed := func(watcher <-chan *WorkflowExecution) {
+		select {
+		case _, ok := <-watcher:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("watcher was not closed")
+		}
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	first, err := c.WithContext(firstCtx).WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+	second, err := c.WithContext(secondCtx).WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both get the current state from the same watch
+	assert.Equal(t, we.UID, receive(first).Name)
+	assert.Equal(t, we.UID, receive(second).Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&watches))
+
+	cancelSecond()
+	assertClosed(second)
+
+	fakeWatcher.Modify(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: we.UID, ResourceVersion: "2"},
+		Status: wfv1.WorkflowStatus{
+			Phase:     wfv1.NodeRunning,
+			StartedAt: metav1.Now(),
+		},
+	})
+
+	wf := &wfv1.Workflow{}
+	assert.Nil(t, json.Unmarshal([]byte(receive(first).Manifest), wf))
+	assert.False(t, wf.Status.StartedAt.IsZero())
+	assert.False(t, fakeWatcher.IsStopped())
+
+	// The watch is stopped once the last subscriber is gone
+	cancelFirst()
+	assertClosed(first)
+	assert.Eventually(t, fakeWatcher.IsStopped, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&watches))
+}
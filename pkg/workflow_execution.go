@@ -1,7 +1,7 @@
 package v1
 
 import (
-	"bufio"
+	"bytes"
 	"cloud.google.com/go/storage"
 	"database/sql"
 	"encoding/json"
@@ -14,6 +14,7 @@ import (
 	"github.com/onepanelio/core/pkg/util/gcs"
 	"github.com/onepanelio/core/pkg/util/label"
 	"github.com/onepanelio/core/pkg/util/ptr"
+	"github.com/onepanelio/core/pkg/util/ratelimit"
 	"github.com/onepanelio/core/pkg/util/request"
 	"github.com/onepanelio/core/pkg/util/types"
 	uid2 "github.com/onepanelio/core/pkg/util/uid"
@@ -23,9 +24,11 @@ import (
 	"io/ioutil"
 	networking "istio.io/api/networking/v1alpha3"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/watch"
 	"net/http"
 	yaml2 "sigs.k8s.io/yaml"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -42,6 +45,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -51,8 +55,66 @@ var (
 	readEndOffset                   = env.GetEnv("ARTIFACT_RERPOSITORY_OBJECT_RANGE", "-102400")
 	workflowTemplateUIDLabelKey     = "onepanel.io/workflow-template-uid"
 	workflowTemplateVersionLabelKey = "onepanel.io/workflow-template-version"
+	// workflowTerminationReasonAnnotationKey is the annotation with why the workflow was terminated
+	workflowTerminationReasonAnnotationKey = "onepanel.io/termination-reason"
+	// parentWorkflowLabelKey is the label with the name of the workflow that spawned the workflow,
+	// for child workflows that are not owned by their parent
+	parentWorkflowLabelKey = "onepanel.io/parent-workflow"
 )
 
+// idempotencyKeyTTL is how long the idempotency key of a workflow execution is honored after it is created
+const idempotencyKeyTTL = 24 * time.Hour
+
+// fallbackWorkflowExecutionRateLimitBurst is the burst if WORKFLOW_EXECUTION_RATE_LIMIT_BURST is not set
+const fallbackWorkflowExecutionRateLimitBurst = 10
+
+// defaultWorkflowExecutionLimiter limits how fast each namespace can create workflow executions.
+// It allows WORKFLOW_EXECUTION_RATE_LIMIT executions per second, with bursts of up to WORKFLOW_EXECUTION_RATE_LIMIT_BURST.
+// If WORKFLOW_EXECUTION_RATE_LIMIT is not set, there is no limit.
+var defaultWorkflowExecutionLimiter = newWorkflowExecutionLimiter(
+	env.GetEnv("WORKFLOW_EXECUTION_RATE_LIMIT", ""),
+	env.GetEnv("WORKFLOW_EXECUTION_RATE_LIMIT_BURST", ""),
+)
+
+// newWorkflowExecutionLimiter parses the configured rate limit and burst. Invalid values disable the limit
+// or use fallbackWorkflowExecutionRateLimitBurst respectively.
+func newWorkflowExecutionLimiter(limitValue, burstValue string) *ratelimit.KeyedLimiter {
+	limit := float64(0)
+	if limitValue != "" {
+		parsed, err := strconv.ParseFloat(limitValue, 64)
+		if err != nil || parsed < 0 {
+			log.WithFields(log.Fields{
+				"Value": limitValue,
+			}).Warn("Invalid WORKFLOW_EXECUTION_RATE_LIMIT, workflow executions are not rate limited.")
+		} else {
+			limit = parsed
+		}
+	}
+
+	burst := fallbackWorkflowExecutionRateLimitBurst
+	if burstValue != "" {
+		parsed, err := strconv.Atoi(burstValue)
+		if err != nil || parsed <= 0 {
+			log.WithFields(log.Fields{
+				"Value": burstValue,
+			}).Warn("Invalid WORKFLOW_EXECUTION_RATE_LIMIT_BURST, using the fallback burst.")
+		} else {
+			burst = parsed
+		}
+	}
+
+	return ratelimit.NewKeyedLimiter(limit, burst)
+}
+
+// WithWorkflowExecutionLimiter returns a shallow copy of the client that rate limits the creation of workflow executions
+// with limiter instead of the one configured from the environment
+func (c *Client) WithWorkflowExecutionLimiter(limiter *ratelimit.KeyedLimiter) *Client {
+	client := *c
+	client.workflowExecutionLimiter = limiter
+
+	return &client
+}
+
 func typeWorkflow(wf *wfv1.Workflow) (workflow *WorkflowExecution) {
 	manifest, err := json.Marshal(wf)
 	if err != nil {
@@ -68,10 +130,70 @@ func typeWorkflow(wf *wfv1.Workflow) (workflow *WorkflowExecution) {
 	return
 }
 
+// typeRedactedWorkflow converts wf like typeWorkflow, after redacting the values of the secrets of the parameters
+// the workflow execution with the uid was created with, see redactWorkflowSecrets
+func (c *Client) typeRedactedWorkflow(namespace, uid string, wf *wfv1.Workflow) (*WorkflowExecution, error) {
+	// Without the parameters it is not known which values are secrets, so nothing is returned
+	parameters, err := c.getWorkflowExecutionParameters(namespace, uid)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to get the parameters of the workflow.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow.")
+	}
+
+	redacted, err := redactWorkflowSecrets(wf, parameters)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to redact the secrets of the workflow.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow.")
+	}
+
+	return typeWorkflow(redacted), nil
+}
+
+// getWorkflowExecutionParameters returns the parameters the workflow execution was created with, as they were saved.
+// A workflow execution that was not saved, or saved without parameters, has none. Any other error is returned.
+func (c *Client) getWorkflowExecutionParameters(namespace, uid string) ([]Parameter, error) {
+	var parametersBytes []byte
+	err := sb.Select("parameters").
+		From("workflow_executions").
+		Where(sq.Eq{
+			"namespace": namespace,
+			"name":      uid,
+		}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&parametersBytes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if parametersBytes == nil {
+		return nil, nil
+	}
+
+	parameters := make([]Parameter, 0)
+	if err := json.Unmarshal(parametersBytes, &parameters); err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
 // WorkflowExecutionFilter represents the available ways we can filter WorkflowExecutions
 type WorkflowExecutionFilter struct {
-	Labels []*Label
-	Phase  string // empty string means none
+	Labels       []*Label
+	Phase        string // empty string means none
+	NameContains string // case-insensitive, empty string means none
+	CreatedBy    string // identity of the creator, see Client.Identity. Empty string means none
 }
 
 // GetLabels returns the labels in the filter
@@ -94,6 +216,16 @@ func applyWorkflowExecutionFilter(sb sq.SelectBuilder, request *request.Request)
 		return sb, err
 	}
 
+	if filter.NameContains != "" {
+		sb = sb.Where("we.name ILIKE ?", "%"+escapeLikePattern(filter.NameContains)+"%")
+	}
+
+	if filter.CreatedBy != "" {
+		sb = sb.Where(sq.Eq{
+			"we.created_by": filter.CreatedBy,
+		})
+	}
+
 	switch filter.Phase {
 	case "":
 		return sb, nil
@@ -125,6 +257,11 @@ func applyWorkflowExecutionFilter(sb sq.SelectBuilder, request *request.Request)
 	return sb, nil
 }
 
+// escapeLikePattern escapes the characters with a special meaning in a LIKE pattern, so value is matched as is
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func UnmarshalWorkflows(wfBytes []byte, strict bool) (wfs []wfv1.Workflow, err error) {
 	if len(wfBytes) == 0 {
 		return nil, fmt.Errorf("UnmarshalWorkflows unable to work on empty bytes")
@@ -168,7 +305,7 @@ func getWorkflowsFromWorkflowTemplate(wt *WorkflowTemplate) (wfs []wfv1.Workflow
 // appendArtifactRepositoryConfigIfMissing appends default artifact repository config to artifacts that have a key.
 // Artifacts that contain anything other than key are skipped.
 func injectArtifactRepositoryConfig(artifact *wfv1.Artifact, namespaceConfig *NamespaceConfig) {
-	if artifact.S3 != nil && artifact.S3.Key != "" && artifact.S3.Bucket == "" {
+	if artifact.S3 != nil && artifact.S3.Key != "" && artifact.S3.Bucket == "" && namespaceConfig.ArtifactRepository.S3 != nil {
 		s3Config := namespaceConfig.ArtifactRepository.S3
 		artifact.S3.Endpoint = s3Config.Endpoint
 		artifact.S3.Bucket = s3Config.Bucket
@@ -233,7 +370,7 @@ func injectEnvironmentVariables(container *corev1.Container, systemConfig System
 	env.PrependEnvVarToContainer(container, "ONEPANEL_RESOURCE_UID", "{{workflow.name}}")
 }
 
-func (c *Client) injectAutomatedFields(namespace string, wf *wfv1.Workflow, opts *WorkflowExecutionOptions) (err error) {
+func (c *Client) injectAutomatedFields(namespace string, namespaceConfig *NamespaceConfig, wf *wfv1.Workflow, opts *WorkflowExecutionOptions) (err error) {
 	if opts.PodGCStrategy == nil {
 		if wf.Spec.PodGC == nil {
 			//TODO - Load this data from onepanel config-map or secret
@@ -269,10 +406,6 @@ func (c *Client) injectAutomatedFields(namespace string, wf *wfv1.Workflow, opts
 	if err != nil {
 		return err
 	}
-	namespaceConfig, err := c.GetNamespaceConfig(namespace)
-	if err != nil {
-		return err
-	}
 	for i := range wf.Spec.Templates {
 		template := &wf.Spec.Templates[i]
 
@@ -367,14 +500,8 @@ func (c *Client) ArchiveWorkflowExecution(namespace, uid string) error {
 	return nil
 }
 
-// createWorkflow creates the workflow in the database and argo.
-// Name is == to UID, no user friendly name.
-// Workflow execution name == uid, example: name = my-friendly-wf-name-8skjz, uid = my-friendly-wf-name-8skjz
-func (c *Client) createWorkflow(namespace string, workflowTemplateID uint64, workflowTemplateVersionID uint64, wf *wfv1.Workflow, opts *WorkflowExecutionOptions, labels types.JSONLabels) (createdWorkflow *WorkflowExecution, err error) {
-	if opts == nil {
-		opts = &WorkflowExecutionOptions{}
-	}
-
+// applyWorkflowExecutionOptions sets the fields of the argo workflow that are overridden by opts
+func applyWorkflowExecutionOptions(wf *wfv1.Workflow, opts *WorkflowExecutionOptions) {
 	if opts.Name != "" {
 		wf.ObjectMeta.Name = opts.Name
 	}
@@ -410,146 +537,644 @@ func (c *Client) createWorkflow(namespace string, workflowTemplateID uint64, wor
 	if opts.Labels != nil {
 		wf.ObjectMeta.Labels = opts.Labels
 	}
+}
 
-	if err = injectWorkflowExecutionStatusCaller(wf, wfv1.NodeRunning); err != nil {
-		return nil, err
+// mergeDefaultParameters appends the defaults that are not already in parameters, so values set by the client take precedence.
+func mergeDefaultParameters(parameters []Parameter, defaults []Parameter) []Parameter {
+	passedParams := make(map[string]bool)
+	for _, param := range parameters {
+		passedParams[param.Name] = true
 	}
 
-	if err = injectExitHandlerWorkflowExecutionStatistic(wf, &workflowTemplateID); err != nil {
-		return nil, err
+	for _, param := range defaults {
+		if _, ok := passedParams[param.Name]; ok {
+			continue
+		}
+		parameters = append(parameters, param)
 	}
 
-	if err = c.injectAutomatedFields(namespace, wf, opts); err != nil {
-		return nil, err
+	return parameters
+}
+
+// renderWorkflow applies opts to the argo workflow and injects the system fields, resulting in the workflow that is submitted to argo.
+// The namespace placeholders of the workflow are substituted first, so they are not substituted in the parameters, see applyNamespaceSubstitutions.
+// The namespace default parameters are added to opts.Parameters, see GetNamespaceDefaultParameters.
+// The namespace config is loaded once and used by each step that needs it.
+// Workflows created from a workflow template and from a raw manifest are both rendered by it, so they get the same steps.
+// If workflowTemplateID is nil, the workflow has no workflow template and is not tracked in the database,
+// so it does not report the status and statistics of its workflow execution.
+func (c *Client) renderWorkflow(namespace string, workflowTemplateID *uint64, wf *wfv1.Workflow, opts *WorkflowExecutionOptions) error {
+	namespaceConfig, err := c.GetNamespaceConfig(namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := applyNamespaceSubstitutions(namespaceConfig, wf); err != nil {
+		return err
+	}
+
+	defaultParameters, err := parseNamespaceDefaultParameters(namespace, namespaceConfig.configMap)
+	if err != nil {
+		return err
+	}
+	opts.Parameters = mergeDefaultParameters(opts.Parameters, defaultParameters)
+
+	applyWorkflowExecutionOptions(wf, opts)
+
+	if err := applyWorkflowTTL(namespace, namespaceConfig, wf, opts.TTLSecondsAfterFinished); err != nil {
+		return err
+	}
+
+	if err := applyWorkflowActiveDeadline(wf, opts.ActiveDeadlineSeconds); err != nil {
+		return err
+	}
+
+	if err := applyWorkflowPriority(namespace, namespaceConfig, wf, opts.Priority); err != nil {
+		return err
+	}
+
+	if err := applyWorkflowScheduling(wf, opts.NodeSelector, opts.Tolerations); err != nil {
+		return err
+	}
+
+	applyWorkflowFailFast(wf, opts.FailFast)
+
+	if err := applyCostLabels(namespace, namespaceConfig, wf, opts); err != nil {
+		return err
+	}
+
+	if err := applyDefaultResources(namespace, namespaceConfig, wf); err != nil {
+		return err
+	}
+
+	if workflowTemplateID != nil {
+		if err := injectWorkflowExecutionStatusCaller(wf, wfv1.NodeRunning); err != nil {
+			return err
+		}
+
+		if err := injectExitHandlerWorkflowExecutionStatistic(wf, workflowTemplateID); err != nil {
+			return err
+		}
+	}
+
+	if err := c.injectAutomatedFields(namespace, namespaceConfig, wf, opts); err != nil {
+		return err
 	}
 
 	newTemplateOrder, err := c.injectAccessForSidecars(namespace, wf)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	wf.Spec.Templates = newTemplateOrder
-	createdArgoWorkflow, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(wf)
+
+	return nil
+}
+
+// applyNamespaceSubstitutions replaces the placeholders of the workflow, like {{.Domain}}, with their values in the namespace,
+// see GetNamespaceManifestSubstitutions. Placeholders without a value are left as is.
+func applyNamespaceSubstitutions(namespaceConfig *NamespaceConfig, wf *wfv1.Workflow) error {
+	substitutions := parseNamespaceManifestSubstitutions(namespaceConfig.configMap)
+	if len(substitutions) == 0 {
+		return nil
+	}
+
+	replacements := make([]string, 0)
+	for placeholder, value := range substitutions {
+		// The values are replaced in the JSON of the workflow, so they are escaped like JSON strings
+		escaped, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		replacements = append(replacements, placeholder, string(escaped[1:len(escaped)-1]))
+	}
+
+	manifest, err := json.Marshal(wf)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	manifest = []byte(strings.NewReplacer(replacements...).Replace(string(manifest)))
 
-	createdWorkflow = &WorkflowExecution{
-		Name:         createdArgoWorkflow.Name,
-		CreatedAt:    createdArgoWorkflow.CreationTimestamp.UTC(),
-		ArgoWorkflow: createdArgoWorkflow,
-		WorkflowTemplate: &WorkflowTemplate{
-			WorkflowTemplateVersionID: workflowTemplateVersionID,
-		},
-		Parameters: opts.Parameters,
-		Labels:     labels,
+	substituted := wfv1.Workflow{}
+	if err := json.Unmarshal(manifest, &substituted); err != nil {
+		return err
 	}
+	*wf = substituted
 
-	if err = createdWorkflow.GenerateUID(createdArgoWorkflow.Name); err != nil {
-		return nil, err
+	return nil
+}
+
+// applyWorkflowTTL sets how long the workflow is kept after it finishes to ttl seconds.
+// If ttl is nil, the namespace's workflow TTL is used, if there is one. Otherwise the workflow's own TTL strategy is kept.
+// A negative ttl is a codes.InvalidArgument error.
+func applyWorkflowTTL(namespace string, namespaceConfig *NamespaceConfig, wf *wfv1.Workflow, ttl *int32) error {
+	if ttl == nil {
+		namespaceTTL, err := parseNamespaceWorkflowTTL(namespace, namespaceConfig.configMap)
+		if err != nil {
+			return err
+		}
+		ttl = namespaceTTL
+	}
+	if ttl == nil {
+		return nil
+	}
+	if *ttl < 0 {
+		return util.NewUserError(codes.InvalidArgument, "ttlSecondsAfterFinished can not be negative.")
 	}
 
-	//Create an entry for workflow_executions statistic
-	//CURL code will hit the API endpoint that will update the db row
-	if err := c.createWorkflowExecutionDB(namespace, createdWorkflow); err != nil {
-		return nil, err
+	// The strategy is replaced, as argo prefers a TTL for success or failure over one for completion
+	wf.Spec.TTLStrategy = &wfv1.TTLStrategy{
+		SecondsAfterCompletion: ptr.Int32(*ttl),
 	}
+	wf.Spec.TTLSecondsAfterFinished = nil
 
-	return
+	return nil
 }
 
-func (c *Client) injectAccessForSidecars(namespace string, wf *wfv1.Workflow) ([]wfv1.Template, error) {
-	var newTemplateOrder []wfv1.Template
-	taskSysSendStatusName := "sys-send-status"
-	taskSysSendExitStats := "sys-send-exit-stats"
-	for tIdx, t := range wf.Spec.Templates {
-		//Inject services, virtual routes
-		for si, s := range t.Sidecars {
-			//If TTY is true, sidecar needs to be accessible by HTTP
-			//Otherwise, we skip the sidecar
-			if s.TTY != true {
-				continue
-			}
-			if len(s.Ports) == 0 {
-				msg := fmt.Sprintf("sidecar %s must have at least one port.", s.Name)
-				return nil, util.NewUserError(codes.InvalidArgument, msg)
-			}
+// applyWorkflowActiveDeadline sets how long the workflow can run before argo fails it to deadline seconds.
+// If deadline is nil, the workflow's own deadline is kept. A deadline that is not positive is a codes.InvalidArgument error.
+func applyWorkflowActiveDeadline(wf *wfv1.Workflow, deadline *int64) error {
+	if deadline == nil {
+		return nil
+	}
+	if *deadline <= 0 {
+		return util.NewUserError(codes.InvalidArgument, "activeDeadlineSeconds must be positive.")
+	}
 
-			t.Sidecars[si].MirrorVolumeMounts = ptr.Bool(true)
-			serviceNameUID := "s" + uuid.New().String() + "--" + namespace
-			serviceNameUIDDNSCompliant, err := uid2.GenerateUID(serviceNameUID, 63)
-			if err != nil {
-				return nil, util.NewUserError(codes.InvalidArgument, err.Error())
-			}
+	wf.Spec.ActiveDeadlineSeconds = ptr.Int64(*deadline)
 
-			serviceName := serviceNameUIDDNSCompliant + "." + *c.systemConfig.Domain()
+	return nil
+}
 
-			serviceTemplateName := uuid.New().String()
-			serviceTemplateNameAdd := "sys-k8s-service-template-add-" + serviceTemplateName
-			serviceTemplateNameDelete := "sys-k8s-service-template-delete-" + serviceTemplateName
-			serviceTaskName := "service-" + uuid.New().String()
-			serviceAddTaskName := "sys-add-" + serviceTaskName
-			serviceDeleteTaskName := "sys-delete-" + serviceTaskName
-			virtualServiceTemplateName := uuid.New().String()
-			virtualServiceTemplateNameAdd := "sys-k8s-virtual-service-template-add-" + virtualServiceTemplateName
-			virtualServiceTemplateNameDelete := "sys-k8s-virtual-service-template-delete-" + virtualServiceTemplateName
-			virtualServiceTaskName := "virtual-service-" + uuid.New().String()
-			virtualServiceAddTaskName := "sys-add-" + virtualServiceTaskName
-			virtualServiceDeleteTaskName := "sys-delete-" + virtualServiceTaskName
-			var servicePorts []corev1.ServicePort
-			var routes []*networking.HTTPRoute
-			for _, port := range s.Ports {
-				servicePort := corev1.ServicePort{
-					Name:       port.Name,
-					Protocol:   port.Protocol,
-					Port:       port.ContainerPort,
-					TargetPort: intstr.FromInt(int(port.ContainerPort)),
-				}
-				servicePorts = append(servicePorts, servicePort)
-				route := networking.HTTPRoute{
-					Match: []*networking.HTTPMatchRequest{
-						{
-							Uri: &networking.StringMatch{
-								MatchType: &networking.StringMatch_Prefix{
-									Prefix: "/"},
-							},
-						},
-					},
-					Route: []*networking.HTTPRouteDestination{
-						{
-							Destination: &networking.Destination{
-								Host: serviceNameUIDDNSCompliant,
-								Port: &networking.PortSelector{
-									Number: uint32(port.ContainerPort),
-								},
-							},
-						},
-					},
-				}
-				routes = append(routes, &route)
-			}
-			service := corev1.Service{
-				TypeMeta: metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "Service",
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name: serviceNameUIDDNSCompliant,
-				},
-				Spec: corev1.ServiceSpec{
-					Ports: servicePorts,
-					Selector: map[string]string{
-						serviceTaskName: serviceNameUIDDNSCompliant,
-					},
-				},
-			}
-			//Istio needs to know which pod to setup the route to
-			if wf.Spec.Templates[tIdx].Metadata.Labels == nil {
-				wf.Spec.Templates[tIdx].Metadata.Labels = make(map[string]string)
-			}
-			wf.Spec.Templates[tIdx].Metadata.Labels[serviceTaskName] = serviceNameUIDDNSCompliant
-			serviceManifestBytes, err := yaml2.Marshal(service)
-			if err != nil {
-				return nil, err
+// applyWorkflowPriority schedules the pods of the workflow with the priority class named priority.
+// If priority is empty, the workflow's own priority class is kept.
+// Only the priority classes of the namespace can be used, see GetNamespacePriorityClasses, others are a codes.InvalidArgument error.
+func applyWorkflowPriority(namespace string, namespaceConfig *NamespaceConfig, wf *wfv1.Workflow, priority string) error {
+	if priority == "" {
+		return nil
+	}
+
+	priorityClasses, err := parseNamespacePriorityClasses(namespace, namespaceConfig.configMap)
+	if err != nil {
+		return err
+	}
+
+	for _, priorityClass := range priorityClasses {
+		if priorityClass == priority {
+			wf.Spec.PodPriorityClassName = priority
+			return nil
+		}
+	}
+
+	return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Priority %v is not allowed in the namespace.", priority))
+}
+
+// validateNodeSelector makes sure the keys of nodeSelector are qualified names and its values valid label values
+func validateNodeSelector(nodeSelector map[string]string) error {
+	keys := make([]string, 0, len(nodeSelector))
+	for key := range nodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Node selector key '%v' is not valid: %v", key, strings.Join(errs, "; ")))
+		}
+
+		if errs := validation.IsValidLabelValue(nodeSelector[key]); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Node selector value of key '%v' is not valid: %v", key, strings.Join(errs, "; ")))
+		}
+	}
+
+	return nil
+}
+
+// validateToleration makes sure toleration is well-formed, following the rules kubernetes applies to pods:
+// the operator is Equal or Exists, Exists has no value, an empty key is only allowed with Exists,
+// and tolerationSeconds is only allowed with the NoExecute effect
+func validateToleration(toleration corev1.Toleration) error {
+	if toleration.Key != "" {
+		if errs := validation.IsQualifiedName(toleration.Key); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Toleration key '%v' is not valid: %v", toleration.Key, strings.Join(errs, "; ")))
+		}
+	}
+
+	switch toleration.Operator {
+	case "", corev1.TolerationOpEqual:
+		if toleration.Key == "" {
+			return util.NewUserError(codes.InvalidArgument, "Toleration operator must be Exists when the key is empty.")
+		}
+		if errs := validation.IsValidLabelValue(toleration.Value); len(errs) > 0 {
+			return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Toleration value of key '%v' is not valid: %v", toleration.Key, strings.Join(errs, "; ")))
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			return util.NewUserError(codes.InvalidArgument, "Toleration value must be empty when the operator is Exists.")
+		}
+	default:
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Toleration operator '%v' is not supported, it must be Equal or Exists.", toleration.Operator))
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Toleration effect '%v' is not supported, it must be NoSchedule, PreferNoSchedule or NoExecute.", toleration.Effect))
+	}
+
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		return util.NewUserError(codes.InvalidArgument, "Toleration seconds can only be set with the NoExecute effect.")
+	}
+
+	return nil
+}
+
+// applyWorkflowScheduling schedules the pods of the workflow on the nodes matching nodeSelector, tolerating tolerations.
+// nodeSelector is merged into the workflow's own node selector, taking precedence for the same key,
+// and tolerations are added to the workflow's own tolerations.
+// Malformed node selectors or tolerations are a codes.InvalidArgument error.
+func applyWorkflowScheduling(wf *wfv1.Workflow, nodeSelector map[string]string, tolerations []corev1.Toleration) error {
+	if err := validateNodeSelector(nodeSelector); err != nil {
+		return err
+	}
+	for _, toleration := range tolerations {
+		if err := validateToleration(toleration); err != nil {
+			return err
+		}
+	}
+
+	if len(nodeSelector) > 0 {
+		if wf.Spec.NodeSelector == nil {
+			wf.Spec.NodeSelector = make(map[string]string)
+		}
+		for key, value := range nodeSelector {
+			wf.Spec.NodeSelector[key] = value
+		}
+	}
+	wf.Spec.Tolerations = append(wf.Spec.Tolerations, tolerations...)
+
+	return nil
+}
+
+// applyWorkflowFailFast makes every DAG of the workflow stop starting new tasks once one of its tasks fails,
+// even DAGs that disable it. The failed DAG waits for its running tasks and then fails, failing the workflow.
+// If failFast is false, the workflow's own DAGs are kept as they are.
+func applyWorkflowFailFast(wf *wfv1.Workflow, failFast bool) {
+	if !failFast {
+		return
+	}
+
+	for i := range wf.Spec.Templates {
+		if wf.Spec.Templates[i].DAG != nil {
+			wf.Spec.Templates[i].DAG.FailFast = ptr.Bool(true)
+		}
+	}
+}
+
+// applyCostLabels labels the workflow and opts with the cost labels of the namespace.
+// They take precedence over labels with the same key set by the client, so workflows are always charged to the namespace's cost center.
+func applyCostLabels(namespace string, namespaceConfig *NamespaceConfig, wf *wfv1.Workflow, opts *WorkflowExecutionOptions) error {
+	costLabels, err := parseNamespaceCostLabels(namespace, namespaceConfig.configMap)
+	if err != nil {
+		return err
+	}
+	if len(costLabels) == 0 {
+		return nil
+	}
+
+	if opts.Labels == nil {
+		opts.Labels = make(map[string]string)
+	}
+	if wf.ObjectMeta.Labels == nil {
+		wf.ObjectMeta.Labels = make(map[string]string)
+	}
+	for key, value := range costLabels {
+		opts.Labels[key] = value
+		wf.ObjectMeta.Labels[key] = value
+	}
+
+	return nil
+}
+
+// applyDefaultResources sets the namespace default resources on the containers of the templates of the workflow, see GetNamespaceDefaultResources.
+// A container only gets the defaults of resources it declares neither a request nor a limit for,
+// so resources declared by the template are kept and a default request is never above a declared limit.
+func applyDefaultResources(namespace string, namespaceConfig *NamespaceConfig, wf *wfv1.Workflow) error {
+	defaultResources, err := parseNamespaceDefaultResources(namespace, namespaceConfig.configMap)
+	if err != nil {
+		return err
+	}
+	if defaultResources == nil {
+		return nil
+	}
+
+	for i := range wf.Spec.Templates {
+		template := &wf.Spec.Templates[i]
+
+		if template.Container != nil {
+			injectDefaultResources(template.Container, defaultResources)
+		}
+		if template.Script != nil {
+			injectDefaultResources(&template.Script.Container, defaultResources)
+		}
+	}
+
+	return nil
+}
+
+// injectDefaultResources copies the requests and limits of defaultResources to the container, for resources the container does not declare
+func injectDefaultResources(container *corev1.Container, defaultResources *corev1.ResourceRequirements) {
+	declared := make(map[corev1.ResourceName]bool)
+	for name := range container.Resources.Requests {
+		declared[name] = true
+	}
+	for name := range container.Resources.Limits {
+		declared[name] = true
+	}
+
+	for name, quantity := range defaultResources.Requests {
+		if declared[name] {
+			continue
+		}
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		container.Resources.Requests[name] = quantity.DeepCopy()
+	}
+
+	for name, quantity := range defaultResources.Limits {
+		if declared[name] {
+			continue
+		}
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[name] = quantity.DeepCopy()
+	}
+}
+
+// createWorkflow creates the workflow in the database and argo.
+// Name is == to UID, no user friendly name.
+// Workflow execution name == uid, example: name = my-friendly-wf-name-8skjz, uid = my-friendly-wf-name-8skjz
+func (c *Client) createWorkflow(namespace string, workflowTemplateID uint64, workflowTemplateVersionID uint64, wf *wfv1.Workflow, opts *WorkflowExecutionOptions, labels types.JSONLabels) (createdWorkflow *WorkflowExecution, err error) {
+	if opts == nil {
+		opts = &WorkflowExecutionOptions{}
+	}
+
+	opts.Parameters, err = c.resolveParameterSecretRefs(namespace, opts.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.renderWorkflow(namespace, &workflowTemplateID, wf, opts); err != nil {
+		return nil, err
+	}
+
+	var createdArgoWorkflow *wfv1.Workflow
+	err = c.runKubeMutation("CreateWorkflow", func() (err error) {
+		createdArgoWorkflow, err = c.ArgoprojV1alpha1().Workflows(namespace).Create(wf)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createdWorkflow = &WorkflowExecution{
+		Name:         createdArgoWorkflow.Name,
+		CreatedAt:    createdArgoWorkflow.CreationTimestamp.UTC(),
+		ArgoWorkflow: createdArgoWorkflow,
+		WorkflowTemplate: &WorkflowTemplate{
+			WorkflowTemplateVersionID: workflowTemplateVersionID,
+		},
+		Parameters:     redactParameterSecrets(opts.Parameters),
+		Labels:         labels,
+		IdempotencyKey: opts.IdempotencyKey,
+	}
+
+	if err = createdWorkflow.GenerateUID(createdArgoWorkflow.Name); err != nil {
+		return nil, err
+	}
+
+	//Create an entry for workflow_executions statistic
+	//CURL code will hit the API endpoint that will update the db row
+	if err := c.createWorkflowExecutionDB(namespace, createdWorkflow); err != nil {
+		return nil, err
+	}
+
+	c.createAuditEvent(namespace, AuditActionCreateWorkflowExecution, createdWorkflow.UID, createdWorkflow.Parameters, "")
+
+	return
+}
+
+// resolveParameterSecretRefs returns a copy of parameters, where the value of each parameter with a SecretRef
+// is read from the key of the secret. Only secrets in the namespace can be referenced.
+// The values are never logged.
+func (c *Client) resolveParameterSecretRefs(namespace string, parameters []Parameter) ([]Parameter, error) {
+	resolved := make([]Parameter, len(parameters))
+	for i, parameter := range parameters {
+		resolved[i] = parameter
+		if parameter.SecretRef == nil {
+			continue
+		}
+
+		// The placeholder returned instead of the value of the secret is not a value, so parameters can be sent back as they were returned
+		if parameter.Value != nil && *parameter.Value != redactedParameterValue {
+			return nil, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Parameter '%v' can not have both a value and a secretRef.", parameter.Name))
+		}
+
+		ref, err := ParseSecretKeyRef(namespace, *parameter.SecretRef)
+		if err != nil {
+			return nil, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Parameter '%v': %v.", parameter.Name, err.Error()))
+		}
+		if ref.Namespace != namespace {
+			return nil, util.NewUserError(codes.PermissionDenied, fmt.Sprintf("Parameter '%v' references a secret outside of the namespace.", parameter.Name))
+		}
+
+		secret, err := c.CoreV1().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"Parameter": parameter.Name,
+				"SecretRef": *parameter.SecretRef,
+				"Error":     err.Error(),
+			}).Error("Unable to get secret of parameter.")
+			if k8serrors.IsNotFound(err) {
+				return nil, util.NewUserError(codes.NotFound, fmt.Sprintf("Secret '%v' of parameter '%v' not found.", ref.Name, parameter.Name))
+			}
+			return nil, util.NewUserError(codes.Internal, fmt.Sprintf("Unable to get secret '%v' of parameter '%v'.", ref.Name, parameter.Name))
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, util.NewUserError(codes.NotFound, fmt.Sprintf("Key '%v' of secret '%v' of parameter '%v' not found.", ref.Key, ref.Name, parameter.Name))
+		}
+		resolved[i].Value = ptr.String(string(value))
+	}
+
+	return resolved, nil
+}
+
+// redactedParameterValue is returned instead of the value of a parameter taken from a secret
+const redactedParameterValue = "********"
+
+// redactParameterSecrets returns a copy of parameters without the values of the parameters with a SecretRef,
+// so they are not saved or returned
+func redactParameterSecrets(parameters []Parameter) []Parameter {
+	if parameters == nil {
+		return nil
+	}
+
+	redacted := make([]Parameter, len(parameters))
+	for i, parameter := range parameters {
+		redacted[i] = parameter
+		if parameter.SecretRef != nil {
+			redacted[i].Value = nil
+		}
+	}
+
+	return redacted
+}
+
+// redactWorkflowSecrets returns a copy of the argo workflow where the values of the arguments taken from a secret,
+// according to parameters, are redactedParameterValue. So are all other strings equal to one of those values,
+// like the inputs of the steps they were passed to. A workflow without such arguments is returned as is.
+func redactWorkflowSecrets(wf *wfv1.Workflow, parameters []Parameter) (*wfv1.Workflow, error) {
+	secretParameters := make(map[string]bool)
+	for _, parameter := range parameters {
+		if parameter.SecretRef != nil {
+			secretParameters[parameter.Name] = true
+		}
+	}
+
+	var secrets [][]byte
+	for _, argument := range wf.Spec.Arguments.Parameters {
+		if !secretParameters[argument.Name] || argument.Value == nil || *argument.Value == "" || *argument.Value == redactedParameterValue {
+			continue
+		}
+
+		secret, err := json.Marshal(*argument.Value)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	if len(secrets) == 0 {
+		return wf, nil
+	}
+
+	manifest, err := json.Marshal(wf)
+	if err != nil {
+		return nil, err
+	}
+
+	redactedValue, err := json.Marshal(redactedParameterValue)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		manifest = bytes.ReplaceAll(manifest, secret, redactedValue)
+	}
+
+	redacted := &wfv1.Workflow{}
+	if err := json.Unmarshal(manifest, redacted); err != nil {
+		return nil, err
+	}
+
+	return redacted, nil
+}
+
+func (c *Client) injectAccessForSidecars(namespace string, wf *wfv1.Workflow) ([]wfv1.Template, error) {
+	var newTemplateOrder []wfv1.Template
+	taskSysSendStatusName := "sys-send-status"
+	taskSysSendExitStats := "sys-send-exit-stats"
+	for tIdx, t := range wf.Spec.Templates {
+		//Inject services, virtual routes
+		for si, s := range t.Sidecars {
+			//If TTY is true, sidecar needs to be accessible by HTTP
+			//Otherwise, we skip the sidecar
+			if s.TTY != true {
+				continue
+			}
+			if len(s.Ports) == 0 {
+				msg := fmt.Sprintf("sidecar %s must have at least one port.", s.Name)
+				return nil, util.NewUserError(codes.InvalidArgument, msg)
+			}
+
+			t.Sidecars[si].MirrorVolumeMounts = ptr.Bool(true)
+			serviceNameUID := "s" + uuid.New().String() + "--" + namespace
+			serviceNameUIDDNSCompliant, err := uid2.GenerateUID(serviceNameUID, 63)
+			if err != nil {
+				return nil, util.NewUserError(codes.InvalidArgument, err.Error())
+			}
+
+			serviceName := serviceNameUIDDNSCompliant + "." + *c.systemConfig.Domain()
+
+			serviceTemplateName := uuid.New().String()
+			serviceTemplateNameAdd := "sys-k8s-service-template-add-" + serviceTemplateName
+			serviceTemplateNameDelete := "sys-k8s-service-template-delete-" + serviceTemplateName
+			serviceTaskName := "service-" + uuid.New().String()
+			serviceAddTaskName := "sys-add-" + serviceTaskName
+			serviceDeleteTaskName := "sys-delete-" + serviceTaskName
+			virtualServiceTemplateName := uuid.New().String()
+			virtualServiceTemplateNameAdd := "sys-k8s-virtual-service-template-add-" + virtualServiceTemplateName
+			virtualServiceTemplateNameDelete := "sys-k8s-virtual-service-template-delete-" + virtualServiceTemplateName
+			virtualServiceTaskName := "virtual-service-" + uuid.New().String()
+			virtualServiceAddTaskName := "sys-add-" + virtualServiceTaskName
+			virtualServiceDeleteTaskName := "sys-delete-" + virtualServiceTaskName
+			var servicePorts []corev1.ServicePort
+			var routes []*networking.HTTPRoute
+			for _, port := range s.Ports {
+				servicePort := corev1.ServicePort{
+					Name:       port.Name,
+					Protocol:   port.Protocol,
+					Port:       port.ContainerPort,
+					TargetPort: intstr.FromInt(int(port.ContainerPort)),
+				}
+				servicePorts = append(servicePorts, servicePort)
+				route := networking.HTTPRoute{
+					Match: []*networking.HTTPMatchRequest{
+						{
+							Uri: &networking.StringMatch{
+								MatchType: &networking.StringMatch_Prefix{
+									Prefix: "/"},
+							},
+						},
+					},
+					Route: []*networking.HTTPRouteDestination{
+						{
+							Destination: &networking.Destination{
+								Host: serviceNameUIDDNSCompliant,
+								Port: &networking.PortSelector{
+									Number: uint32(port.ContainerPort),
+								},
+							},
+						},
+					},
+				}
+				routes = append(routes, &route)
+			}
+			service := corev1.Service{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Service",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: serviceNameUIDDNSCompliant,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: servicePorts,
+					Selector: map[string]string{
+						serviceTaskName: serviceNameUIDDNSCompliant,
+					},
+				},
+			}
+			//Istio needs to know which pod to setup the route to
+			if wf.Spec.Templates[tIdx].Metadata.Labels == nil {
+				wf.Spec.Templates[tIdx].Metadata.Labels = make(map[string]string)
+			}
+			wf.Spec.Templates[tIdx].Metadata.Labels[serviceTaskName] = serviceNameUIDDNSCompliant
+			serviceManifestBytes, err := yaml2.Marshal(service)
+			if err != nil {
+				return nil, err
 			}
 			serviceManifest := string(serviceManifestBytes)
 			templateServiceResource := wfv1.Template{
@@ -560,450 +1185,1181 @@ func (c *Client) injectAccessForSidecars(namespace string, wf *wfv1.Workflow) ([
 					},
 				},
 				Resource: &wfv1.ResourceTemplate{
-					Action:   "create",
+					Action:   "create",
+					Manifest: serviceManifest,
+				},
+			}
+			newTemplateOrder = append(newTemplateOrder, templateServiceResource)
+			//routes
+			virtualServiceNameUUID := "vs-" + uuid.New().String()
+			hosts := []string{serviceName}
+			wf.Spec.Templates[tIdx].Outputs.Parameters = append(wf.Spec.Templates[tIdx].Outputs.Parameters,
+				wfv1.Parameter{Name: "sys-sidecar-url--" + s.Name, Value: &serviceName},
+			)
+			virtualService := map[string]interface{}{
+				"apiVersion": "networking.istio.io/v1alpha3",
+				"kind":       "VirtualService",
+				"metadata": metav1.ObjectMeta{
+					Name: virtualServiceNameUUID,
+				},
+				"spec": networking.VirtualService{
+					Http:     routes,
+					Gateways: []string{"istio-system/ingressgateway"},
+					Hosts:    hosts,
+				},
+			}
+
+			virtualServiceManifestBytes, err := yaml2.Marshal(virtualService)
+			if err != nil {
+				return nil, err
+			}
+			virtualServiceManifest := string(virtualServiceManifestBytes)
+
+			templateRouteResource := wfv1.Template{
+				Name: virtualServiceTemplateNameAdd,
+				Metadata: wfv1.Metadata{
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+					},
+				},
+				Resource: &wfv1.ResourceTemplate{
+					Action:   "create",
+					Manifest: virtualServiceManifest,
+				},
+			}
+			newTemplateOrder = append(newTemplateOrder, templateRouteResource)
+
+			for i2, t2 := range wf.Spec.Templates {
+				if t2.Name == wf.Spec.Entrypoint {
+					if t2.DAG != nil {
+						tasks := wf.Spec.Templates[i2].DAG.Tasks
+						t := tasks[0]
+						sysDepFound := false
+						for _, d := range t.Dependencies {
+							if d == taskSysSendStatusName {
+								sysDepFound = true
+								wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies =
+									[]string{virtualServiceAddTaskName}
+							}
+						}
+						if sysDepFound == false {
+							wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies = append(wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies, virtualServiceAddTaskName)
+						}
+
+						wf.Spec.Templates[i2].DAG.Tasks = append(tasks, []wfv1.DAGTask{
+							{
+								Name:         serviceAddTaskName,
+								Template:     serviceTemplateNameAdd,
+								Dependencies: []string{taskSysSendStatusName},
+							},
+							{
+								Name:         virtualServiceAddTaskName,
+								Template:     virtualServiceTemplateNameAdd,
+								Dependencies: []string{serviceAddTaskName},
+							},
+						}...)
+					}
+				}
+			}
+			//Inject clean-up for service and virtualservice
+			templateServiceDeleteResource := wfv1.Template{
+				Name: serviceTemplateNameDelete,
+				Metadata: wfv1.Metadata{
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+					},
+				},
+				Resource: &wfv1.ResourceTemplate{
+					Action:   "delete",
 					Manifest: serviceManifest,
 				},
 			}
-			newTemplateOrder = append(newTemplateOrder, templateServiceResource)
-			//routes
-			virtualServiceNameUUID := "vs-" + uuid.New().String()
-			hosts := []string{serviceName}
-			wf.Spec.Templates[tIdx].Outputs.Parameters = append(wf.Spec.Templates[tIdx].Outputs.Parameters,
-				wfv1.Parameter{Name: "sys-sidecar-url--" + s.Name, Value: &serviceName},
-			)
-			virtualService := map[string]interface{}{
-				"apiVersion": "networking.istio.io/v1alpha3",
-				"kind":       "VirtualService",
-				"metadata": metav1.ObjectMeta{
-					Name: virtualServiceNameUUID,
+			newTemplateOrder = append(newTemplateOrder, templateServiceDeleteResource)
+
+			templateRouteDeleteResource := wfv1.Template{
+				Name: virtualServiceTemplateNameDelete,
+				Metadata: wfv1.Metadata{
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+					},
 				},
-				"spec": networking.VirtualService{
-					Http:     routes,
-					Gateways: []string{"istio-system/ingressgateway"},
-					Hosts:    hosts,
+				Resource: &wfv1.ResourceTemplate{
+					Action:   "delete",
+					Manifest: virtualServiceManifest,
 				},
 			}
 
-			virtualServiceManifestBytes, err := yaml2.Marshal(virtualService)
-			if err != nil {
-				return nil, err
-			}
-			virtualServiceManifest := string(virtualServiceManifestBytes)
+			newTemplateOrder = append(newTemplateOrder, templateRouteDeleteResource)
+
+			dagTasks := []wfv1.DAGTask{
+				{
+					Name:     serviceDeleteTaskName,
+					Template: serviceTemplateNameDelete,
+				},
+				{
+					Name:         virtualServiceDeleteTaskName,
+					Template:     virtualServiceTemplateNameDelete,
+					Dependencies: []string{serviceDeleteTaskName},
+				},
+			}
+			if wf.Spec.OnExit != "" {
+				for _, t := range wf.Spec.Templates {
+					if t.Name == wf.Spec.OnExit {
+						t.DAG.Tasks = append(t.DAG.Tasks, dagTasks...)
+						sysExitDepFound := false
+						for dti, dt := range t.DAG.Tasks {
+							if dt.Name == taskSysSendExitStats {
+								sysExitDepFound = true
+								t.DAG.Tasks[dti].Dependencies = append(t.DAG.Tasks[dti].Dependencies, virtualServiceDeleteTaskName)
+							}
+						}
+						if sysExitDepFound == false {
+							t.DAG.Tasks[0].Dependencies = append(t.DAG.Tasks[0].Dependencies, virtualServiceDeleteTaskName)
+						}
+						break
+					}
+				}
+			} else {
+				exitHandlerDAG := wfv1.Template{
+					Name: "exit-handler",
+					DAG: &wfv1.DAGTemplate{
+						Tasks: dagTasks,
+					},
+				}
+				wf.Spec.OnExit = "exit-handler"
+				wf.Spec.Templates = append(wf.Spec.Templates, exitHandlerDAG)
+			}
+		}
+		newTemplateOrder = append(newTemplateOrder, wf.Spec.Templates[tIdx])
+
+	}
+	return newTemplateOrder, nil
+}
+
+func (c *Client) ValidateWorkflowExecution(namespace string, manifest []byte) (err error) {
+	manifest, err = filterOutCustomTypesFromManifest(manifest)
+	if err != nil {
+		return
+	}
+
+	workflows, err := UnmarshalWorkflows(manifest, true)
+	if err != nil {
+		return
+	}
+
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(c.ArgoprojV1alpha1().WorkflowTemplates(namespace))
+	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(c.argoprojV1alpha1.ClusterWorkflowTemplates())
+	namespaceConfig, err := c.GetNamespaceConfig(namespace)
+	if err != nil {
+		return
+	}
+
+	for _, wf := range workflows {
+		if err = c.injectAutomatedFields(namespace, namespaceConfig, &wf, &WorkflowExecutionOptions{}); err != nil {
+			return err
+		}
+		_, err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, &wf, validate.ValidateOpts{})
+		if err != nil {
+			return
+		}
+
+		// Check that entrypoint and onExit templates are DAGs
+		for _, t := range wf.Spec.Templates {
+			if t.Name == wf.Spec.Entrypoint && t.DAG == nil {
+				return errors.New("\"entrypoint\" template should be a DAG")
+			}
+
+			if wf.Spec.OnExit != "" && t.Name == wf.Spec.OnExit && t.DAG == nil {
+				return errors.New("\"onExit\" template should be a DAG")
+			}
+		}
+	}
+
+	return
+}
+
+// newWorkflowExecutionOptions creates the options used to run workflow from workflowTemplate.
+// See CreateWorkflowExecution for how the name is picked.
+func newWorkflowExecutionOptions(workflow *WorkflowExecution, workflowTemplate *WorkflowTemplate) (*WorkflowExecutionOptions, error) {
+	opts := &WorkflowExecutionOptions{
+		Labels:                  make(map[string]string),
+		Parameters:              workflow.Parameters,
+		IdempotencyKey:          workflow.IdempotencyKey,
+		TTLSecondsAfterFinished: workflow.TTLSecondsAfterFinished,
+		ActiveDeadlineSeconds:   workflow.ActiveDeadlineSeconds,
+		Priority:                workflow.Priority,
+		NodeSelector:            workflow.NodeSelector,
+		Tolerations:             workflow.Tolerations,
+		FailFast:                workflow.FailFast,
+	}
+
+	if workflow.Name != "" {
+		opts.Name = workflow.Name
+	}
+
+	if workflowExecutionName := workflow.GetParameterValue("workflow-execution-name"); workflowExecutionName != nil {
+		opts.Name = *workflowExecutionName
+	}
+
+	nameUID, err := uid2.GenerateUID(workflowTemplate.Name, 63)
+	if err != nil {
+		return nil, err
+	}
+	opts.GenerateName = nameUID + "-"
+
+	if err := validateLabels(workflow.Labels); err != nil {
+		return nil, err
+	}
+	opts.Labels[workflowTemplateUIDLabelKey] = workflowTemplate.UID
+	opts.Labels[workflowTemplateVersionLabelKey] = fmt.Sprint(workflowTemplate.Version)
+	label.MergeLabelsPrefix(opts.Labels, workflow.Labels, label.TagPrefix)
+
+	return opts, nil
+}
+
+// PreviewWorkflowExecutions renders the argo workflow manifest that CreateWorkflowExecution would submit
+// for each of the parameter sets, without submitting anything.
+// The manifests are returned as yaml, in the same order as parameterSets.
+func (c *Client) PreviewWorkflowExecutions(namespace string, workflowTemplate *WorkflowTemplate, parameterSets [][]Parameter) (manifests []string, err error) {
+	for _, parameters := range parameterSets {
+		opts, err := newWorkflowExecutionOptions(&WorkflowExecution{Parameters: parameters}, workflowTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(workflows) != 1 {
+			return nil, fmt.Errorf("workflow Template contained more than 1 workflow execution")
+		}
+
+		if err := c.renderWorkflow(namespace, &workflowTemplate.ID, &workflows[0], opts); err != nil {
+			return nil, err
+		}
+
+		manifest, err := yaml2.Marshal(workflows[0])
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append(manifests, string(manifest))
+	}
+
+	return
+}
+
+// CreateWorkflowExecution creates an argo workflow execution and related resources.
+// If workflow.Name is set, it is used instead of a generated name.
+// If there is a parameter named "workflow-execution-name" in workflow.Parameters, it is set as the name.
+// If workflow.IdempotencyKey is set and an execution was created with the same key within idempotencyKeyTTL,
+// that execution is returned and nothing new is created. If the execution with the key is still being created,
+// a codes.Aborted error is returned, so the caller can try again.
+func (c *Client) CreateWorkflowExecution(namespace string, workflow *WorkflowExecution, workflowTemplate *WorkflowTemplate) (*WorkflowExecution, error) {
+	if workflow.IdempotencyKey != "" {
+		claimed, err := c.claimWorkflowExecutionIdempotencyKey(namespace, workflow.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			existing, err := c.getWorkflowExecutionByIdempotencyKey(namespace, workflow.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				return nil, util.NewUserError(codes.Aborted, "A workflow execution with the idempotency key is being created. Try again later.")
+			}
+
+			workflow.ID = existing.ID
+			workflow.Name = existing.Name
+			workflow.CreatedAt = existing.CreatedAt.UTC()
+			workflow.UID = existing.UID
+			workflow.WorkflowTemplate = workflowTemplate
+
+			return workflow, nil
+		}
+	}
+
+	createdWorkflow, err := c.createWorkflowExecution(namespace, workflow, workflowTemplate)
+	if err != nil && workflow.IdempotencyKey != "" {
+		c.releaseWorkflowExecutionIdempotencyKey(namespace, workflow.IdempotencyKey)
+	}
+
+	return createdWorkflow, err
+}
+
+// createWorkflowExecution creates the workflow execution, see CreateWorkflowExecution.
+// The idempotency key of the workflow, if any, must already be claimed.
+func (c *Client) createWorkflowExecution(namespace string, workflow *WorkflowExecution, workflowTemplate *WorkflowTemplate) (*WorkflowExecution, error) {
+
+	limiter := c.workflowExecutionLimiter
+	if limiter == nil {
+		limiter = defaultWorkflowExecutionLimiter
+	}
+	if !limiter.Allow(namespace) {
+		return nil, util.NewUserError(codes.ResourceExhausted, "Too many workflow executions are being created in the namespace. Try again later.")
+	}
+
+	opts, err := newWorkflowExecutionOptions(workflow, workflowTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workflows) != 1 {
+		return nil, fmt.Errorf("workflow Template contained more than 1 workflow execution")
+	}
+
+	createdWorkflow, err := c.createWorkflow(namespace, workflowTemplate.ID, workflowTemplate.WorkflowTemplateVersionID, &workflows[0], opts, workflow.Labels)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Workflow":  workflow,
+			"Error":     err.Error(),
+		}).Error("Error parsing workflow.")
+		return nil, err
+	}
+
+	workflow.ID = createdWorkflow.ID
+	workflow.Name = createdWorkflow.Name
+	workflow.CreatedAt = createdWorkflow.CreatedAt.UTC()
+	workflow.UID = createdWorkflow.UID
+	workflow.WorkflowTemplate = workflowTemplate
+
+	return workflow, nil
+}
+
+// CreateWorkflowExecutions creates each of the workflow executions from the workflow template, like CreateWorkflowExecution.
+// A workflow execution that fails to be created does not stop the others, its error is set in its result instead.
+// The results are in the same order as workflows.
+func (c *Client) CreateWorkflowExecutions(namespace string, workflows []*WorkflowExecution, workflowTemplate *WorkflowTemplate) []WorkflowExecutionResult {
+	results := make([]WorkflowExecutionResult, len(workflows))
+	for i, workflow := range workflows {
+		results[i].WorkflowExecution, results[i].Err = c.CreateWorkflowExecution(namespace, workflow, workflowTemplate)
+	}
+
+	return results
+}
+
+// CreateWorkflowExecutionFromManifest validates an argo workflow manifest and submits it as is, without a workflow template.
+// workflow.Parameters and workflow.Labels are applied to the manifest, and it is rendered, the same way as for CreateWorkflowExecution.
+// Since there is no workflow template, the execution is only created in argo and not tracked in the database.
+func (c *Client) CreateWorkflowExecutionFromManifest(namespace string, workflow *WorkflowExecution, manifest []byte) (*WorkflowExecution, error) {
+	if err := c.ValidateWorkflowExecution(namespace, manifest); err != nil {
+		return nil, util.NewUserError(codes.InvalidArgument, err.Error())
+	}
+
+	manifest, err := filterOutCustomTypesFromManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows, err := UnmarshalWorkflows(manifest, true)
+	if err != nil {
+		return nil, util.NewUserError(codes.InvalidArgument, err.Error())
+	}
+
+	if len(workflows) != 1 {
+		return nil, util.NewUserError(codes.InvalidArgument, "Manifest should contain exactly 1 workflow.")
+	}
+	wf := &workflows[0]
+
+	parameters, err := c.resolveParameterSecretRefs(namespace, workflow.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &WorkflowExecutionOptions{
+		Labels:                  make(map[string]string),
+		Parameters:              parameters,
+		TTLSecondsAfterFinished: workflow.TTLSecondsAfterFinished,
+		ActiveDeadlineSeconds:   workflow.ActiveDeadlineSeconds,
+		Priority:                workflow.Priority,
+		NodeSelector:            workflow.NodeSelector,
+		Tolerations:             workflow.Tolerations,
+		FailFast:                workflow.FailFast,
+	}
+	for key, value := range wf.ObjectMeta.Labels {
+		opts.Labels[key] = value
+	}
+	if err := validateLabels(workflow.Labels); err != nil {
+		return nil, err
+	}
+	label.MergeLabelsPrefix(opts.Labels, workflow.Labels, label.TagPrefix)
+
+	if workflow.Name != "" {
+		opts.Name = workflow.Name
+	}
+	if wf.ObjectMeta.Name == "" && wf.ObjectMeta.GenerateName == "" {
+		opts.GenerateName = "workflow-"
+	}
+
+	if err := c.renderWorkflow(namespace, nil, wf, opts); err != nil {
+		return nil, err
+	}
+
+	var createdArgoWorkflow *wfv1.Workflow
+	err = c.runKubeMutation("CreateWorkflow", func() (err error) {
+		createdArgoWorkflow, err = c.ArgoprojV1alpha1().Workflows(namespace).Create(wf)
+		return
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Workflow":  workflow,
+			"Error":     err.Error(),
+		}).Error("Error creating workflow from manifest.")
+		return nil, err
+	}
+
+	workflow.Name = createdArgoWorkflow.Name
+	workflow.CreatedAt = createdArgoWorkflow.CreationTimestamp.UTC()
+	workflow.ArgoWorkflow = createdArgoWorkflow
+	if err := workflow.GenerateUID(createdArgoWorkflow.Name); err != nil {
+		return nil, err
+	}
 
-			templateRouteResource := wfv1.Template{
-				Name: virtualServiceTemplateNameAdd,
-				Metadata: wfv1.Metadata{
-					Annotations: map[string]string{
-						"sidecar.istio.io/inject": "false",
-					},
-				},
-				Resource: &wfv1.ResourceTemplate{
-					Action:   "create",
-					Manifest: virtualServiceManifest,
-				},
-			}
-			newTemplateOrder = append(newTemplateOrder, templateRouteResource)
+	c.createAuditEvent(namespace, AuditActionCreateWorkflowExecution, workflow.UID, workflow.Parameters, "")
 
-			for i2, t2 := range wf.Spec.Templates {
-				if t2.Name == wf.Spec.Entrypoint {
-					if t2.DAG != nil {
-						tasks := wf.Spec.Templates[i2].DAG.Tasks
-						t := tasks[0]
-						sysDepFound := false
-						for _, d := range t.Dependencies {
-							if d == taskSysSendStatusName {
-								sysDepFound = true
-								wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies =
-									[]string{virtualServiceAddTaskName}
-							}
-						}
-						if sysDepFound == false {
-							wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies = append(wf.Spec.Templates[i2].DAG.Tasks[0].Dependencies, virtualServiceAddTaskName)
-						}
+	return workflow, nil
+}
 
-						wf.Spec.Templates[i2].DAG.Tasks = append(tasks, []wfv1.DAGTask{
-							{
-								Name:         serviceAddTaskName,
-								Template:     serviceTemplateNameAdd,
-								Dependencies: []string{taskSysSendStatusName},
-							},
-							{
-								Name:         virtualServiceAddTaskName,
-								Template:     virtualServiceTemplateNameAdd,
-								Dependencies: []string{serviceAddTaskName},
-							},
-						}...)
-					}
-				}
-			}
-			//Inject clean-up for service and virtualservice
-			templateServiceDeleteResource := wfv1.Template{
-				Name: serviceTemplateNameDelete,
-				Metadata: wfv1.Metadata{
-					Annotations: map[string]string{
-						"sidecar.istio.io/inject": "false",
-					},
-				},
-				Resource: &wfv1.ResourceTemplate{
-					Action:   "delete",
-					Manifest: serviceManifest,
-				},
-			}
-			newTemplateOrder = append(newTemplateOrder, templateServiceDeleteResource)
+func (c *Client) CloneWorkflowExecution(namespace, uid string) (*WorkflowExecution, error) {
+	// TODO do you need the and template here?
+	workflowExecution, err := c.getWorkflowExecutionAndTemplate(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
 
-			templateRouteDeleteResource := wfv1.Template{
-				Name: virtualServiceTemplateNameDelete,
-				Metadata: wfv1.Metadata{
-					Annotations: map[string]string{
-						"sidecar.istio.io/inject": "false",
-					},
-				},
-				Resource: &wfv1.ResourceTemplate{
-					Action:   "delete",
-					Manifest: virtualServiceManifest,
-				},
-			}
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, workflowExecution.WorkflowTemplate.UID, workflowExecution.WorkflowTemplate.Version)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Workflow":  workflowExecution,
+			"Error":     err.Error(),
+		}).Error("Error with getting workflow template.")
+		return nil, util.NewUserError(codes.NotFound, "Error with getting workflow template.")
+	}
 
-			newTemplateOrder = append(newTemplateOrder, templateRouteDeleteResource)
+	// We remove the name because CreateWorkflowExecution will otherwise use it to try and create an execution with that name
+	workflowExecution.Name = ""
+	return c.CreateWorkflowExecution(namespace, workflowExecution, workflowTemplate)
+}
 
-			dagTasks := []wfv1.DAGTask{
-				{
-					Name:     serviceDeleteTaskName,
-					Template: serviceTemplateNameDelete,
-				},
-				{
-					Name:         virtualServiceDeleteTaskName,
-					Template:     virtualServiceTemplateNameDelete,
-					Dependencies: []string{serviceDeleteTaskName},
-				},
-			}
-			if wf.Spec.OnExit != "" {
-				for _, t := range wf.Spec.Templates {
-					if t.Name == wf.Spec.OnExit {
-						t.DAG.Tasks = append(t.DAG.Tasks, dagTasks...)
-						sysExitDepFound := false
-						for dti, dt := range t.DAG.Tasks {
-							if dt.Name == taskSysSendExitStats {
-								sysExitDepFound = true
-								t.DAG.Tasks[dti].Dependencies = append(t.DAG.Tasks[dti].Dependencies, virtualServiceDeleteTaskName)
-							}
-						}
-						if sysExitDepFound == false {
-							t.DAG.Tasks[0].Dependencies = append(t.DAG.Tasks[0].Dependencies, virtualServiceDeleteTaskName)
-						}
-						break
-					}
-				}
-			} else {
-				exitHandlerDAG := wfv1.Template{
-					Name: "exit-handler",
-					DAG: &wfv1.DAGTemplate{
-						Tasks: dagTasks,
-					},
-				}
-				wf.Spec.OnExit = "exit-handler"
-				wf.Spec.Templates = append(wf.Spec.Templates, exitHandlerDAG)
-			}
+// createWorkflowExecutionDB inserts a workflow execution into the database.
+// Required fields
+// * name
+// * createdAt // we sync the argo created at with the db
+// * parameters, if any
+// * WorkflowTemplate.WorkflowTemplateVersionID
+//
+// After success, the passed in WorkflowExecution will have it's ID set to the new db record.
+func (c *Client) createWorkflowExecutionDB(namespace string, workflowExecution *WorkflowExecution) (err error) {
+	parametersJSON, err := json.Marshal(workflowExecution.Parameters)
+	if err != nil {
+		return err
+	}
+
+	var idempotencyKey *string
+	if workflowExecution.IdempotencyKey != "" {
+		idempotencyKey = &workflowExecution.IdempotencyKey
+	}
+
+	if err := workflowExecution.GenerateUID(workflowExecution.Name); err != nil {
+		return err
+	}
+
+	err = sb.Insert("workflow_executions").
+		SetMap(sq.Eq{
+			"UID":                          workflowExecution.UID,
+			"workflow_template_version_id": workflowExecution.WorkflowTemplate.WorkflowTemplateVersionID,
+			"name":                         workflowExecution.Name,
+			"namespace":                    namespace,
+			"created_at":                   workflowExecution.CreatedAt.UTC(),
+			"phase":                        wfv1.NodePending,
+			"parameters":                   string(parametersJSON),
+			"is_archived":                  false,
+			"labels":                       workflowExecution.Labels,
+			"metrics":                      workflowExecution.Metrics,
+			"idempotency_key":              idempotencyKey,
+			"created_by":                   c.Identity,
+		}).
+		Suffix("RETURNING id").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&workflowExecution.ID)
+	if err != nil {
+		return
+	}
+
+	workflowExecution.CreatedBy = c.Identity
+
+	return
+}
+
+// claimWorkflowExecutionIdempotencyKey records that a workflow execution is being created with the idempotency key.
+// The key is unique in the namespace, so only one caller claims it, until it expires after idempotencyKeyTTL.
+// false is returned if the key was already claimed.
+func (c *Client) claimWorkflowExecutionIdempotencyKey(namespace, idempotencyKey string) (claimed bool, err error) {
+	now := time.Now().UTC()
+
+	var createdAt time.Time
+	err = sb.Insert("workflow_execution_idempotency_keys").
+		SetMap(sq.Eq{
+			"namespace":       namespace,
+			"idempotency_key": idempotencyKey,
+			"created_at":      now,
+		}).
+		Suffix("ON CONFLICT (namespace, idempotency_key) DO UPDATE SET created_at = EXCLUDED.created_at WHERE workflow_execution_idempotency_keys.created_at <= ? RETURNING created_at", now.Add(-idempotencyKeyTTL)).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&createdAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// releaseWorkflowExecutionIdempotencyKey deletes the claim of the idempotency key, after the workflow execution
+// failed to be created, so it can be created again with the key. An error is only logged.
+func (c *Client) releaseWorkflowExecutionIdempotencyKey(namespace, idempotencyKey string) {
+	_, err := sb.Delete("workflow_execution_idempotency_keys").
+		Where(sq.Eq{
+			"namespace":       namespace,
+			"idempotency_key": idempotencyKey,
+		}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace":      namespace,
+			"IdempotencyKey": idempotencyKey,
+			"Error":          err.Error(),
+		}).Error("Unable to release workflow execution idempotency key.")
+	}
+}
+
+// getWorkflowExecutionByIdempotencyKey returns the latest workflow execution created with the idempotency key within idempotencyKeyTTL.
+// If there is none, nil is returned.
+func (c *Client) getWorkflowExecutionByIdempotencyKey(namespace, idempotencyKey string) (workflow *WorkflowExecution, err error) {
+	workflow = &WorkflowExecution{}
+	query := sb.Select("id", "uid", "name", "created_at").
+		From("workflow_executions").
+		Where(sq.Eq{
+			"namespace":       namespace,
+			"idempotency_key": idempotencyKey,
+		}).
+		Where(sq.Gt{
+			"created_at": time.Now().UTC().Add(-idempotencyKeyTTL),
+		}).
+		OrderBy("created_at DESC").
+		Limit(1)
+
+	if err := c.DB.Getx(workflow, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
 		}
-		newTemplateOrder = append(newTemplateOrder, wf.Spec.Templates[tIdx])
 
+		return nil, err
+	}
+
+	return
+}
+
+func (c *Client) FinishWorkflowExecutionStatisticViaExitHandler(namespace, name string, phase wfv1.NodePhase, startedAt time.Time) (err error) {
+	_, err = sb.Update("workflow_executions").
+		SetMap(sq.Eq{
+			"started_at":  startedAt.UTC(),
+			"name":        name,
+			"namespace":   namespace,
+			"finished_at": time.Now().UTC(),
+			"phase":       phase,
+		}).
+		Where(sq.And{
+			sq.Eq{"name": name},
+			sq.NotEq{"phase": "Terminated"},
+		}).
+		RunWith(c.DB).
+		Exec()
+
+	return err
+}
+
+func (c *Client) CronStartWorkflowExecutionStatisticInsert(namespace, uid string, workflowTemplateID int64) (err error) {
+	queryWt := c.workflowTemplatesSelectBuilder(namespace).
+		Where(sq.Eq{
+			"wt.id": workflowTemplateID,
+		})
+
+	workflowTemplate := &WorkflowTemplate{}
+	if err := c.DB.Getx(workflowTemplate, queryWt); err != nil {
+		return err
+	}
+
+	queryCw := c.cronWorkflowSelectBuilder(namespace, workflowTemplate.UID)
+
+	cronWorkflow := &CronWorkflow{}
+	if err := c.DB.Getx(cronWorkflow, queryCw); err != nil {
+		return err
 	}
-	return newTemplateOrder, nil
-}
 
-func (c *Client) ValidateWorkflowExecution(namespace string, manifest []byte) (err error) {
-	manifest, err = filterOutCustomTypesFromManifest(manifest)
+	parametersJSON, err := cronWorkflow.GetParametersFromWorkflowSpecJSON()
 	if err != nil {
-		return
+		return err
 	}
 
-	workflows, err := UnmarshalWorkflows(manifest, true)
+	workflowExecutionID := uint64(0)
+	err = sb.Insert("workflow_executions").
+		SetMap(sq.Eq{
+			"uid":                          uid,
+			"workflow_template_version_id": cronWorkflow.WorkflowTemplateVersionID,
+			"name":                         uid,
+			"namespace":                    namespace,
+			"phase":                        wfv1.NodeRunning,
+			"started_at":                   time.Now().UTC(),
+			"cron_workflow_id":             cronWorkflow.ID,
+			"parameters":                   string(parametersJSON),
+			"labels":                       cronWorkflow.Labels,
+			"metrics":                      Metrics{},
+		}).
+		Suffix("RETURNING id").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&workflowExecutionID)
 	if err != nil {
-		return
+		return err
 	}
 
-	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(c.ArgoprojV1alpha1().WorkflowTemplates(namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(c.argoprojV1alpha1.ClusterWorkflowTemplates())
-	for _, wf := range workflows {
-		if err = c.injectAutomatedFields(namespace, &wf, &WorkflowExecutionOptions{}); err != nil {
-			return err
+	return err
+}
+
+func (c *Client) GetWorkflowExecution(namespace, uid string) (workflow *WorkflowExecution, err error) {
+	return c.getWorkflowExecution(namespace, uid, nil)
+}
+
+// getWorkflowExecution is GetWorkflowExecution with the argo workflow already loaded, if wf is not nil.
+// Otherwise the argo workflow is loaded.
+func (c *Client) getWorkflowExecution(namespace, uid string, wf *wfv1.Workflow) (workflow *WorkflowExecution, err error) {
+	workflow = &WorkflowExecution{}
+	query := sb.Select(getWorkflowExecutionColumns("we")...).
+		Columns(getWorkflowTemplateColumns("wt", "workflow_template")...).
+		Columns(`wtv.manifest "workflow_template.manifest"`).
+		Columns("we.final_manifest").
+		From("workflow_executions we").
+		Join("workflow_template_versions wtv ON wtv.id = we.workflow_template_version_id").
+		Join("workflow_templates wt ON wt.id = wtv.workflow_template_id").
+		Where(sq.Eq{
+			"wt.namespace":   namespace,
+			"we.name":        uid,
+			"we.is_archived": false,
+		})
+
+	if err := c.DB.Getx(workflow, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
 		}
-		_, err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, &wf, validate.ValidateOpts{})
-		if err != nil {
+
+		return nil, err
+	}
+
+	if wf == nil {
+		err = c.runKubeCall("GetWorkflow", func() (err error) {
+			wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
 			return
+		})
+	}
+	// The argo workflow is garbage collected some time after it finishes, fall back to its final status
+	if k8serrors.IsNotFound(err) && workflow.FinalManifest != nil {
+		wf = &wfv1.Workflow{}
+		if err = json.Unmarshal([]byte(*workflow.FinalManifest), wf); err == nil {
+			workflow.Archived = true
 		}
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Workflow not found.")
+		if _, ok := err.(*util.UserError); ok {
+			return nil, err
+		}
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
 
-		// Check that entrypoint and onExit templates are DAGs
-		for _, t := range wf.Spec.Templates {
-			if t.Name == wf.Spec.Entrypoint && t.DAG == nil {
-				return errors.New("\"entrypoint\" template should be a DAG")
-			}
+	if workflow.ParametersBytes != nil {
+		if _, err := workflow.LoadParametersFromBytes(); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Invalid parameters.")
+			return nil, util.NewUserError(codes.Internal, "Unable to get workflow.")
+		}
+	}
 
-			if wf.Spec.OnExit != "" && t.Name == wf.Spec.OnExit && t.DAG == nil {
-				return errors.New("\"onExit\" template should be a DAG")
-			}
+	// The values of secrets are not returned
+	wf, err = redactWorkflowSecrets(wf, workflow.Parameters)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to redact the secrets of the workflow.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow.")
+	}
+
+	uidLabel := wf.ObjectMeta.Labels[workflowTemplateUIDLabelKey]
+	// Without a version label, the latest version of the workflow template is the best guess
+	version := int64(0)
+	if versionLabel := strings.TrimSpace(wf.ObjectMeta.Labels[workflowTemplateVersionLabelKey]); versionLabel == "" {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+		}).Warn("Workflow has no version label, using the latest workflow template version.")
+	} else {
+		version, err = strconv.ParseInt(versionLabel, 10, 64)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Invalid version number.")
+			return nil, util.NewUserError(codes.InvalidArgument, "Invalid version number.")
 		}
 	}
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, uidLabel, version)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Cannot get Workflow Template.")
+		return nil, util.NewUserError(codes.NotFound, "Cannot get Workflow Template.")
+	}
 
-	return
-}
+	manifest, err := json.Marshal(wf)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Invalid status.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Invalid status.")
+	}
 
-// CreateWorkflowExecution creates an argo workflow execution and related resources.
-// If workflow.Name is set, it is used instead of a generated name.
-// If there is a parameter named "workflow-execution-name" in workflow.Parameters, it is set as the name.
-func (c *Client) CreateWorkflowExecution(namespace string, workflow *WorkflowExecution, workflowTemplate *WorkflowTemplate) (*WorkflowExecution, error) {
-	opts := &WorkflowExecutionOptions{
-		Labels:     make(map[string]string),
-		Parameters: workflow.Parameters,
+	workflow.Manifest = string(manifest)
+	workflow.WorkflowTemplate = workflowTemplate
+	workflow.ArgoWorkflow = wf
+	workflow.Resources = getWorkflowResources(wf)
+	workflow.Nodes = getWorkflowNodes(wf)
+	workflow.TerminationReason = wf.ObjectMeta.Annotations[workflowTerminationReasonAnnotationKey]
+
+	if !workflow.Archived && !wf.Status.Fulfilled() {
+		workflow.UnschedulablePods = c.getWorkflowExecutionUnschedulablePods(namespace, uid)
+		workflow.ProvisioningVolumes = c.getWorkflowExecutionProvisioningVolumes(namespace, wf)
 	}
 
-	if workflow.Name != "" {
-		opts.Name = workflow.Name
+	workflow.Warnings = c.getWorkflowExecutionWarnings(namespace, uid, workflowTemplate)
+
+	workflow.Attempts, err = c.getWorkflowExecutionAttempts(workflow.ID, wf)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to get the attempts of the workflow.")
+		err = nil
 	}
 
-	if workflowExecutionName := workflow.GetParameterValue("workflow-execution-name"); workflowExecutionName != nil {
-		opts.Name = *workflowExecutionName
+	workflow.EstimatedFinishAt, err = c.getWorkflowExecutionEstimatedFinishAt(workflow)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to estimate when the workflow finishes.")
+		err = nil
 	}
 
-	nameUID, err := uid2.GenerateUID(workflowTemplate.Name, 63)
+	workflow.EstimatedCost, err = c.getWorkflowExecutionEstimatedCost(namespace, workflow)
 	if err != nil {
-		return nil, err
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to estimate the cost of the workflow.")
+		err = nil
 	}
-	opts.GenerateName = nameUID + "-"
 
-	opts.Labels[workflowTemplateUIDLabelKey] = workflowTemplate.UID
-	opts.Labels[workflowTemplateVersionLabelKey] = fmt.Sprint(workflowTemplate.Version)
-	label.MergeLabelsPrefix(opts.Labels, workflow.Labels, label.TagPrefix)
+	return
+}
 
-	workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+// getWorkflowExecutionWarnings returns the warnings of a workflow execution that ran the workflow template version.
+// There is a warning if a newer version of the workflow template exists.
+// The warnings are informational, so if the latest version can not be loaded, none are returned.
+func (c *Client) getWorkflowExecutionWarnings(namespace, uid string, workflowTemplate *WorkflowTemplate) (warnings []string) {
+	latest, err := c.getLatestWorkflowTemplateVersionDB(namespace, workflowTemplate.Name)
 	if err != nil {
-		return nil, err
+		log.WithFields(log.Fields{
+			"Namespace":        namespace,
+			"UID":              uid,
+			"WorkflowTemplate": workflowTemplate.UID,
+			"Error":            err.Error(),
+		}).Error("Unable to get the latest workflow template version.")
+		return
 	}
 
-	if len(workflows) != 1 {
-		return nil, fmt.Errorf("workflow Template contained more than 1 workflow execution")
+	if latest.Version > workflowTemplate.Version {
+		warnings = append(warnings, fmt.Sprintf("The workflow ran version %v of the workflow template, a newer version %v exists.", workflowTemplate.Version, latest.Version))
 	}
 
-	createdWorkflow, err := c.createWorkflow(namespace, workflowTemplate.ID, workflowTemplate.WorkflowTemplateVersionID, &workflows[0], opts, workflow.Labels)
+	return
+}
+
+// getWorkflowExecutionUnschedulablePods returns the pending pods of the workflow that can not be scheduled,
+// with the reason from their latest FailedScheduling event, or from their PodScheduled condition if there is no event.
+// The pods are informational, so if they can not be loaded, none are returned.
+func (c *Client) getWorkflowExecutionUnschedulablePods(namespace, uid string) (unschedulablePods []*UnschedulablePod) {
+	pods, err := c.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v=%v", common.LabelKeyWorkflow, uid),
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
-			"Workflow":  workflow,
+			"UID":       uid,
 			"Error":     err.Error(),
-		}).Error("Error parsing workflow.")
-		return nil, err
+		}).Error("Unable to list workflow pods.")
+		return nil
 	}
 
-	workflow.ID = createdWorkflow.ID
-	workflow.Name = createdWorkflow.Name
-	workflow.CreatedAt = createdWorkflow.CreatedAt.UTC()
-	workflow.UID = createdWorkflow.UID
-	workflow.WorkflowTemplate = workflowTemplate
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
 
-	return workflow, nil
+		var unschedulablePod *UnschedulablePod
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				unschedulablePod = &UnschedulablePod{
+					PodName: pod.Name,
+					Reason:  condition.Reason,
+					Message: condition.Message,
+				}
+			}
+		}
+		if unschedulablePod == nil {
+			continue
+		}
+
+		event, err := c.getLatestPodEvent(namespace, pod.Name, "FailedScheduling")
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"PodName":   pod.Name,
+				"Error":     err.Error(),
+			}).Error("Unable to list pod events.")
+		}
+		if event != nil {
+			unschedulablePod.Reason = event.Reason
+			unschedulablePod.Message = event.Message
+		}
+
+		unschedulablePods = append(unschedulablePods, unschedulablePod)
+	}
+
+	return
 }
 
-func (c *Client) CloneWorkflowExecution(namespace, uid string) (*WorkflowExecution, error) {
-	// TODO do you need the and template here?
-	workflowExecution, err := c.getWorkflowExecutionAndTemplate(namespace, uid)
-	if err != nil {
-		return nil, err
+// getWorkflowExecutionProvisioningVolumes returns the persistent volume claims of the workflow that are not bound to a volume,
+// with the reason and message of their latest event, like ProvisioningFailed.
+// The claims are the ones recorded in the workflow status, or named after the volume claim templates if argo has not recorded them yet.
+// The volumes are informational, so if they can not be loaded, none are returned.
+func (c *Client) getWorkflowExecutionProvisioningVolumes(namespace string, wf *wfv1.Workflow) (provisioningVolumes []*ProvisioningVolume) {
+	claimNames := make([]string, 0)
+	for _, volume := range wf.Status.PersistentVolumeClaims {
+		if volume.PersistentVolumeClaim != nil {
+			claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	if len(claimNames) == 0 {
+		for _, claimTemplate := range wf.Spec.VolumeClaimTemplates {
+			claimNames = append(claimNames, wf.Name+"-"+claimTemplate.Name)
+		}
 	}
 
-	workflowTemplate, err := c.GetWorkflowTemplate(namespace, workflowExecution.WorkflowTemplate.UID, workflowExecution.WorkflowTemplate.Version)
+	for _, claimName := range claimNames {
+		pvc, err := c.CoreV1().PersistentVolumeClaims(namespace).Get(claimName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       wf.Name,
+				"ClaimName": claimName,
+				"Error":     err.Error(),
+			}).Error("Unable to get workflow persistent volume claim.")
+			return nil
+		}
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+
+		provisioningVolume := &ProvisioningVolume{
+			ClaimName: claimName,
+			Phase:     pvc.Status.Phase,
+		}
+
+		event, err := c.getLatestEvent(namespace, "PersistentVolumeClaim", claimName, "")
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       wf.Name,
+				"ClaimName": claimName,
+				"Error":     err.Error(),
+			}).Error("Unable to list persistent volume claim events.")
+		}
+		if event != nil {
+			provisioningVolume.Reason = event.Reason
+			provisioningVolume.Message = event.Message
+		}
+
+		provisioningVolumes = append(provisioningVolumes, provisioningVolume)
+	}
+
+	return
+}
+
+// GetWorkflowExecutionChildWorkflows returns the workflows in the namespace that are owned by wf, or labelled with its name, oldest first.
+// Owner references can not be selected on, so every workflow of the namespace is listed. That is why, unlike the rest
+// of the workflow execution, the children are not loaded by GetWorkflowExecution, only when asked for.
+// The children are informational, so if they can not be loaded, none are returned.
+func (c *Client) GetWorkflowExecutionChildWorkflows(namespace string, wf *wfv1.Workflow) (childWorkflows []*ChildWorkflow) {
+	var workflows *wfv1.WorkflowList
+	err := c.runKubeCall("ListWorkflows", func() (err error) {
+		workflows, err = c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+		return
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
-			"Workflow":  workflowExecution,
+			"UID":       wf.Name,
 			"Error":     err.Error(),
-		}).Error("Error with getting workflow template.")
-		return nil, util.NewUserError(codes.NotFound, "Error with getting workflow template.")
+		}).Error("Unable to list child workflows.")
+		return nil
 	}
 
-	// We remove the name because CreateWorkflowExecution will otherwise use it to try and create an execution with that name
-	workflowExecution.Name = ""
-	return c.CreateWorkflowExecution(namespace, workflowExecution, workflowTemplate)
-}
+	for i := range workflows.Items {
+		child := &workflows.Items[i]
+		if child.Name == wf.Name {
+			continue
+		}
 
-// createWorkflowExecutionDB inserts a workflow execution into the database.
-// Required fields
-// * name
-// * createdAt // we sync the argo created at with the db
-// * parameters, if any
-// * WorkflowTemplate.WorkflowTemplateVersionID
-//
-// After success, the passed in WorkflowExecution will have it's ID set to the new db record.
-func (c *Client) createWorkflowExecutionDB(namespace string, workflowExecution *WorkflowExecution) (err error) {
-	parametersJSON, err := json.Marshal(workflowExecution.Parameters)
+		isChild := child.Labels[parentWorkflowLabelKey] == wf.Name
+		for _, ownerReference := range child.OwnerReferences {
+			if wf.UID != "" && ownerReference.UID == wf.UID {
+				isChild = true
+			}
+		}
+		if !isChild {
+			continue
+		}
+
+		childWorkflows = append(childWorkflows, &ChildWorkflow{
+			UID:       child.Name,
+			Phase:     child.Status.Phase,
+			CreatedAt: child.CreationTimestamp.UTC(),
+		})
+	}
+
+	sort.SliceStable(childWorkflows, func(i, j int) bool {
+		if childWorkflows[i].CreatedAt.Equal(childWorkflows[j].CreatedAt) {
+			return childWorkflows[i].UID < childWorkflows[j].UID
+		}
+		return childWorkflows[i].CreatedAt.Before(childWorkflows[j].CreatedAt)
+	})
+
+	return
+}
+
+// getLatestPodEvent returns the latest event of the pod with the reason. If there is none, nil is returned.
+func (c *Client) getLatestPodEvent(namespace, podName, reason string) (latest *corev1.Event, err error) {
+	return c.getLatestEvent(namespace, "Pod", podName, reason)
+}
+
+// getLatestEvent returns the latest event of the object of kind with the reason, or with any reason if reason is empty.
+// If there is none, nil is returned.
+func (c *Client) getLatestEvent(namespace, kind, name, reason string) (latest *corev1.Event, err error) {
+	selector := fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	}
+	if reason != "" {
+		selector["reason"] = reason
+	}
+	events, err := c.CoreV1().Events(namespace).List(metav1.ListOptions{
+		FieldSelector: selector.AsSelector().String(),
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := workflowExecution.GenerateUID(workflowExecution.Name); err != nil {
-		return err
+	for i := range events.Items {
+		event := &events.Items[i]
+		// Not every client filters on the field selector
+		if event.InvolvedObject.Kind != kind || event.InvolvedObject.Name != name || (reason != "" && event.Reason != reason) {
+			continue
+		}
+		if latest == nil || event.LastTimestamp.After(latest.LastTimestamp.Time) {
+			latest = event
+		}
 	}
 
-	err = sb.Insert("workflow_executions").
-		SetMap(sq.Eq{
-			"UID":                          workflowExecution.UID,
-			"workflow_template_version_id": workflowExecution.WorkflowTemplate.WorkflowTemplateVersionID,
-			"name":                         workflowExecution.Name,
-			"namespace":                    namespace,
-			"created_at":                   workflowExecution.CreatedAt.UTC(),
-			"phase":                        wfv1.NodePending,
-			"parameters":                   string(parametersJSON),
-			"is_archived":                  false,
-			"labels":                       workflowExecution.Labels,
-			"metrics":                      workflowExecution.Metrics,
-		}).
-		Suffix("RETURNING id").
-		RunWith(c.DB).
-		QueryRow().
-		Scan(&workflowExecution.ID)
-
 	return
 }
 
-func (c *Client) FinishWorkflowExecutionStatisticViaExitHandler(namespace, name string, phase wfv1.NodePhase, startedAt time.Time) (err error) {
-	_, err = sb.Update("workflow_executions").
-		SetMap(sq.Eq{
-			"started_at":  startedAt.UTC(),
-			"name":        name,
-			"namespace":   namespace,
-			"finished_at": time.Now().UTC(),
-			"phase":       phase,
-		}).
-		Where(sq.And{
-			sq.Eq{"name": name},
-			sq.NotEq{"phase": "Terminated"},
-		}).
-		RunWith(c.DB).
-		Exec()
+// workflowExecutionEstimateMinimumHistory is how many successful workflow executions of a workflow template version
+// are needed to estimate when other workflow executions of the version finish
+const workflowExecutionEstimateMinimumHistory = 3
 
-	return err
-}
+// getWorkflowExecutionEstimatedFinishAt estimates when the running workflow execution finishes, from the average duration
+// of the successful workflow executions of the same workflow template version.
+// nil is returned if the workflow execution is not running or there are not enough successful workflow executions.
+func (c *Client) getWorkflowExecutionEstimatedFinishAt(workflow *WorkflowExecution) (estimatedFinishAt *time.Time, err error) {
+	if workflow.StartedAt == nil || workflow.FinishedAt != nil || workflow.WorkflowTemplate == nil {
+		return nil, nil
+	}
 
-func (c *Client) CronStartWorkflowExecutionStatisticInsert(namespace, uid string, workflowTemplateID int64) (err error) {
-	queryWt := c.workflowTemplatesSelectBuilder(namespace).
+	history := &struct {
+		Count          int
+		AverageSeconds float64 `db:"average_seconds"`
+	}{}
+	query := sb.Select("COUNT(*) count", "COALESCE(AVG(EXTRACT(EPOCH FROM finished_at - started_at)), 0) average_seconds").
+		From("workflow_executions").
 		Where(sq.Eq{
-			"wt.id": workflowTemplateID,
+			"workflow_template_version_id": workflow.WorkflowTemplate.WorkflowTemplateVersionID,
+			"phase":                        wfv1.NodeSucceeded,
+		}).
+		Where(sq.NotEq{
+			"started_at":  nil,
+			"finished_at": nil,
 		})
+	if err = c.DB.Getx(history, query); err != nil {
+		return nil, err
+	}
 
-	workflowTemplate := &WorkflowTemplate{}
-	if err := c.DB.Getx(workflowTemplate, queryWt); err != nil {
-		return err
+	if history.Count < workflowExecutionEstimateMinimumHistory {
+		return nil, nil
 	}
 
-	queryCw := c.cronWorkflowSelectBuilder(namespace, workflowTemplate.UID)
+	finishAt := workflow.StartedAt.Add(time.Duration(history.AverageSeconds * float64(time.Second)))
 
-	cronWorkflow := &CronWorkflow{}
-	if err := c.DB.Getx(cronWorkflow, queryCw); err != nil {
-		return err
+	return &finishAt, nil
+}
+
+// getWorkflowExecutionEstimatedCost returns the price of the resources requested by the workflow execution,
+// from when it started until it finished, or until now if it is still running. See GetNamespacePricing.
+func (c *Client) getWorkflowExecutionEstimatedCost(namespace string, workflow *WorkflowExecution) (estimatedCost *float64, err error) {
+	if workflow.StartedAt == nil {
+		return nil, nil
 	}
 
-	parametersJSON, err := cronWorkflow.GetParametersFromWorkflowSpecJSON()
-	if err != nil {
-		return err
+	pricing, err := c.GetNamespacePricing(namespace)
+	if err != nil || pricing == nil {
+		return nil, err
 	}
 
-	workflowExecutionID := uint64(0)
-	err = sb.Insert("workflow_executions").
-		SetMap(sq.Eq{
-			"uid":                          uid,
-			"workflow_template_version_id": cronWorkflow.WorkflowTemplateVersionID,
-			"name":                         uid,
-			"namespace":                    namespace,
-			"phase":                        wfv1.NodeRunning,
-			"started_at":                   time.Now().UTC(),
-			"cron_workflow_id":             cronWorkflow.ID,
-			"parameters":                   string(parametersJSON),
-			"labels":                       cronWorkflow.Labels,
-			"metrics":                      Metrics{},
-		}).
-		Suffix("RETURNING id").
-		RunWith(c.DB).
-		QueryRow().
-		Scan(&workflowExecutionID)
-	if err != nil {
-		return err
+	finishedAt := time.Now()
+	if workflow.FinishedAt != nil {
+		finishedAt = *workflow.FinishedAt
+	}
+	duration := finishedAt.Sub(*workflow.StartedAt)
+	if duration < 0 {
+		duration = 0
 	}
 
-	return err
+	cost := workflow.Resources.cost(pricing, duration)
+
+	return &cost, nil
 }
 
-func (c *Client) GetWorkflowExecution(namespace, uid string) (workflow *WorkflowExecution, err error) {
-	workflow = &WorkflowExecution{}
-	query := sb.Select(getWorkflowExecutionColumns("we")...).
-		Columns(getWorkflowTemplateColumns("wt", "workflow_template")...).
-		Columns(`wtv.manifest "workflow_template.manifest"`).
-		From("workflow_executions we").
-		Join("workflow_template_versions wtv ON wtv.id = we.workflow_template_version_id").
-		Join("workflow_templates wt ON wt.id = wtv.workflow_template_id").
-		Where(sq.Eq{
-			"wt.namespace":   namespace,
-			"we.name":        uid,
-			"we.is_archived": false,
-		})
+// saveWorkflowExecutionFinalManifest persists the manifest of the argo workflow once it finished in phase,
+// so the workflow execution can still be loaded after the argo workflow is garbage collected.
+// The exit handler reports the phase while it still runs, before argo marks the workflow as finished,
+// so the saved manifest gets the phase and finish time if argo did not set them yet.
+// The values of secrets are not saved, see typeRedactedWorkflow.
+func (c *Client) saveWorkflowExecutionFinalManifest(namespace, uid string, phase wfv1.NodePhase) error {
+	var wf *wfv1.Workflow
+	err := c.runKubeCall("GetWorkflow", func() (err error) {
+		wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+		return
+	})
+	if err != nil {
+		return err
+	}
 
-	if err := c.DB.Getx(workflow, query); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+	if !wf.Status.Fulfilled() {
+		wf.Status.Phase = phase
+		if wf.Status.FinishedAt.IsZero() {
+			wf.Status.FinishedAt = metav1.Now()
 		}
-
-		return nil, err
 	}
 
-	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+	workflow, err := c.typeRedactedWorkflow(namespace, uid, wf)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"Namespace": namespace,
-			"UID":       uid,
-			"Error":     err.Error(),
-		}).Error("Workflow not found.")
-		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+		return err
 	}
 
-	uidLabel := wf.ObjectMeta.Labels[workflowTemplateUIDLabelKey]
-	version, err := strconv.ParseInt(
-		wf.ObjectMeta.Labels[workflowTemplateVersionLabelKey],
-		10,
-		64,
-	)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"Namespace": namespace,
-			"UID":       uid,
-			"Error":     err.Error(),
-		}).Error("Invalid version number.")
-		return nil, util.NewUserError(codes.InvalidArgument, "Invalid version number.")
-	}
-	workflowTemplate, err := c.GetWorkflowTemplate(namespace, uidLabel, version)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"Namespace": namespace,
-			"UID":       uid,
-			"Error":     err.Error(),
-		}).Error("Cannot get Workflow Template.")
-		return nil, util.NewUserError(codes.NotFound, "Cannot get Workflow Template.")
-	}
+	_, err = sb.Update("workflow_executions").
+		Set("final_manifest", workflow.Manifest).
+		Where(sq.Eq{
+			"namespace": namespace,
+			"name":      uid,
+		}).
+		RunWith(c.DB).
+		Exec()
 
-	manifest, err := json.Marshal(wf)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"Namespace": namespace,
-			"UID":       uid,
-			"Error":     err.Error(),
-		}).Error("Invalid status.")
-		return nil, util.NewUserError(codes.InvalidArgument, "Invalid status.")
+	return err
+}
+
+// GetWorkflowExecutionIfModified returns the workflow execution only if the resource version of the argo workflow
+// is different from resourceVersion. If it is the same, modified is false and the workflow execution is not loaded,
+// so clients can cheaply poll for changes. The argo workflow loaded to compare the resource version is reused
+// to load the workflow execution.
+func (c *Client) GetWorkflowExecutionIfModified(namespace, uid, resourceVersion string) (workflow *WorkflowExecution, modified bool, err error) {
+	var wf *wfv1.Workflow
+	if resourceVersion != "" {
+		err := c.runKubeCall("GetWorkflow", func() (err error) {
+			wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+			return
+		})
+		if err != nil {
+			// Loading the workflow execution handles the error, like falling back to the final manifest
+			wf = nil
+		} else if wf.ResourceVersion == resourceVersion {
+			return nil, false, nil
+		}
 	}
 
-	workflow.Manifest = string(manifest)
-	workflow.WorkflowTemplate = workflowTemplate
+	workflow, err = c.getWorkflowExecution(namespace, uid, wf)
 
-	return
+	return workflow, true, err
 }
 
 // ListWorkflowExecutions gets a list of WorkflowExecutions ordered by most recently created first.
+// workflowTemplateVersion only applies if workflowTemplateUID is set. An empty version, or 0, lists all versions.
 func (c *Client) ListWorkflowExecutions(namespace, workflowTemplateUID, workflowTemplateVersion string, includeSystem bool, request *request.Request) (workflows []*WorkflowExecution, err error) {
 	sb := workflowExecutionsSelectBuilder(namespace, workflowTemplateUID, workflowTemplateVersion, includeSystem)
 
@@ -1052,8 +2408,152 @@ func (c *Client) CountWorkflowExecutions(namespace, workflowTemplateUID, workflo
 	return
 }
 
+// maxWatchReconnects is how many times in a row a closed workflow watch is reopened without receiving any events
+const maxWatchReconnects = 5
+
+// forwardWorkflowEvents sends the workflows received by watcher to workflowWatcher until the watcher's channel closes.
+// resourceVersion is updated to the resource version of the last workflow received.
+// The values of the arguments taken from a secret, according to parameters, are redacted, see redactWorkflowSecrets.
+// Workflows whose marshaled status is the same as lastStatus, the status of the last workflow sent, are skipped,
+// so watchers only get the workflow when its status changes. lastStatus is updated to the status of each workflow sent.
+// done is true if the workflow finished, an error occurred, or ctx is done, meaning there is nothing left to watch.
+func forwardWorkflowEvents(ctx context.Context, namespace, uid string, parameters []Parameter, watcher watch.Interface, workflowWatcher chan<- *WorkflowExecution, resourceVersion *string, lastStatus *[]byte) (done bool) {
+	for {
+		var next watch.Event
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return true
+		case next, ok = <-watcher.ResultChan():
+			if !ok {
+				return false
+			}
+		}
+
+		workflow, ok := next.Object.(*wfv1.Workflow)
+		if !ok {
+			return true
+		}
+		if workflow == nil {
+			continue
+		}
+		*resourceVersion = workflow.ResourceVersion
+
+		workflow, err := redactWorkflowSecrets(workflow, parameters)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Unable to redact the secrets of the workflow.")
+			return true
+		}
+
+		status, err := json.Marshal(workflow.Status)
+		if err == nil && bytes.Equal(status, *lastStatus) {
+			continue
+		}
+
+		manifest, err := json.Marshal(workflow)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Workflow":  workflow,
+				"Error":     err.Error(),
+			}).Error("Error with trying to JSON Marshal workflow.Status.")
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return true
+		case workflowWatcher <- newWatchedWorkflowExecution(workflow, manifest):
+		}
+		*lastStatus = status
+
+		if !workflow.Status.FinishedAt.IsZero() {
+			return true
+		}
+	}
+}
+
+// newWatchedWorkflowExecution creates the WorkflowExecution sent to watchers for the argo workflow and its JSON manifest
+func newWatchedWorkflowExecution(workflow *wfv1.Workflow, manifest []byte) *WorkflowExecution {
+	return &WorkflowExecution{
+		CreatedAt:  workflow.CreationTimestamp.UTC(),
+		StartedAt:  ptr.Time(workflow.Status.StartedAt.UTC()),
+		FinishedAt: ptr.Time(workflow.Status.FinishedAt.UTC()),
+		UID:        workflow.Name,
+		Name:       workflow.Name,
+		Manifest:   string(manifest),
+		Phase:      workflow.Status.Phase,
+	}
+}
+
+// WatchWorkflowExecution streams the state of the workflow execution until it finishes.
+// The current state is always the first message, followed by each change of the workflow.
+// Clients sharing a watch group share a single watch of the workflow, in which case a watcher that falls behind
+// skips to the latest state instead of receiving every change.
 func (c *Client) WatchWorkflowExecution(namespace, uid string) (<-chan *WorkflowExecution, error) {
-	_, err := c.GetWorkflowExecution(namespace, uid)
+	if c.watchGroup == nil {
+		return c.watchWorkflowExecution(c.Context(), namespace, uid)
+	}
+
+	return c.watchGroup.workflows.subscribe(c.Context(), namespace, uid, c.watchWorkflowExecution)
+}
+
+// WatchWorkflowExecutionPhase streams the phase of the workflow execution until it finishes, like WatchWorkflowExecution,
+// but only when the phase changes, to minimize bandwidth for clients that only need to know about phase changes.
+// The workflow executions sent only have their UID, Phase, CreatedAt, StartedAt and FinishedAt set.
+func (c *Client) WatchWorkflowExecutionPhase(namespace, uid string) (<-chan *WorkflowExecution, error) {
+	watcher, err := c.WatchWorkflowExecution(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	phaseWatcher := make(chan *WorkflowExecution)
+	err = c.watchGroup.Go(c.Context(), func(ctx context.Context) {
+		defer close(phaseWatcher)
+
+		sent := false
+		var lastPhase wfv1.NodePhase
+		for workflow := range watcher {
+			if sent && workflow.Phase == lastPhase {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case phaseWatcher <- newWorkflowExecutionPhase(workflow):
+			}
+			sent = true
+			lastPhase = workflow.Phase
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return phaseWatcher, nil
+}
+
+// newWorkflowExecutionPhase returns the lightweight version of a watched workflow execution, see WatchWorkflowExecutionPhase
+func newWorkflowExecutionPhase(workflow *WorkflowExecution) *WorkflowExecution {
+	return &WorkflowExecution{
+		UID:        workflow.UID,
+		Phase:      workflow.Phase,
+		CreatedAt:  workflow.CreatedAt,
+		StartedAt:  workflow.StartedAt,
+		FinishedAt: workflow.FinishedAt,
+	}
+}
+
+// watchWorkflowExecution opens a watch of the workflow execution, streaming its state until it finishes or ctx is done
+func (c *Client) watchWorkflowExecution(ctx context.Context, namespace, uid string) (<-chan *WorkflowExecution, error) {
+	we, err := c.GetWorkflowExecution(namespace, uid)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
@@ -1062,107 +2562,132 @@ func (c *Client) WatchWorkflowExecution(namespace, uid string) (<-chan *Workflow
 		}).Errorf("Workflow execution not found for namespace: %v, uid: %v).", namespace, uid)
 		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
 	}
+	if we == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
 
+	snapshot := newWatchedWorkflowExecution(we.ArgoWorkflow, []byte(we.Manifest))
+	// There is nothing to watch once the workflow finished, or was garbage collected
+	finished := we.Archived || !we.ArgoWorkflow.Status.FinishedAt.IsZero()
+
+	// Changes are watched from the version of the snapshot, so none are missed or sent twice
+	resourceVersion := we.ArgoWorkflow.ResourceVersion
+	// Changes are only sent when the status differs from the last one sent, starting with the snapshot's
+	lastStatus, _ := json.Marshal(we.ArgoWorkflow.Status)
 	fieldSelector, _ := fields.ParseSelector(fmt.Sprintf("metadata.name=%s", uid))
-	watcher, err := c.ArgoprojV1alpha1().Workflows(namespace).Watch(metav1.ListOptions{
-		FieldSelector: fieldSelector.String(),
-	})
-	if err != nil {
-		log.WithFields(log.Fields{
-			"Namespace": namespace,
-			"UID":       uid,
-			"Error":     err.Error(),
-		}).Error("Watch Workflow error.")
-		return nil, util.NewUserError(codes.Unknown, "Error with watching workflow.")
+
+	var watcher watch.Interface
+	if !finished {
+		watcher, err = c.ArgoprojV1alpha1().Workflows(namespace).Watch(metav1.ListOptions{
+			FieldSelector:   fieldSelector.String(),
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Watch Workflow error.")
+			return nil, util.NewUserError(codes.Unknown, "Error with watching workflow.")
+		}
 	}
 
 	workflowWatcher := make(chan *WorkflowExecution)
-	go func() {
-		var next watch.Event
-		done := false
-
-		for !done {
-			for next = range watcher.ResultChan() {
-				workflow, ok := next.Object.(*wfv1.Workflow)
-				if !ok {
-					done = true
-					break
-				}
-				if workflow == nil {
-					continue
-				}
+	if err := c.watchGroup.Go(ctx, func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			finished = true
+		case workflowWatcher <- snapshot:
+		}
 
-				manifest, err := json.Marshal(workflow)
-				if err != nil {
-					log.WithFields(log.Fields{
-						"Namespace": namespace,
-						"UID":       uid,
-						"Workflow":  workflow,
-						"Error":     err.Error(),
-					}).Error("Error with trying to JSON Marshal workflow.Status.")
-					done = true
-					break
-				}
+		if finished {
+			if watcher != nil {
+				watcher.Stop()
+			}
+			close(workflowWatcher)
+			return
+		}
 
-				workflowWatcher <- &WorkflowExecution{
-					CreatedAt:  workflow.CreationTimestamp.UTC(),
-					StartedAt:  ptr.Time(workflow.Status.StartedAt.UTC()),
-					FinishedAt: ptr.Time(workflow.Status.FinishedAt.UTC()),
-					UID:        workflow.Name,
-					Name:       workflow.Name,
-					Manifest:   string(manifest),
-				}
+		reconnects := 0
 
-				if !workflow.Status.FinishedAt.IsZero() {
-					done = true
-					break
-				}
+		// We want to continue to watch the workflow until it is done, or an error occurred.
+		// If the watch closes before then, reopen it from the last resource version we received.
+		for {
+			lastResourceVersion := resourceVersion
+			if forwardWorkflowEvents(ctx, namespace, uid, we.Parameters, watcher, workflowWatcher, &resourceVersion, &lastStatus) {
+				break
 			}
 
-			// We want to continue to watch the workflow until it is done, or an error occurred
-			// If it is not done, create a new watch and continue watching.
-			if !done {
-				workflow, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
-				if err != nil {
-					log.WithFields(log.Fields{
-						"Namespace": namespace,
-						"UID":       uid,
-						"Workflow":  workflow,
-						"Error":     err.Error(),
-					}).Error("Unable to get workflow.")
-
-					done = true
-					break
-				}
+			if resourceVersion != lastResourceVersion {
+				reconnects = 0
+			}
+			if reconnects == maxWatchReconnects {
+				log.WithFields(log.Fields{
+					"Namespace":       namespace,
+					"UID":             uid,
+					"ResourceVersion": resourceVersion,
+				}).Error("Watch Workflow closed too many times without progress.")
+				break
+			}
+			reconnects++
 
-				if workflow.Status.Phase == wfv1.NodeRunning {
-					watcher, err = c.ArgoprojV1alpha1().Workflows(namespace).Watch(metav1.ListOptions{
-						FieldSelector: fieldSelector.String(),
-					})
-					if err != nil {
-						log.WithFields(log.Fields{
-							"Namespace": namespace,
-							"UID":       uid,
-							"Error":     err.Error(),
-						}).Error("Watch Workflow error.")
-						done = true
-						break
-					}
-				} else {
-					done = true
-					break
-				}
+			watcher.Stop()
+			watcher, err = c.ArgoprojV1alpha1().Workflows(namespace).Watch(metav1.ListOptions{
+				FieldSelector:   fieldSelector.String(),
+				ResourceVersion: resourceVersion,
+			})
+			if err != nil {
+				log.WithFields(log.Fields{
+					"Namespace": namespace,
+					"UID":       uid,
+					"Error":     err.Error(),
+				}).Error("Watch Workflow error.")
+				close(workflowWatcher)
+				return
 			}
 		}
 
 		watcher.Stop()
 		close(workflowWatcher)
-	}()
+	}); err != nil {
+		if watcher != nil {
+			watcher.Stop()
+		}
+		return nil, err
+	}
 
 	return workflowWatcher, nil
 }
 
-func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName string) (<-chan *LogEntry, error) {
+// verifyPodContainer returns a NotFound error, listing the available containers, if the pod has no container named containerName.
+// initContainer is true if containerName is one of the init containers of the pod.
+// Pods that no longer exist are not checked, as the logs of finished pods are read from the artifact repository.
+func (c *Client) verifyPodContainer(namespace, podName, containerName string) (initContainer bool, err error) {
+	pod, err := c.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return false, nil
+	}
+
+	var containerNames []string
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == containerName {
+			return true, nil
+		}
+		containerNames = append(containerNames, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return false, nil
+		}
+		containerNames = append(containerNames, container.Name)
+	}
+
+	return false, util.NewUserError(codes.NotFound, fmt.Sprintf("Container '%v' not found in pod '%v'. Available containers: %v.", containerName, podName, strings.Join(containerNames, ", ")))
+}
+
+// GetWorkflowExecutionLogs streams the logs of the container of the pod of the workflow execution.
+// If since is set, only the logs from since on are streamed, see ParseLogSinceDuration.
+func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName string, since time.Time) (<-chan *LogEntry, error) {
 	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -1175,6 +2700,11 @@ func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName
 		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
 	}
 
+	initContainer, err := c.verifyPodContainer(namespace, podName, containerName)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		stream    io.ReadCloser
 		s3Client  *s3.Client
@@ -1183,7 +2713,8 @@ func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName
 		endOffset int
 	)
 
-	if wf.Status.Nodes[podName].Completed() {
+	// Only the logs of the main container are archived, init container logs are read from the pod while it exists
+	if wf.Status.Nodes[podName].Completed() && !initContainer {
 		config, err = c.GetNamespaceConfig(namespace)
 		if err != nil {
 			log.WithFields(log.Fields{
@@ -1249,11 +2780,15 @@ func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName
 			}
 		}
 	} else {
-		stream, err = c.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		podLogOptions := &corev1.PodLogOptions{
 			Container:  containerName,
 			Follow:     true,
 			Timestamps: true,
-		}).Stream()
+		}
+		if !since.IsZero() {
+			podLogOptions.SinceTime = &metav1.Time{Time: since}
+		}
+		stream, err = c.CoreV1().Pods(namespace).GetLogs(podName, podLogOptions).Stream()
 	}
 	// TODO: Catch exact kubernetes error
 	//Todo: Can above todo be removed with the logging error?
@@ -1268,49 +2803,55 @@ func (c *Client) GetWorkflowExecutionLogs(namespace, uid, podName, containerName
 		return nil, util.NewUserError(codes.NotFound, "Log not found.")
 	}
 
-	logWatcher := make(chan *LogEntry)
-	go func() {
-		buffer := make([]byte, 4096)
-		reader := bufio.NewReader(stream)
+	// Archived logs can not be requested from a time on, so their lines are skipped until then
+	if !since.IsZero() {
+		stream = newSinceLogReader(stream, since)
+	}
 
-		newLine := true
-		for {
-			bytesRead, err := reader.Read(buffer)
-			if err != nil && err.Error() != "EOF" {
-				break
-			}
-			content := string(buffer[:bytesRead])
-
-			if newLine {
-				parts := strings.Split(content, " ")
-				if len(parts) == 0 {
-					logWatcher <- &LogEntry{Content: content}
-				} else {
-					timestamp, err := time.Parse(time.RFC3339, parts[0])
-					if err != nil {
-						logWatcher <- &LogEntry{Content: content}
-					} else {
-						logWatcher <- &LogEntry{
-							Timestamp: timestamp,
-							Content:   strings.Join(parts[1:], " "),
-						}
-					}
-				}
-			} else {
-				logWatcher <- &LogEntry{Content: content}
-			}
+	return streamLogEntries(c.Context(), stream, c.getLogStreamOptions()), nil
+}
 
-			if err != nil && err.Error() == "EOF" {
-				break
-			}
+// GetWorkflowExecutionOutputs returns the global output parameters of a finished workflow execution, in the order argo reports them.
+// A FailedPrecondition error is returned if the workflow execution has not finished yet.
+func (c *Client) GetWorkflowExecutionOutputs(namespace, uid string) (outputs []*WorkflowExecutionOutput, err error) {
+	var wf *wfv1.Workflow
+	err = c.runKubeCall("GetWorkflow", func() (err error) {
+		wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+		return
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Workflow not found.")
+		if _, ok := err.(*util.UserError); ok {
+			return nil, err
+		}
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
+
+	if !wf.Status.Fulfilled() {
+		return nil, util.NewUserError(codes.FailedPrecondition, "Workflow has not finished.")
+	}
+
+	outputs = make([]*WorkflowExecutionOutput, 0)
+	if wf.Status.Outputs == nil {
+		return
+	}
 
-			newLine = strings.Contains(content, "\n")
+	for _, parameter := range wf.Status.Outputs.Parameters {
+		output := &WorkflowExecutionOutput{
+			Name: parameter.Name,
+		}
+		if parameter.Value != nil {
+			output.Value = *parameter.Value
 		}
 
-		close(logWatcher)
-	}()
+		outputs = append(outputs, output)
+	}
 
-	return logWatcher, err
+	return
 }
 
 func (c *Client) GetWorkflowExecutionMetrics(namespace, uid, podName string) (metrics []*Metric, err error) {
@@ -1319,14 +2860,7 @@ func (c *Client) GetWorkflowExecutionMetrics(namespace, uid, podName string) (me
 		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
 	}
 
-	var (
-		stream    io.ReadCloser
-		s3Client  *s3.Client
-		gcsClient *gcs.Client
-		config    *NamespaceConfig
-	)
-
-	config, err = c.GetNamespaceConfig(namespace)
+	config, err := c.GetNamespaceConfig(namespace)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
@@ -1337,6 +2871,82 @@ func (c *Client) GetWorkflowExecutionMetrics(namespace, uid, podName string) (me
 		return nil, util.NewUserError(codes.NotFound, "Can't get configuration.")
 	}
 
+	return c.readWorkflowExecutionPodMetrics(namespace, uid, podName, config)
+}
+
+// getWorkflowSteps returns the steps of the workflow that ran in a pod, ordered by node name.
+// Nodes without a pod, like steps, DAGs, suspended and skipped nodes, are left out.
+func getWorkflowSteps(wf *wfv1.Workflow) (steps []*WorkflowStepMetrics) {
+	steps = make([]*WorkflowStepMetrics, 0)
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || node.Phase == wfv1.NodeSkipped || node.Phase == wfv1.NodeOmitted {
+			continue
+		}
+
+		// Argo names the pod of a node after the node's ID
+		steps = append(steps, &WorkflowStepMetrics{
+			NodeName:    node.Name,
+			DisplayName: node.DisplayName,
+			PodName:     node.ID,
+			Metrics:     make([]*Metric, 0),
+		})
+	}
+
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].NodeName < steps[j].NodeName
+	})
+
+	return
+}
+
+// GetWorkflowExecutionStepMetrics returns the metrics of each step of the workflow execution that ran in a pod.
+// Steps that have not reported metrics yet have no metrics.
+func (c *Client) GetWorkflowExecutionStepMetrics(namespace, uid string) (steps []*WorkflowStepMetrics, err error) {
+	we, err := c.GetWorkflowExecution(namespace, uid)
+	if err != nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
+	if we == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
+
+	steps = getWorkflowSteps(we.ArgoWorkflow)
+	if len(steps) == 0 {
+		return
+	}
+
+	config, err := c.GetNamespaceConfig(namespace)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Can't get configuration.")
+		return nil, util.NewUserError(codes.NotFound, "Can't get configuration.")
+	}
+
+	for _, step := range steps {
+		metrics, err := c.readWorkflowExecutionPodMetrics(namespace, uid, step.PodName, config)
+		if err != nil {
+			if userErr, ok := err.(*util.UserError); ok && userErr.Code == codes.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		step.Metrics = metrics
+	}
+
+	return
+}
+
+// readWorkflowExecutionPodMetrics reads the metrics the pod saved to the artifact repository of the namespace
+func (c *Client) readWorkflowExecutionPodMetrics(namespace, uid, podName string, config *NamespaceConfig) (metrics []*Metric, err error) {
+	var (
+		stream    io.ReadCloser
+		s3Client  *s3.Client
+		gcsClient *gcs.Client
+	)
+
 	switch {
 	case config.ArtifactRepository.S3 != nil:
 		{
@@ -1418,42 +3028,218 @@ func (c *Client) GetWorkflowExecutionMetrics(namespace, uid, podName string) (me
 	return
 }
 
+// RetryWorkflowExecution retries the failed steps of the workflow execution.
+// The argo workflow keeps the arguments it was created with, so the values of secret parameters are the ones
+// resolved then, not the current values of the secrets. They are redacted in the returned workflow execution,
+// see redactWorkflowSecrets and TestClient_RetryWorkflowExecution_SecretRef.
 func (c *Client) RetryWorkflowExecution(namespace, uid string) (workflow *WorkflowExecution, err error) {
 	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
 	if err != nil {
 		return
 	}
 
+	attempt := newWorkflowExecutionAttempt(wf)
+
 	h := hydrator.New(sqldb.ExplosiveOffloadNodeStatusRepo)
 	wf, err = argoutil.RetryWorkflow(c, h, c.ArgoprojV1alpha1().Workflows(namespace), wf, true, "")
+	if err != nil {
+		return
+	}
+
+	retries, err := c.incrementWorkflowExecutionRetries(namespace, uid)
+	if err != nil {
+		return
+	}
+	if err = c.recordWorkflowExecutionAttempt(namespace, uid, attempt); err != nil {
+		return
+	}
 
-	workflow = typeWorkflow(wf)
+	workflow, err = c.typeRedactedWorkflow(namespace, uid, wf)
+	if err != nil {
+		return nil, err
+	}
+	workflow.Retries = retries
 
 	return
 }
 
+// ResubmitWorkflowExecution runs the workflow execution again, as a new argo workflow with the arguments of the original.
+// Like with RetryWorkflowExecution, the values of secret parameters are the ones resolved when the original was created,
+// and they are redacted in the returned workflow execution.
+// The new argo workflow has no row of its own in the database, so the retries count and attempts stay on the original,
+// and the Retries of the returned workflow execution is the count of the original.
 func (c *Client) ResubmitWorkflowExecution(namespace, uid string) (workflow *WorkflowExecution, err error) {
-	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+	original, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
 	if err != nil {
 		return
 	}
 
-	wf, err = argoutil.FormulateResubmitWorkflow(wf, false)
+	wf, err := argoutil.FormulateResubmitWorkflow(original, false)
 	if err != nil {
 		return
 	}
+	copyOnepanelLabels(original, wf)
 
 	wf, err = argoutil.SubmitWorkflow(c.ArgoprojV1alpha1().Workflows(namespace), c, namespace, wf, &wfv1.SubmitOpts{})
 	if err != nil {
 		return
 	}
 
-	workflow = typeWorkflow(wf)
+	retries, err := c.incrementWorkflowExecutionRetries(namespace, uid)
+	if err != nil {
+		return
+	}
+	if err = c.recordWorkflowExecutionAttempt(namespace, uid, newWorkflowExecutionAttempt(original)); err != nil {
+		return
+	}
+
+	// The event belongs to the new workflow execution, the one it was resubmitted from is a parameter
+	c.createAuditEvent(namespace, AuditActionResubmitWorkflowExecution, wf.Name, []Parameter{
+		{Name: "originalUid", Value: &uid},
+	}, "")
+
+	// The resubmitted workflow has the arguments of the original one
+	workflow, err = c.typeRedactedWorkflow(namespace, uid, wf)
+	if err != nil {
+		return nil, err
+	}
+	workflow.Retries = retries
+
+	return
+}
+
+// copyOnepanelLabels sets the onepanel labels of from, like the workflow template uid and version, on to.
+// Workflows are filtered by them, so a resubmitted workflow must have the same ones as the original.
+func copyOnepanelLabels(from, to *wfv1.Workflow) {
+	onepanelLabels := label.FilterByPrefix(label.OnepanelPrefix, from.Labels)
+	if len(onepanelLabels) == 0 {
+		return
+	}
+
+	if to.Labels == nil {
+		to.Labels = make(map[string]string)
+	}
+	for key, value := range onepanelLabels {
+		to.Labels[key] = value
+	}
+}
+
+// incrementWorkflowExecutionRetries adds one to the number of times the workflow execution was retried or resubmitted
+// and returns the new count. The count is kept on the row of the workflow execution identified by uid, which is the
+// original one when it is resubmitted. Workflows that are not in the database, such as ones created from a manifest, have no count.
+func (c *Client) incrementWorkflowExecutionRetries(namespace, uid string) (retries int32, err error) {
+	err = sb.Update("workflow_executions").
+		Set("retries", sq.Expr("retries + 1")).
+		Where(sq.Eq{
+			"uid":       uid,
+			"namespace": namespace,
+		}).
+		Suffix("RETURNING retries").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&retries)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return
+}
+
+// recordWorkflowExecutionAttempt keeps the attempt of the workflow execution that was retried or resubmitted,
+// so it is part of the attempts of the workflow execution. Workflows that are not in the database, such as ones created
+// from a manifest, are not recorded.
+func (c *Client) recordWorkflowExecutionAttempt(namespace, uid string, attempt *WorkflowExecutionAttempt) error {
+	var workflowExecutionID uint64
+	err := sb.Select("id").
+		From("workflow_executions").
+		Where(sq.Eq{
+			"uid":       uid,
+			"namespace": namespace,
+		}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&workflowExecutionID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = sb.Insert("workflow_execution_attempts").
+		SetMap(sq.Eq{
+			"workflow_execution_id": workflowExecutionID,
+			"phase":                 attempt.Phase,
+			"started_at":            attempt.StartedAt,
+			"finished_at":           attempt.FinishedAt,
+		}).
+		RunWith(c.DB).
+		Exec()
+
+	return err
+}
+
+// getWorkflowExecutionAttempts returns the attempts of the workflow execution with the id, oldest first:
+// the recorded attempts that were retried or resubmitted, followed by the current attempt of the argo workflow.
+// A workflow execution that was never retried or resubmitted has no attempts.
+func (c *Client) getWorkflowExecutionAttempts(workflowExecutionID uint64, wf *wfv1.Workflow) (attempts []*WorkflowExecutionAttempt, err error) {
+	query := sb.Select("phase", "started_at", "finished_at").
+		From("workflow_execution_attempts").
+		Where(sq.Eq{"workflow_execution_id": workflowExecutionID}).
+		OrderBy("id")
+	if err = c.DB.Selectx(&attempts, query); err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+
+	// A resubmitted workflow runs as a new argo workflow, leaving this one as it was when its attempt was recorded
+	current := newWorkflowExecutionAttempt(wf)
+	if !attempts[len(attempts)-1].equal(current) {
+		attempts = append(attempts, current)
+	}
+
+	return
+}
+
+// isWorkflowSuspended returns true if the argo workflow was suspended and not resumed yet
+func isWorkflowSuspended(wf *wfv1.Workflow) bool {
+	return wf.Spec.Suspend != nil && *wf.Spec.Suspend
+}
+
+// getArgoWorkflow gets the argo workflow of the workflow execution, returning a codes.NotFound error if it does not exist
+func (c *Client) getArgoWorkflow(namespace, uid string) (wf *wfv1.Workflow, err error) {
+	err = c.runKubeCall("GetWorkflow", func() (err error) {
+		wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+		return
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Workflow not found.")
+		if _, ok := err.(*util.UserError); ok {
+			return nil, err
+		}
+		return nil, util.NewUserError(codes.NotFound, "Workflow not found.")
+	}
 
 	return
 }
 
+// ResumeWorkflowExecution resumes a workflow execution that was suspended with SuspendWorkflowExecution.
+// A codes.FailedPrecondition error is returned if the workflow execution is not suspended.
 func (c *Client) ResumeWorkflowExecution(namespace, uid string) (workflow *WorkflowExecution, err error) {
+	wf, err := c.getArgoWorkflow(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !isWorkflowSuspended(wf) || wf.Status.Fulfilled() {
+		return nil, util.NewUserError(codes.FailedPrecondition, "Only suspended workflows can be resumed.")
+	}
+
 	// TODO Review hydrator
 	h := hydrator.New(sqldb.ExplosiveOffloadNodeStatusRepo)
 	err = argoutil.ResumeWorkflow(c.ArgoprojV1alpha1().Workflows(namespace), h, uid, "")
@@ -1461,21 +3247,40 @@ func (c *Client) ResumeWorkflowExecution(namespace, uid string) (workflow *Workf
 		return
 	}
 
-	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
-
-	workflow = typeWorkflow(wf)
+	wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
 
-	return
+	return c.typeRedactedWorkflow(namespace, uid, wf)
 }
 
+// SuspendWorkflowExecution pauses a running workflow execution so that no new steps are started, until it is resumed
+// with ResumeWorkflowExecution. Steps that are already running continue until they finish.
+// A codes.FailedPrecondition error is returned if the workflow execution is not running or is already suspended.
 func (c *Client) SuspendWorkflowExecution(namespace, uid string) (err error) {
+	wf, err := c.getArgoWorkflow(namespace, uid)
+	if err != nil {
+		return err
+	}
+	if wf.Status.Phase != wfv1.NodeRunning || isWorkflowSuspended(wf) {
+		return util.NewUserError(codes.FailedPrecondition, "Only running workflows can be suspended.")
+	}
+
 	err = argoutil.SuspendWorkflow(c.ArgoprojV1alpha1().Workflows(namespace), uid)
 
 	return
 }
 
 // TerminateWorkflowExecution marks a workflows execution as terminated in DB and terminates the argo resource.
-func (c *Client) TerminateWorkflowExecution(namespace, uid string) (err error) {
+// If reason is not empty, it is saved in an annotation of the workflow, see WorkflowExecution.TerminationReason.
+func (c *Client) TerminateWorkflowExecution(namespace, uid, reason string) (err error) {
+	if reason != "" {
+		if err := c.annotateWorkflowTerminationReason(namespace, uid, reason); err != nil {
+			return err
+		}
+	}
+
 	_, err = sb.Update("workflow_executions").
 		Set("phase", "Terminated").
 		Set("started_at", time.Time.UTC(time.Now())).
@@ -1492,10 +3297,81 @@ func (c *Client) TerminateWorkflowExecution(namespace, uid string) (err error) {
 
 	h := hydrator.New(sqldb.ExplosiveOffloadNodeStatusRepo)
 	err = argoutil.StopWorkflow(c.ArgoprojV1alpha1().Workflows(namespace), h, uid, "", "")
+	if err != nil {
+		return
+	}
+
+	c.createAuditEvent(namespace, AuditActionTerminateWorkflowExecution, uid, nil, reason)
 
 	return
 }
 
+// TerminateWorkflowExecutions terminates every workflow execution of the namespace in phase, like TerminateWorkflowExecution,
+// and returns how many were terminated. phase defaults to Running, and Pending also matches workflows argo has not picked up yet.
+// As this can stop a lot of work, confirmPhase must be the same phase, and only Running and Pending can be terminated,
+// anything else is a codes.InvalidArgument error.
+func (c *Client) TerminateWorkflowExecutions(namespace string, phase, confirmPhase wfv1.NodePhase, reason string) (count int, err error) {
+	if phase == "" {
+		phase = wfv1.NodeRunning
+	}
+	if phase != wfv1.NodeRunning && phase != wfv1.NodePending {
+		return 0, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Workflow executions in phase %v can not be terminated, only Running or Pending.", phase))
+	}
+	if confirmPhase != phase {
+		return 0, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("confirmPhase must be %v to terminate the workflow executions in that phase.", phase))
+	}
+
+	var workflows *wfv1.WorkflowList
+	err = c.runKubeCall("ListWorkflows", func() (err error) {
+		workflows, err = c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, wf := range workflows.Items {
+		workflowPhase := wf.Status.Phase
+		if workflowPhase == "" {
+			workflowPhase = wfv1.NodePending
+		}
+		if workflowPhase != phase {
+			continue
+		}
+
+		if err := c.TerminateWorkflowExecution(namespace, wf.Name, reason); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace":  namespace,
+				"UID":        wf.Name,
+				"Terminated": count,
+				"Error":      err.Error(),
+			}).Error("TerminateWorkflowExecutions failed.")
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// annotateWorkflowTerminationReason saves why the workflow is terminated in its workflowTerminationReasonAnnotationKey annotation
+func (c *Client) annotateWorkflowTerminationReason(namespace, uid, reason string) error {
+	wf, err := c.getArgoWorkflow(namespace, uid)
+	if err != nil {
+		return err
+	}
+
+	if wf.ObjectMeta.Annotations == nil {
+		wf.ObjectMeta.Annotations = make(map[string]string)
+	}
+	wf.ObjectMeta.Annotations[workflowTerminationReasonAnnotationKey] = reason
+
+	return c.runKubeMutation("UpdateWorkflow", func() (err error) {
+		_, err = c.ArgoprojV1alpha1().Workflows(namespace).Update(wf)
+		return
+	})
+}
+
 func (c *Client) GetArtifact(namespace, uid, key string) (data []byte, err error) {
 	config, err := c.GetNamespaceConfig(namespace)
 	if err != nil {
@@ -2076,7 +3952,8 @@ func workflowExecutionsSelectBuilderNoColumns(namespace, workflowTemplateUID, wo
 	if workflowTemplateUID != "" {
 		whereMap["wt.uid"] = workflowTemplateUID
 
-		if workflowTemplateVersion != "" {
+		// Version 0 means all versions of the workflow template
+		if workflowTemplateVersion != "" && workflowTemplateVersion != "0" {
 			whereMap["wtv.version"] = workflowTemplateVersion
 		}
 	}
@@ -2147,6 +4024,17 @@ func (c *Client) UpdateWorkflowExecutionStatus(namespace, uid string, status *Wo
 		return util.NewUserError(codes.NotFound, "Workflow execution not found.")
 	}
 
+	// Keep the final status around, GetWorkflowExecution falls back to it once the argo workflow is gone
+	if status.Phase == wfv1.NodeSucceeded || status.Phase == wfv1.NodeFailed || status.Phase == wfv1.NodeError {
+		if err := c.saveWorkflowExecutionFinalManifest(namespace, uid, status.Phase); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Unable to save final manifest.")
+		}
+	}
+
 	return
 }
 
@@ -1,8 +1,35 @@
 package v1
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	argoFake "github.com/argoproj/argo/pkg/client/clientset/versioned/fake"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/label"
+	"github.com/onepanelio/core/pkg/util/ptr"
+	"github.com/onepanelio/core/pkg/util/ratelimit"
+	"github.com/onepanelio/core/pkg/util/request"
+	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestClient_CreateWorkflowExecution tests creating a workflow execution
@@ -97,3 +124,3704 @@ func TestClient_ArchiveWorkflowExecution_Exist(t *testing.T) {
 	err = c.ArchiveWorkflowExecution(namespace, weName)
 	assert.Nil(t, err)
 }
+
+const rawWorkflowExecutionManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-world-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: hello
+        template: hello
+  - name: hello
+    container:
+      image: alpine:3.7
+      command: [echo, hello]
+`
+
+// TestClient_CreateWorkflowExecutionFromManifest tests creating a workflow execution from a manifest without a template
+func TestClient_CreateWorkflowExecutionFromManifest(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	we := &WorkflowExecution{
+		Name: "test",
+		Labels: map[string]string{
+			"stage": "dev",
+		},
+	}
+
+	we, err := c.CreateWorkflowExecutionFromManifest(namespace, we, []byte(rawWorkflowExecutionManifest))
+	assert.Nil(t, err)
+	assert.Equal(t, "test", we.Name)
+	assert.Equal(t, "test", we.UID)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get("test", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "main", wf.Spec.Entrypoint)
+	assert.Equal(t, "dev", wf.Labels[label.TagPrefix+"stage"])
+}
+
+// TestClient_CreateWorkflowExecutionFromManifest_DefaultResources makes sure the containers of a workflow execution
+// created from a manifest get the namespace default resources, like the ones created from a workflow template
+func TestClient_CreateWorkflowExecutionFromManifest_DefaultResources(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["defaultResources"] = `
+requests:
+  cpu: 500m
+limits:
+  memory: 2Gi
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	_, err := c.CreateWorkflowExecutionFromManifest(namespace, &WorkflowExecution{Name: "test"}, []byte(rawWorkflowExecutionManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get("test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, template := range wf.Spec.Templates {
+		if template.Name != "hello" {
+			continue
+		}
+		if assert.NotNil(t, template.Container) {
+			cpu := template.Container.Resources.Requests[corev1.ResourceCPU]
+			assert.Equal(t, 0, cpu.Cmp(resource.MustParse("500m")))
+			memory := template.Container.Resources.Limits[corev1.ResourceMemory]
+			assert.Equal(t, 0, memory.Cmp(resource.MustParse("2Gi")))
+		}
+		return
+	}
+	t.Error("hello template not found")
+}
+
+// TestClient_CreateWorkflowExecutionFromManifest_DefaultParameters makes sure the namespace default parameters are passed
+// to a workflow created from a manifest, as they are for one created from a workflow template
+func TestClient_CreateWorkflowExecutionFromManifest_DefaultParameters(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["defaultParameters"] = `
+- name: bucket
+  value: onepanel-bucket
+- name: region
+  value: us-west-2
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	_, err := c.CreateWorkflowExecutionFromManifest(namespace, &WorkflowExecution{
+		Name:       "test",
+		Parameters: []Parameter{{Name: "region", Value: ptr.String("eu-central-1")}},
+	}, []byte(rawWorkflowExecutionManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get("test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parameters := make(map[string]string)
+	for _, p := range wf.Spec.Arguments.Parameters {
+		parameters[p.Name] = *p.Value
+	}
+	assert.Equal(t, "onepanel-bucket", parameters["bucket"])
+	assert.Equal(t, "eu-central-1", parameters["region"])
+}
+
+// TestClient_CreateWorkflowExecutionFromManifest_Invalid makes sure an invalid manifest is rejected and not submitted
+func TestClient_CreateWorkflowExecutionFromManifest_Invalid(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	// the entrypoint template does not exist
+	manifest := strings.Replace(rawWorkflowExecutionManifest, "entrypoint: main", "entrypoint: missing", 1)
+
+	we, err := c.CreateWorkflowExecutionFromManifest(namespace, &WorkflowExecution{Name: "test"}, []byte(manifest))
+	assert.Nil(t, we)
+	assert.NotNil(t, err)
+
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+
+	wfs, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, wfs.Items, 0)
+}
+
+// TestClient_GetWorkflowExecutionIfModified makes sure a matching resource version short-circuits
+// and a different one returns the full workflow execution, loading the argo workflow only once
+func TestClient_GetWorkflowExecutionIfModified(t *testing.T) {
+	gets := 0
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependReactor("get", "workflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+	wt, _ = c.CreateWorkflowTemplate(namespace, wt)
+
+	we := &WorkflowExecution{
+		Name: "test",
+	}
+	we, _ = c.CreateWorkflowExecution(namespace, we, wt)
+
+	// the fake client does not set resource versions, so we set one
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	wf.ResourceVersion = "42"
+	_, err = c.ArgoprojV1alpha1().Workflows(namespace).Update(wf)
+	assert.Nil(t, err)
+
+	notModified, modified, err := c.GetWorkflowExecutionIfModified(namespace, we.UID, "42")
+	assert.Nil(t, err)
+	assert.False(t, modified)
+	assert.Nil(t, notModified)
+
+	gets = 0
+	getWe, modified, err := c.GetWorkflowExecutionIfModified(namespace, we.UID, "41")
+	assert.Nil(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, we.UID, getWe.UID)
+	assert.Equal(t, "42", getWe.GetResourceVersion())
+	assert.Equal(t, 1, gets)
+}
+
+// TestClient_ListWorkflowExecutions_WorkflowTemplateVersion makes sure filtering by workflow template version
+// only returns the executions of that version, and version 0 returns all of them
+func TestClient_ListWorkflowExecutions_WorkflowTemplateVersion(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+	wt, _ = c.CreateWorkflowTemplate(namespace, wt)
+	firstVersion := wt.Version
+	firstTemplate, _ := c.GetWorkflowTemplate(namespace, wt.UID, firstVersion)
+	_, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "first"}, firstTemplate)
+	assert.Nil(t, err)
+
+	wt, _ = c.CreateWorkflowTemplateVersion(namespace, wt)
+	secondVersion := wt.Version
+	secondTemplate, _ := c.GetWorkflowTemplate(namespace, wt.UID, secondVersion)
+	_, err = c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "second"}, secondTemplate)
+	assert.Nil(t, err)
+
+	paginator := pagination.NewRequest(0, 10)
+	req := &request.Request{Pagination: &paginator}
+
+	workflows, err := c.ListWorkflowExecutions(namespace, wt.UID, fmt.Sprint(firstVersion), false, req)
+	assert.Nil(t, err)
+	assert.Len(t, workflows, 1)
+	assert.Equal(t, "first", workflows[0].Name)
+
+	workflows, err = c.ListWorkflowExecutions(namespace, wt.UID, fmt.Sprint(secondVersion), false, req)
+	assert.Nil(t, err)
+	assert.Len(t, workflows, 1)
+	assert.Equal(t, "second", workflows[0].Name)
+
+	workflows, err = c.ListWorkflowExecutions(namespace, wt.UID, "0", false, req)
+	assert.Nil(t, err)
+	assert.Len(t, workflows, 2)
+}
+
+// TestClient_ListWorkflowExecutions_TemplateLabels makes sure listed workflow executions have the uid and version
+// of their workflow template, and their labels
+func TestClient_ListWorkflowExecutions_TemplateLabels(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name:   "test",
+		Labels: map[string]string{"dataset": "mnist"},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The argo workflow carries the same association
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, wt.UID, wf.Labels[workflowTemplateUIDLabelKey])
+	assert.Equal(t, fmt.Sprint(wt.Version), wf.Labels[workflowTemplateVersionLabelKey])
+
+	paginator := pagination.NewRequest(0, 10)
+	workflows, err := c.ListWorkflowExecutions(namespace, "", "", false, &request.Request{Pagination: &paginator})
+	assert.Nil(t, err)
+	if assert.Len(t, workflows, 1) {
+		assert.Equal(t, we.UID, workflows[0].UID)
+		assert.Equal(t, "mnist", workflows[0].Labels["dataset"])
+		if assert.NotNil(t, workflows[0].WorkflowTemplate) {
+			assert.Equal(t, wt.UID, workflows[0].WorkflowTemplate.UID)
+			assert.Equal(t, wt.Version, workflows[0].WorkflowTemplate.Version)
+		}
+	}
+}
+
+// TestClient_ListWorkflowExecutions_TemplateSummary makes sure each listed workflow execution has the name and version
+// of the workflow template version it ran, when workflow executions of different templates and versions are listed together
+func TestClient_ListWorkflowExecutions_TemplateSummary(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	expected := make(map[string]*WorkflowTemplate)
+	for _, name := range []string{"train", "evaluate"} {
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     name,
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name + "-first"}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected[we.UID] = wt
+
+		wt, err = c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+			UID:      wt.UID,
+			Name:     name,
+			Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		we, err = c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name + "-second"}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected[we.UID] = wt
+	}
+
+	paginator := pagination.NewRequest(0, 10)
+	workflows, err := c.ListWorkflowExecutions(namespace, "", "", false, &request.Request{Pagination: &paginator})
+	assert.Nil(t, err)
+	assert.Len(t, workflows, len(expected))
+	for _, workflow := range workflows {
+		wt, ok := expected[workflow.UID]
+		if !assert.True(t, ok, workflow.UID) || !assert.NotNil(t, workflow.WorkflowTemplate, workflow.UID) {
+			continue
+		}
+		assert.Equal(t, wt.UID, workflow.WorkflowTemplate.UID)
+		assert.Equal(t, wt.Name, workflow.WorkflowTemplate.Name)
+		assert.Equal(t, wt.Version, workflow.WorkflowTemplate.Version)
+	}
+}
+
+// TestClient_ListWorkflowExecutions_NameContains makes sure the name filter matches prefixes and substrings, ignoring case,
+// along with the workflow template filter
+func TestClient_ListWorkflowExecutions_NameContains(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"train-mnist", "train-cifar", "evaluate-mnist"} {
+		if _, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name}, wt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		nameContains string
+		expected     []string
+	}{
+		{"train", []string{"train-mnist", "train-cifar"}},
+		{"TRAIN-", []string{"train-mnist", "train-cifar"}},
+		{"mnist", []string{"train-mnist", "evaluate-mnist"}},
+		{"n-c", []string{"train-cifar"}},
+		{"%", []string{}},
+		{"_", []string{}},
+		{"", []string{"train-mnist", "train-cifar", "evaluate-mnist"}},
+	}
+
+	for _, test := range tests {
+		paginator := pagination.NewRequest(0, 10)
+		req := &request.Request{
+			Pagination: &paginator,
+			Filter:     WorkflowExecutionFilter{NameContains: test.nameContains},
+		}
+
+		workflows, err := c.ListWorkflowExecutions(namespace, wt.UID, "0", false, req)
+		assert.Nil(t, err)
+
+		names := make([]string, 0)
+		for _, workflow := range workflows {
+			names = append(names, workflow.Name)
+		}
+		assert.ElementsMatch(t, test.expected, names, test.nameContains)
+
+		count, err := c.CountWorkflowExecutions(namespace, wt.UID, "0", false, req)
+		assert.Nil(t, err)
+		assert.Equal(t, len(test.expected), count, test.nameContains)
+	}
+}
+
+// TestClient_ListWorkflowExecutions_CreatedBy makes sure the creator filter only lists the workflow executions
+// created by the identity, and that all are listed without it
+func TestClient_ListWorkflowExecutions_CreatedBy(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	c.Identity = "system:serviceaccount:onepanel:alice"
+	bob := *c
+	bob.Identity = "system:serviceaccount:onepanel:bob"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"alice-first", "alice-second"} {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, c.Identity, we.CreatedBy)
+	}
+	if _, err := bob.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "bob-first"}, wt); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		createdBy string
+		expected  []string
+	}{
+		{c.Identity, []string{"alice-first", "alice-second"}},
+		{bob.Identity, []string{"bob-first"}},
+		{"system:serviceaccount:onepanel:carol", []string{}},
+		{"", []string{"alice-first", "alice-second", "bob-first"}},
+	}
+
+	for _, test := range tests {
+		paginator := pagination.NewRequest(0, 10)
+		req := &request.Request{
+			Pagination: &paginator,
+			Filter:     WorkflowExecutionFilter{CreatedBy: test.createdBy},
+		}
+
+		workflows, err := c.ListWorkflowExecutions(namespace, "", "", false, req)
+		assert.Nil(t, err)
+
+		names := make([]string, 0)
+		for _, workflow := range workflows {
+			names = append(names, workflow.Name)
+			if test.createdBy != "" {
+				assert.Equal(t, test.createdBy, workflow.CreatedBy)
+			}
+		}
+		assert.ElementsMatch(t, test.expected, names, test.createdBy)
+
+		count, err := c.CountWorkflowExecutions(namespace, "", "", false, req)
+		assert.Nil(t, err)
+		assert.Equal(t, len(test.expected), count, test.createdBy)
+	}
+}
+
+// TestClient_CreateWorkflowExecution_NamespaceSubstitutions makes sure the namespace placeholders of the manifest are replaced
+// with their values from the namespace config, while the ones without a value and the parameters of the caller are left as is
+func TestClient_CreateWorkflowExecution_NamespaceSubstitutions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	manifest := strings.Replace(defaultWorkflowTemplate, "      workingDir: /mnt/src\n", `      workingDir: /mnt/src
+      env:
+      - name: HOST
+        value: "https://{{.Host}}/api"
+      - name: DOMAIN
+        value: "{{.Domain}}"
+      - name: FQDN
+        value: "{{.FQDN}}"
+`, 1)
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: manifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "command", Value: ptr.String("echo {{.Domain}}")},
+		},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := make(map[string]string)
+	for _, template := range wf.Spec.Templates {
+		if template.Name != "pytorch" {
+			continue
+		}
+		for _, envVar := range template.Container.Env {
+			env[envVar.Name] = envVar.Value
+		}
+	}
+	assert.Equal(t, "https://demo.onepanel.site/api", env["HOST"])
+	assert.Equal(t, "demo.onepanel.site", env["DOMAIN"])
+	assert.Equal(t, "{{.FQDN}}", env["FQDN"])
+
+	for _, parameter := range wf.Spec.Arguments.Parameters {
+		if parameter.Name == "command" {
+			assert.Equal(t, "echo {{.Domain}}", *parameter.Value)
+		}
+	}
+
+	substitutions, err := c.GetNamespaceManifestSubstitutions("unconfigured")
+	assert.Nil(t, err)
+	assert.Empty(t, substitutions)
+}
+
+// TestClient_CreateWorkflowExecutionFromManifest_NamespaceSubstitutions makes sure the namespace placeholders of a manifest
+// created without a workflow template are replaced too
+func TestClient_CreateWorkflowExecutionFromManifest_NamespaceSubstitutions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	manifest := strings.Replace(rawWorkflowExecutionManifest, "      command: [echo, hello]\n", `      command: [echo, hello]
+      env:
+      - name: DOMAIN
+        value: "{{.Domain}}"
+`, 1)
+
+	_, err := c.CreateWorkflowExecutionFromManifest(namespace, &WorkflowExecution{Name: "test"}, []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get("test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, template := range wf.Spec.Templates {
+		if template.Name != "hello" {
+			continue
+		}
+		if !assert.NotNil(t, template.Container) {
+			return
+		}
+		for _, envVar := range template.Container.Env {
+			if envVar.Name == "DOMAIN" {
+				assert.Equal(t, "demo.onepanel.site", envVar.Value)
+				return
+			}
+		}
+		t.Error("DOMAIN environment variable not found")
+		return
+	}
+	t.Error("hello template not found")
+}
+
+// newBlockedArgoTestClient returns a test client whose argo calls for verb block until unblock is closed
+func newBlockedArgoTestClient(verb string, unblock chan struct{}) *Client {
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependReactor(verb, "workflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		<-unblock
+		return false, nil, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+
+	return c
+}
+
+// TestClient_GetWorkflowExecution_Deadline makes sure the kubernetes call stops once the context deadline passes
+func TestClient_GetWorkflowExecution_Deadline(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	blocked := newBlockedArgoTestClient("get", unblock).WithContext(ctx)
+
+	getWe, err := blocked.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, getWe)
+
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.DeadlineExceeded, userErr.Code)
+}
+
+// TestClient_CreateWorkflowExecution_Canceled makes sure no workflow is created once the context is cancelled
+func TestClient_CreateWorkflowExecution_Canceled(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := c.WithContext(ctx)
+
+	we, err := canceled.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	assert.Nil(t, we)
+	assertUserErrorCode(t, err, codes.Canceled)
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, workflows.Items)
+
+	count := 0
+	err = database.Get(&count, "SELECT COUNT(*) FROM workflow_executions")
+	assert.Nil(t, err)
+	assert.Zero(t, count)
+}
+
+// TestClient_CreateWorkflowExecution_SlowCreate makes sure a create that outlives the context is waited for,
+// so the workflow execution is saved along with its argo workflow instead of leaving the workflow orphaned
+func TestClient_CreateWorkflowExecution_SlowCreate(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unblock := make(chan struct{})
+	time.AfterFunc(150*time.Millisecond, func() {
+		close(unblock)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	blocked := newBlockedArgoTestClient("create", unblock).WithContext(ctx)
+
+	we, err := blocked.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, ctx.Err())
+
+	_, err = blocked.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+
+	count := 0
+	err = database.Get(&count, "SELECT COUNT(*) FROM workflow_executions WHERE name = $1", we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// TestClient_GetWorkflowExecutionLogs_ContainerNotFound makes sure requesting logs for a container
+// that is not in the pod returns an error naming the available containers
+func TestClient_GetWorkflowExecutionLogs_ContainerNotFound(t *testing.T) {
+	namespace := "onepanel"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "main"}, {Name: "wait"}},
+		},
+	}
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, pod)
+
+	_, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: namespace,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := c.GetWorkflowExecutionLogs(namespace, "test", "test-pod", "bogus", time.Time{})
+	assert.Nil(t, logs)
+
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+	assert.Equal(t, "Container 'bogus' not found in pod 'test-pod'. Available containers: init, main, wait.", userErr.Message)
+}
+
+// TestClient_GetWorkflowExecutionLogs_InitContainer makes sure the logs of an init container are streamed from the pod,
+// even once the step completed, as only the main container logs are archived
+func TestClient_GetWorkflowExecutionLogs_InitContainer(t *testing.T) {
+	namespace := "onepanel"
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "main"}, {Name: "wait"}},
+		},
+	}
+
+	logContainers := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/onepanel/pods/test-pod":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pod)
+		case "/api/v1/namespaces/onepanel/pods/test-pod/log":
+			logContainers <- r.URL.Query().Get("container")
+			w.Write([]byte("2020-10-01T10:00:00Z fetching artifacts\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		Interface:        kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+		argoprojV1alpha1: argoFake.NewSimpleClientset().ArgoprojV1alpha1(),
+	}
+
+	_, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: namespace,
+		},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test-pod": {ID: "test-pod", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := c.GetWorkflowExecutionLogs(namespace, "test", "test-pod", "init", time.Time{})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var entries []*LogEntry
+	for le := range logs {
+		entries = append(entries, le)
+	}
+
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "fetching artifacts\n", entries[0].Content)
+		assert.Equal(t, time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC), entries[0].Timestamp.UTC())
+	}
+	assert.Equal(t, "init", <-logContainers)
+}
+
+// TestClient_GetWorkflowExecutionLogs_Since makes sure the pod logs are requested from since on,
+// and only the lines logged from then on are streamed
+func TestClient_GetWorkflowExecutionLogs_Since(t *testing.T) {
+	namespace := "onepanel"
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "main"}, {Name: "wait"}},
+		},
+	}
+
+	sinceTimes := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/onepanel/pods/test-pod":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pod)
+		case "/api/v1/namespaces/onepanel/pods/test-pod/log":
+			sinceTimes <- r.URL.Query().Get("sinceTime")
+			w.Write([]byte("2020-10-01T09:00:00Z epoch 1\n2020-10-01T10:00:00Z epoch 2\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		Interface:        kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+		argoprojV1alpha1: argoFake.NewSimpleClientset().ArgoprojV1alpha1(),
+	}
+
+	_, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: namespace,
+		},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test-pod": {ID: "test-pod", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2020, 10, 1, 9, 30, 0, 0, time.UTC)
+	logs, err := c.GetWorkflowExecutionLogs(namespace, "test", "test-pod", "main", since)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var content []string
+	for le := range logs {
+		if !le.Heartbeat {
+			content = append(content, le.Content)
+		}
+	}
+
+	assert.Equal(t, []string{"epoch 2\n"}, content)
+	assert.Equal(t, "2020-10-01T09:30:00Z", <-sinceTimes)
+}
+
+// TestClient_GetWorkflowExecutionOutputs makes sure the global outputs of a completed workflow are returned
+// and that a workflow that has not finished is rejected
+func TestClient_GetWorkflowExecutionOutputs(t *testing.T) {
+	c := DefaultTestClient()
+
+	namespace := "onepanel"
+	accuracy := "0.92"
+	model := "s3://bucket/model.h5"
+
+	_, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "completed",
+			Namespace: namespace,
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.NodeSucceeded,
+			Outputs: &wfv1.Outputs{
+				Parameters: []wfv1.Parameter{
+					{Name: "accuracy", Value: &accuracy},
+					{Name: "model", Value: &model},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := c.GetWorkflowExecutionOutputs(namespace, "completed")
+	assert.Nil(t, err)
+	assert.Equal(t, []*WorkflowExecutionOutput{
+		{Name: "accuracy", Value: accuracy},
+		{Name: "model", Value: model},
+	}, outputs)
+
+	_, err = c.ArgoprojV1alpha1().Workflows(namespace).Create(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "running",
+			Namespace: namespace,
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.NodeRunning,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err = c.GetWorkflowExecutionOutputs(namespace, "running")
+	assert.Nil(t, outputs)
+
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, userErr.Code)
+}
+
+// TestClient_WatchWorkflowExecution_Reconnect makes sure the watch is reopened from the last resource version
+// when it closes before the workflow finishes
+func TestClient_WatchWorkflowExecution_Reconnect(t *testing.T) {
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	var watchResourceVersions []string
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependWatchReactor("workflows", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersion := action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion
+		watchResourceVersions = append(watchResourceVersions, resourceVersion)
+
+		watcher := watch.NewFakeWithChanSize(1, false)
+		if len(watchResourceVersions) == 1 {
+			watcher.Add(&wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.NodeRunning},
+			})
+			// Simulate the watch dropping mid-stream
+			watcher.Stop()
+		} else {
+			watcher.Add(&wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "2"},
+				Status: wfv1.WorkflowStatus{
+					Phase:      wfv1.NodeSucceeded,
+					FinishedAt: metav1.Now(),
+				},
+			})
+		}
+
+		return true, watcher, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := c.WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []*WorkflowExecution
+	for workflow := range watcher {
+		received = append(received, workflow)
+	}
+
+	// The current state, followed by the workflow from each watch
+	assert.Len(t, received, 3)
+	assert.Equal(t, []string{"", "1"}, watchResourceVersions)
+}
+
+// TestClient_WatchWorkflowExecution_DistinctStatus makes sure workflows are only sent when their status changes
+func TestClient_WatchWorkflowExecution_DistinctStatus(t *testing.T) {
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	startedAt := metav1.NewTime(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC))
+	running := wfv1.WorkflowStatus{
+		Phase:     wfv1.NodeRunning,
+		StartedAt: startedAt,
+	}
+	runningNode := wfv1.WorkflowStatus{
+		Phase:     wfv1.NodeRunning,
+		StartedAt: startedAt,
+		Nodes: wfv1.Nodes{
+			"test": {ID: "test", Name: "test", Phase: wfv1.NodeRunning},
+		},
+	}
+	statuses := []wfv1.WorkflowStatus{running, running, runningNode, runningNode, runningNode, {
+		Phase:      wfv1.NodeSucceeded,
+		StartedAt:  startedAt,
+		FinishedAt: metav1.NewTime(startedAt.Add(time.Minute)),
+	}}
+
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependWatchReactor("workflows", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFakeWithChanSize(len(statuses), false)
+		for i, status := range statuses {
+			watcher.Modify(&wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: fmt.Sprint(i + 1)},
+				Status:     status,
+			})
+		}
+
+		return true, watcher, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := c.WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var phases []wfv1.NodePhase
+	var nodes []int
+	for workflow := range watcher {
+		wf := &wfv1.Workflow{}
+		if err := json.Unmarshal([]byte(workflow.Manifest), wf); err != nil {
+			t.Fatal(err)
+		}
+		phases = append(phases, wf.Status.Phase)
+		nodes = append(nodes, len(wf.Status.Nodes))
+	}
+
+	// The current state, followed by each distinct status
+	assert.Equal(t, []wfv1.NodePhase{"", wfv1.NodeRunning, wfv1.NodeRunning, wfv1.NodeSucceeded}, phases)
+	assert.Equal(t, []int{0, 0, 1, 0}, nodes)
+}
+
+// TestClient_WatchWorkflowExecutionPhase makes sure only the uid, phase and timestamps of the workflow are sent,
+// each time the phase changes, while the full workflow is sent by default
+func TestClient_WatchWorkflowExecutionPhase(t *testing.T) {
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	startedAt := metav1.NewTime(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC))
+	finishedAt := metav1.NewTime(startedAt.Add(time.Minute))
+	statuses := []wfv1.WorkflowStatus{
+		{Phase: wfv1.NodeRunning, StartedAt: startedAt},
+		{Phase: wfv1.NodeRunning, StartedAt: startedAt, Nodes: wfv1.Nodes{
+			"test": {ID: "test", Name: "test", Phase: wfv1.NodeRunning},
+		}},
+		{Phase: wfv1.NodeSucceeded, StartedAt: startedAt, FinishedAt: finishedAt},
+	}
+
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependWatchReactor("workflows", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFakeWithChanSize(len(statuses), false)
+		for i, status := range statuses {
+			watcher.Modify(&wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: fmt.Sprint(i + 1)},
+				Status:     status,
+			})
+		}
+
+		return true, watcher, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := c.WatchWorkflowExecutionPhase(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []*WorkflowExecution
+	for workflow := range watcher {
+		received = append(received, workflow)
+	}
+
+	// The current state, followed by each distinct phase
+	if assert.Len(t, received, 3) {
+		assert.Equal(t, we.UID, received[0].UID)
+		assert.Equal(t, wfv1.NodePhase(""), received[0].Phase)
+		assert.Equal(t, wfv1.NodeRunning, received[1].Phase)
+		assert.Equal(t, startedAt.UTC(), *received[1].StartedAt)
+		assert.Equal(t, wfv1.NodeSucceeded, received[2].Phase)
+		assert.Equal(t, finishedAt.UTC(), *received[2].FinishedAt)
+	}
+	for _, workflow := range received {
+		assert.Equal(t, &WorkflowExecution{
+			UID:        workflow.UID,
+			Phase:      workflow.Phase,
+			CreatedAt:  workflow.CreatedAt,
+			StartedAt:  workflow.StartedAt,
+			FinishedAt: workflow.FinishedAt,
+		}, workflow)
+	}
+
+	// The full workflow is sent for every status by default
+	watcher, err = c.WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received = nil
+	for workflow := range watcher {
+		received = append(received, workflow)
+	}
+
+	if assert.Len(t, received, 4) {
+		assert.Equal(t, wfv1.NodeRunning, received[2].Phase)
+		for _, workflow := range received {
+			assert.NotEmpty(t, workflow.Name)
+			assert.NotEmpty(t, workflow.Manifest)
+		}
+	}
+}
+
+// TestClient_WatchWorkflowExecution_Snapshot makes sure the current state of the workflow is sent
+// without waiting for the workflow to change
+func TestClient_WatchWorkflowExecution_Snapshot(t *testing.T) {
+	c := DefaultTestClient().WithWatchGroup(NewWatchGroup())
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	watcher, err := c.WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	select {
+	case snapshot, ok := <-watcher:
+		assert.True(t, ok)
+		assert.Equal(t, we.UID, snapshot.Name)
+
+		wf := &wfv1.Workflow{}
+		assert.Nil(t, json.Unmarshal([]byte(snapshot.Manifest), wf))
+		assert.Equal(t, wfv1.NodeRunning, wf.Status.Phase)
+	case <-time.After(time.Second):
+		t.Fatal("current state of the workflow was not sent")
+	}
+}
+
+// TestClient_WatchWorkflowExecution_Finished makes sure only the current state is sent for a finished workflow
+func TestClient_WatchWorkflowExecution_Finished(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Phase = wfv1.NodeSucceeded
+	wf.Status.FinishedAt = metav1.Now()
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := c.WatchWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []*WorkflowExecution
+	for workflow := range watcher {
+		received = append(received, workflow)
+	}
+
+	assert.Len(t, received, 1)
+}
+
+// TestClient_PreviewWorkflowExecutions makes sure each parameter set is rendered into its own manifest
+// and that nothing is submitted
+func TestClient_PreviewWorkflowExecutions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err = c.GetWorkflowTemplate(namespace, wt.UID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := []string{"https://github.com/onepanelio/first.git", "https://github.com/onepanelio/second.git"}
+	var parameterSets [][]Parameter
+	for _, source := range sources {
+		parameterSets = append(parameterSets, []Parameter{{Name: "source", Value: ptr.String(source)}})
+	}
+
+	manifests, err := c.PreviewWorkflowExecutions(namespace, wt, parameterSets)
+	assert.Nil(t, err)
+	assert.Len(t, manifests, len(sources))
+
+	for i, manifest := range manifests {
+		workflows, err := UnmarshalWorkflows([]byte(manifest), true)
+		assert.Nil(t, err)
+		assert.Len(t, workflows, 1)
+
+		parameters := make(map[string]string)
+		for _, p := range workflows[0].Spec.Arguments.Parameters {
+			parameters[p.Name] = *p.Value
+		}
+		assert.Equal(t, sources[i], parameters["source"])
+		assert.Equal(t, "python mnist/main.py --epochs=1", parameters["command"])
+	}
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, workflows.Items)
+}
+
+// TestClient_CreateWorkflowExecution_DefaultParameters makes sure the namespace default parameters are passed to the workflow
+// and that parameters set by the client take precedence over them
+func TestClient_CreateWorkflowExecution_DefaultParameters(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["defaultParameters"] = `
+- name: bucket
+  value: onepanel-bucket
+- name: source
+  value: https://github.com/onepanelio/default.git
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := "https://github.com/onepanelio/client.git"
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name:       "test",
+		Parameters: []Parameter{{Name: "source", Value: ptr.String(source)}},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parameters := make(map[string]string)
+	for _, p := range wf.Spec.Arguments.Parameters {
+		parameters[p.Name] = *p.Value
+	}
+	assert.Equal(t, "onepanel-bucket", parameters["bucket"])
+	assert.Equal(t, source, parameters["source"])
+	assert.Equal(t, "python mnist/main.py --epochs=1", parameters["command"])
+}
+
+// TestClient_GetNamespaceDefaultParameters_Missing makes sure there are no default parameters if they are not configured
+func TestClient_GetNamespaceDefaultParameters_Missing(t *testing.T) {
+	c := DefaultTestClient()
+
+	parameters, err := c.GetNamespaceDefaultParameters("onepanel")
+	assert.Nil(t, err)
+	assert.Empty(t, parameters)
+
+	parameters, err = c.GetNamespaceDefaultParameters("missing")
+	assert.Nil(t, err)
+	assert.Empty(t, parameters)
+}
+
+// TestClient_ResubmitWorkflowExecution_Retries makes sure the retries count of a workflow execution goes up each time it is resubmitted,
+// and that the count is kept on the original workflow execution rather than on the resubmitted one
+func TestClient_ResubmitWorkflowExecution_Retries(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := int32(1); i <= 2; i++ {
+		resubmitted, err := c.ResubmitWorkflowExecution(namespace, we.UID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, we.UID, resubmitted.UID)
+		assert.Equal(t, i, resubmitted.Retries)
+
+		// The resubmitted workflow execution has no row to keep a count on
+		var rows int
+		err = sb.Select("COUNT(*)").
+			From("workflow_executions").
+			Where(sq.Eq{
+				"namespace": namespace,
+				"uid":       resubmitted.UID,
+			}).
+			RunWith(c.DB).
+			QueryRow().
+			Scan(&rows)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, rows)
+	}
+
+	we, err = c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), we.Retries)
+}
+
+// TestClient_ResubmitWorkflowExecution_AuditEvent makes sure resubmitting is recorded for the new workflow execution,
+// along with the one it was resubmitted from
+func TestClient_ResubmitWorkflowExecution_AuditEvent(t *testing.T) {
+	c := DefaultTestClient()
+	c.Identity = "system:serviceaccount:onepanel:admin"
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resubmitted, err := c.ResubmitWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, we.UID, resubmitted.UID)
+
+	events, err := c.ListAuditEvents(namespace, resubmitted.UID, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, AuditActionResubmitWorkflowExecution, events[0].Action)
+		assert.Equal(t, "system:serviceaccount:onepanel:admin", events[0].Identity)
+		if assert.Len(t, events[0].Parameters, 1) {
+			assert.Equal(t, "originalUid", events[0].Parameters[0].Name)
+			if assert.NotNil(t, events[0].Parameters[0].Value) {
+				assert.Equal(t, we.UID, *events[0].Parameters[0].Value)
+			}
+		}
+	}
+
+	// Only the creation is recorded for the original
+	events, err = c.ListAuditEvents(namespace, we.UID, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, AuditActionCreateWorkflowExecution, events[0].Action)
+	}
+}
+
+// TestClient_ResubmitWorkflowExecution_Labels makes sure the resubmitted workflow has the same onepanel labels as the original,
+// so it is still found when filtering by workflow template
+func TestClient_ResubmitWorkflowExecution_Labels(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resubmitted, err := c.ResubmitWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+
+	original, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(resubmitted.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originalLabels := label.FilterByPrefix(label.OnepanelPrefix, original.Labels)
+	assert.Equal(t, wt.UID, originalLabels[workflowTemplateUIDLabelKey])
+	assert.NotEmpty(t, originalLabels[workflowTemplateVersionLabelKey])
+	assert.Equal(t, originalLabels, label.FilterByPrefix(label.OnepanelPrefix, wf.Labels))
+}
+
+// TestClient_GetWorkflowExecution_Attempts makes sure a workflow that failed and succeeded once retried
+// has an attempt for each run, with its outcome and timestamps
+func TestClient_GetWorkflowExecution_Attempts(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	setStatus := func(status wfv1.WorkflowStatus) {
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wf.Status = status
+		if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	startedAt := time.Date(2020, 12, 6, 10, 0, 0, 0, time.UTC)
+	setStatus(wfv1.WorkflowStatus{
+		Phase:      wfv1.NodeFailed,
+		StartedAt:  metav1.NewTime(startedAt),
+		FinishedAt: metav1.NewTime(startedAt.Add(time.Minute)),
+	})
+
+	failed, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Empty(t, failed.Attempts)
+
+	if _, err := c.RetryWorkflowExecution(namespace, we.UID); err != nil {
+		t.Fatal(err)
+	}
+
+	retriedAt := startedAt.Add(time.Hour)
+	setStatus(wfv1.WorkflowStatus{
+		Phase:      wfv1.NodeSucceeded,
+		StartedAt:  metav1.NewTime(retriedAt),
+		FinishedAt: metav1.NewTime(retriedAt.Add(time.Minute)),
+	})
+
+	succeeded, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), succeeded.Retries)
+	if assert.Len(t, succeeded.Attempts, 2) {
+		assert.Equal(t, wfv1.NodeFailed, succeeded.Attempts[0].Phase)
+		assert.True(t, startedAt.Equal(*succeeded.Attempts[0].StartedAt))
+		assert.True(t, startedAt.Add(time.Minute).Equal(*succeeded.Attempts[0].FinishedAt))
+
+		assert.Equal(t, wfv1.NodeSucceeded, succeeded.Attempts[1].Phase)
+		assert.True(t, retriedAt.Equal(*succeeded.Attempts[1].StartedAt))
+		assert.True(t, retriedAt.Add(time.Minute).Equal(*succeeded.Attempts[1].FinishedAt))
+	}
+}
+
+// Test_copyOnepanelLabels makes sure only onepanel labels are copied and that they replace labels with the same key
+func Test_copyOnepanelLabels(t *testing.T) {
+	from := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				workflowTemplateUIDLabelKey:     "test",
+				workflowTemplateVersionLabelKey: "2",
+				"team":                          "vision",
+			},
+		},
+	}
+	to := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				workflowTemplateVersionLabelKey: "1",
+			},
+		},
+	}
+
+	copyOnepanelLabels(from, to)
+	assert.Equal(t, map[string]string{
+		workflowTemplateUIDLabelKey:     "test",
+		workflowTemplateVersionLabelKey: "2",
+	}, to.Labels)
+
+	empty := &wfv1.Workflow{}
+	copyOnepanelLabels(from, empty)
+	assert.Len(t, empty.Labels, 2)
+}
+
+// TestClient_CreateWorkflowExecution_IdempotencyKey makes sure creating a workflow execution twice with the same key
+// only creates it once and returns the same execution both times
+func TestClient_CreateWorkflowExecution_IdempotencyKey(t *testing.T) {
+	// The fake client does not set the creation timestamp, which the idempotency key expiry is based on
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependReactor("create", "workflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		wf := action.(k8stesting.CreateAction).GetObject().(*wfv1.Workflow)
+		wf.CreationTimestamp = metav1.NewTime(time.Now().Truncate(time.Second))
+		return false, nil, nil
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "first", IdempotencyKey: "key"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "first", IdempotencyKey: "key"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, first, second)
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, workflows.Items, 1)
+
+	third, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "second", IdempotencyKey: "other-key"}, wt)
+	assert.Nil(t, err)
+	assert.NotEqual(t, first.UID, third.UID)
+}
+
+// TestClient_CreateWorkflowExecution_IdempotencyKey_Concurrent makes sure concurrent calls with the same key
+// only create the workflow execution once, the other calls either get it or are asked to try again
+func TestClient_CreateWorkflowExecution_IdempotencyKey_Concurrent(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	concurrency := 5
+	results := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			_, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{IdempotencyKey: "key"}, wt)
+			results <- err
+		}()
+	}
+	for i := 0; i < concurrency; i++ {
+		if err := <-results; err != nil {
+			assertUserErrorCode(t, err, codes.Aborted)
+		}
+	}
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, workflows.Items, 1)
+}
+
+// TestClient_CreateWorkflowExecution_IdempotencyKey_Failed makes sure the key of a workflow execution
+// that could not be created can be used again
+func TestClient_CreateWorkflowExecution_IdempotencyKey_Failed(t *testing.T) {
+	failing := argoFake.NewSimpleClientset()
+	failing.PrependReactor("create", "workflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("workflows are not accepted")
+	})
+
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	argoClient := c.argoprojV1alpha1
+	c.argoprojV1alpha1 = failing.ArgoprojV1alpha1()
+	_, err = c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test", IdempotencyKey: "key"}, wt)
+	assert.NotNil(t, err)
+
+	c.argoprojV1alpha1 = argoClient
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test", IdempotencyKey: "key"}, wt)
+	assert.Nil(t, err)
+	if assert.NotNil(t, we) {
+		assert.Equal(t, "test", we.UID)
+	}
+}
+
+// assertUserErrorCode asserts that err is a *util.UserError with the code
+func assertUserErrorCode(t *testing.T, err error, code codes.Code) {
+	userErr, ok := err.(*util.UserError)
+	if assert.True(t, ok) {
+		assert.Equal(t, code, userErr.Code)
+	}
+}
+
+func TestClient_SuspendWorkflowExecution(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	assert.Nil(t, c.SuspendWorkflowExecution(namespace, we.UID))
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.True(t, isWorkflowSuspended(wf))
+
+	// Suspending a suspended workflow is not allowed
+	assertUserErrorCode(t, c.SuspendWorkflowExecution(namespace, we.UID), codes.FailedPrecondition)
+}
+
+// TestClient_SuspendWorkflowExecution_NotRunning makes sure only running workflows can be suspended
+func TestClient_SuspendWorkflowExecution_NotRunning(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Phase = wfv1.NodeSucceeded
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	assertUserErrorCode(t, c.SuspendWorkflowExecution(namespace, we.UID), codes.FailedPrecondition)
+}
+
+func TestClient_ResumeWorkflowExecution(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	// Resuming a workflow that is not suspended is not allowed
+	_, err := c.ResumeWorkflowExecution(namespace, we.UID)
+	assertUserErrorCode(t, err, codes.FailedPrecondition)
+
+	if err := c.SuspendWorkflowExecution(namespace, we.UID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ResumeWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.False(t, isWorkflowSuspended(wf))
+	assert.Equal(t, wfv1.NodeRunning, wf.Status.Phase)
+}
+
+// TestClient_GetWorkflowExecution_Archived makes sure a finished workflow execution is loaded from the database
+// once its argo workflow is garbage collected
+func TestClient_GetWorkflowExecution_Archived(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Phase = wfv1.NodeSucceeded
+	wf.Status.FinishedAt = metav1.Now()
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading the workflow does not write to the database
+	we, err = c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.False(t, we.Archived)
+	assert.Nil(t, we.FinalManifest)
+
+	// The exit handler reporting the final status persists it
+	if err := c.UpdateWorkflowExecutionStatus(namespace, we.UID, &WorkflowExecutionStatus{Phase: wfv1.NodeSucceeded}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ArgoprojV1alpha1().Workflows(namespace).Delete(we.UID, &metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.True(t, archived.Archived)
+	assert.Equal(t, we.Manifest, archived.Manifest)
+	assert.Equal(t, wfv1.NodeSucceeded, archived.ArgoWorkflow.Status.Phase)
+	assert.Equal(t, wt.UID, archived.WorkflowTemplate.UID)
+}
+
+// TestClient_GetWorkflowExecution_NoVersionLabel makes sure a workflow without a version label
+// gets the latest version of its workflow template instead of failing
+func TestClient_GetWorkflowExecution_NoVersionLabel(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+		UID:      wt.UID,
+		Name:     "test",
+		Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, versionLabel := range []string{"", " "} {
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if versionLabel == "" {
+			delete(wf.Labels, workflowTemplateVersionLabelKey)
+		} else {
+			wf.Labels[workflowTemplateVersionLabelKey] = versionLabel
+		}
+		if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+			t.Fatal(err)
+		}
+
+		getWe, err := c.GetWorkflowExecution(namespace, we.UID)
+		assert.Nil(t, err)
+		if assert.NotNil(t, getWe) {
+			assert.Equal(t, wt.UID, getWe.WorkflowTemplate.UID)
+			assert.Equal(t, latest.Version, getWe.WorkflowTemplate.Version)
+		}
+	}
+}
+
+// TestClient_GetWorkflowExecution_Warnings makes sure a workflow that ran a superseded workflow template version
+// has a warning, and one that ran the latest version does not
+func TestClient_GetWorkflowExecution_Warnings(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "old"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getOld, err := c.GetWorkflowExecution(namespace, old.UID)
+	assert.Nil(t, err)
+	assert.Empty(t, getOld.Warnings)
+
+	latest, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+		UID:      wt.UID,
+		Name:     "test",
+		Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latestTemplate, err := c.GetWorkflowTemplate(namespace, wt.UID, latest.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "current"}, latestTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getOld, err = c.GetWorkflowExecution(namespace, old.UID)
+	assert.Nil(t, err)
+	if assert.Len(t, getOld.Warnings, 1) {
+		assert.Contains(t, getOld.Warnings[0], fmt.Sprintf("a newer version %v exists", latest.Version))
+	}
+
+	getCurrent, err := c.GetWorkflowExecution(namespace, current.UID)
+	assert.Nil(t, err)
+	assert.Empty(t, getCurrent.Warnings)
+}
+
+// TestClient_GetWorkflowExecution_UnschedulablePods makes sure pending pods that can not be scheduled are reported
+// with the reason of their latest FailedScheduling event, or of their PodScheduled condition without one
+func TestClient_GetWorkflowExecution_UnschedulablePods(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createPod := func(name string, phase corev1.PodPhase, scheduled corev1.ConditionStatus) {
+		_, err := c.CoreV1().Pods(namespace).Create(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"workflows.argoproj.io/workflow": we.UID,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:    corev1.PodScheduled,
+						Status:  scheduled,
+						Reason:  "Unschedulable",
+						Message: "0/1 nodes are available: 1 node(s) didn't match node selector.",
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	createPodEvent := func(name, podName, reason, message string, at time.Time) {
+		_, err := c.CoreV1().Events(namespace).Create(&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      podName,
+				Namespace: namespace,
+			},
+			Reason:        reason,
+			Message:       message,
+			LastTimestamp: metav1.NewTime(at),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	createPod("test-memory", corev1.PodPending, corev1.ConditionFalse)
+	createPodEvent("test-memory.1", "test-memory", "FailedScheduling", "0/3 nodes are available: 3 Insufficient cpu.", now.Add(-time.Minute))
+	createPodEvent("test-memory.2", "test-memory", "FailedScheduling", "0/3 nodes are available: 3 Insufficient memory.", now)
+	createPodEvent("test-memory.3", "test-memory", "Pulling", "Pulling image", now.Add(time.Minute))
+	createPod("test-selector", corev1.PodPending, corev1.ConditionFalse)
+	createPod("test-scheduled", corev1.PodPending, corev1.ConditionTrue)
+	createPod("test-running", corev1.PodRunning, corev1.ConditionTrue)
+
+	getWe, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+
+	unschedulablePods := make(map[string]*UnschedulablePod)
+	for _, pod := range getWe.UnschedulablePods {
+		unschedulablePods[pod.PodName] = pod
+	}
+	assert.Len(t, unschedulablePods, 2)
+	assert.Equal(t, &UnschedulablePod{
+		PodName: "test-memory",
+		Reason:  "FailedScheduling",
+		Message: "0/3 nodes are available: 3 Insufficient memory.",
+	}, unschedulablePods["test-memory"])
+	assert.Equal(t, &UnschedulablePod{
+		PodName: "test-selector",
+		Reason:  "Unschedulable",
+		Message: "0/1 nodes are available: 1 node(s) didn't match node selector.",
+	}, unschedulablePods["test-selector"])
+}
+
+// TestClient_GetWorkflowExecution_ProvisioningVolumes makes sure persistent volume claims of the workflow that are not bound
+// are reported with their latest event, apart from the pods pending on them
+func TestClient_GetWorkflowExecution_ProvisioningVolumes(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createClaim := func(name string, phase corev1.PersistentVolumeClaimPhase) {
+		_, err := c.CoreV1().PersistentVolumeClaims(namespace).Create(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: phase,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	createClaimEvent := func(name, claimName, reason, message string, at time.Time) {
+		_, err := c.CoreV1().Events(namespace).Create(&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Name:      claimName,
+				Namespace: namespace,
+			},
+			Reason:        reason,
+			Message:       message,
+			LastTimestamp: metav1.NewTime(at),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	dataClaim := we.UID + "-data"
+	createClaim(dataClaim, corev1.ClaimPending)
+	createClaimEvent("data.1", dataClaim, "ExternalProvisioning", "waiting for a volume to be created", now.Add(-time.Minute))
+	createClaimEvent("data.2", dataClaim, "ProvisioningFailed", "storageclass.storage.k8s.io \"fast\" not found", now)
+	createClaim(we.UID+"-output", corev1.ClaimBound)
+
+	_, err = c.CoreV1().Pods(namespace).Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"workflows.argoproj.io/workflow": we.UID,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Unschedulable",
+					Message: "pod has unbound immediate PersistentVolumeClaims",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getWe, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []*ProvisioningVolume{
+		{
+			ClaimName: dataClaim,
+			Phase:     corev1.ClaimPending,
+			Reason:    "ProvisioningFailed",
+			Message:   "storageclass.storage.k8s.io \"fast\" not found",
+		},
+	}, getWe.ProvisioningVolumes)
+
+	assert.Len(t, getWe.UnschedulablePods, 1)
+	assert.Equal(t, "test-pod", getWe.UnschedulablePods[0].PodName)
+}
+
+// TestClient_GetWorkflowExecution_NotFinished makes sure a workflow execution without a final status
+// is not found once its argo workflow is gone
+func TestClient_GetWorkflowExecution_NotFinished(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ArgoprojV1alpha1().Workflows(namespace).Delete(we.UID, &metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetWorkflowExecution(namespace, we.UID)
+	assertUserErrorCode(t, err, codes.NotFound)
+}
+
+// TestClient_GetWorkflowExecution_EstimatedFinishAt makes sure running workflow executions are estimated to take
+// as long as the successful workflow executions of the same workflow template version, once there are enough of them
+func TestClient_GetWorkflowExecution_EstimatedFinishAt(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setTimes := func(uid string, phase wfv1.NodePhase, startedAt time.Time, finishedAt *time.Time) {
+		_, err := sb.Update("workflow_executions").
+			SetMap(sq.Eq{
+				"phase":       phase,
+				"started_at":  startedAt,
+				"finished_at": finishedAt,
+			}).
+			Where(sq.Eq{"uid": uid}).
+			RunWith(c.DB).
+			Exec()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	running, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "running"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startedAt := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+	setTimes(running.UID, wfv1.NodeRunning, startedAt, nil)
+
+	start := startedAt.Add(-24 * time.Hour)
+	for i, duration := range []time.Duration{40 * time.Minute, 60 * time.Minute, 80 * time.Minute} {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: fmt.Sprintf("history-%v", i)}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		finishedAt := start.Add(duration)
+		setTimes(we.UID, wfv1.NodeSucceeded, start, &finishedAt)
+
+		we, err = c.GetWorkflowExecution(namespace, running.UID)
+		assert.Nil(t, err)
+		if i < workflowExecutionEstimateMinimumHistory-1 {
+			assert.Nil(t, we.EstimatedFinishAt)
+		}
+	}
+
+	// failed workflow executions are not part of the history
+	failed, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "failed"}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failedAt := start.Add(time.Minute)
+	setTimes(failed.UID, wfv1.NodeFailed, start, &failedAt)
+
+	we, err := c.GetWorkflowExecution(namespace, running.UID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, we.EstimatedFinishAt) {
+		assert.WithinDuration(t, startedAt.Add(60*time.Minute), *we.EstimatedFinishAt, time.Second)
+	}
+}
+
+// TestClient_GetWorkflowExecution_EstimatedCost makes sure the cost of the requested resources is estimated from the namespace pricing,
+// over the whole run for finished workflow executions and so far for running ones
+func TestClient_GetWorkflowExecution_EstimatedCost(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["pricing"] = `
+cpuHour: 0.05
+memoryGBHour: 0.01
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 cores and 4 GB cost 0.14 an hour
+	createWorkflowExecution := func(name string, startedAt time.Time, finishedAt *time.Time) *WorkflowExecution {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wf.Spec.Templates = []wfv1.Template{
+			{
+				Name: "main",
+				Container: &corev1.Container{
+					Image: "alpine",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("4G"),
+						},
+					},
+				},
+			},
+		}
+		if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = sb.Update("workflow_executions").
+			SetMap(sq.Eq{
+				"started_at":  startedAt,
+				"finished_at": finishedAt,
+			}).
+			Where(sq.Eq{"uid": we.UID}).
+			RunWith(c.DB).
+			Exec()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return we
+	}
+
+	startedAt := time.Now().UTC().Add(-24 * time.Hour)
+	finishedAt := startedAt.Add(3 * time.Hour)
+	finished := createWorkflowExecution("finished", startedAt, &finishedAt)
+
+	we, err := c.GetWorkflowExecution(namespace, finished.UID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, we.EstimatedCost) {
+		assert.InDelta(t, 0.42, *we.EstimatedCost, 0.0001)
+	}
+
+	running := createWorkflowExecution("running", time.Now().UTC().Add(-30*time.Minute), nil)
+
+	we, err = c.GetWorkflowExecution(namespace, running.UID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, we.EstimatedCost) {
+		assert.InDelta(t, 0.07, *we.EstimatedCost, 0.001)
+	}
+
+	// Without pricing, there is no estimate
+	onepanelConfigMap, err := c.CoreV1().ConfigMaps(namespace).Get("onepanel", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(onepanelConfigMap.Data, "pricing")
+	if _, err := c.CoreV1().ConfigMaps(namespace).Update(onepanelConfigMap); err != nil {
+		t.Fatal(err)
+	}
+
+	we, err = c.GetWorkflowExecution(namespace, finished.UID)
+	assert.Nil(t, err)
+	assert.Nil(t, we.EstimatedCost)
+}
+
+// TestClient_CreateWorkflowExecutions makes sure a workflow execution that can not be created
+// does not stop the rest of the batch
+func TestClient_CreateWorkflowExecutions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newWorkflow := func(name, command string) *WorkflowExecution {
+		return &WorkflowExecution{
+			Parameters: []Parameter{
+				{Name: "workflow-execution-name", Value: ptr.String(name)},
+				{Name: "command", Value: ptr.String(command)},
+			},
+		}
+	}
+
+	results := c.CreateWorkflowExecutions(namespace, []*WorkflowExecution{
+		newWorkflow("first", "python main.py --lr=0.1"),
+		newWorkflow("first", "python main.py --lr=0.01"), // same name as the first one
+		newWorkflow("third", "python main.py --lr=0.001"),
+	}, wt)
+
+	assert.Len(t, results, 3)
+
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "first", results[0].WorkflowExecution.Name)
+
+	assert.NotNil(t, results[1].Err)
+	assert.Nil(t, results[1].WorkflowExecution)
+
+	assert.Nil(t, results[2].Err)
+	assert.Equal(t, "third", results[2].WorkflowExecution.Name)
+
+	wfs, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, wfs.Items, 2)
+}
+
+// TestClient_CreateWorkflowExecution_RateLimit makes sure a namespace can create a burst of workflow executions
+// before being throttled, without affecting other namespaces
+func TestClient_CreateWorkflowExecution_RateLimit(t *testing.T) {
+	c := DefaultTestClient().WithWorkflowExecutionLimiter(ratelimit.NewKeyedLimiter(0.001, 2))
+	clearDatabase(t)
+
+	templates := make(map[string]*WorkflowTemplate)
+	for _, namespace := range []string{"onepanel", "other"} {
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		templates[namespace] = wt
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.CreateWorkflowExecution("onepanel", &WorkflowExecution{Name: fmt.Sprintf("test-%v", i)}, templates["onepanel"])
+		assert.Nil(t, err)
+	}
+
+	_, err := c.CreateWorkflowExecution("onepanel", &WorkflowExecution{Name: "test-2"}, templates["onepanel"])
+	assertUserErrorCode(t, err, codes.ResourceExhausted)
+
+	_, err = c.CreateWorkflowExecution("other", &WorkflowExecution{Name: "test-0"}, templates["other"])
+	assert.Nil(t, err)
+}
+
+// TestClient_CreateWorkflowExecution_TTL makes sure the TTL of the workflow execution, or else the namespace's,
+// is set on the submitted workflow
+func TestClient_CreateWorkflowExecution_TTL(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["workflowTTLSecondsAfterFinished"] = "3600"
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		ttl       *int32
+		expected  *int32
+		errorCode codes.Code
+	}{
+		{"none", mockSystemConfigMap, nil, nil, codes.OK},
+		{"workflow", mockSystemConfigMap, ptr.Int32(600), ptr.Int32(600), codes.OK},
+		{"namespace", configMap, nil, ptr.Int32(3600), codes.OK},
+		{"workflow over namespace", configMap, ptr.Int32(0), ptr.Int32(0), codes.OK},
+		{"negative", mockSystemConfigMap, ptr.Int32(-1), nil, codes.InvalidArgument},
+	}
+
+	namespace := "onepanel"
+	for _, test := range tests {
+		c := NewTestClient(database, test.configMap, mockSystemSecret)
+		clearDatabase(t)
+
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name:                    "test",
+			TTLSecondsAfterFinished: test.ttl,
+		}, wt)
+		if test.errorCode != codes.OK {
+			userErr, ok := err.(*util.UserError)
+			assert.True(t, ok, test.name)
+			if ok {
+				assert.Equal(t, test.errorCode, userErr.Code, test.name)
+			}
+			continue
+		}
+		assert.Nil(t, err, test.name)
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		assert.Nil(t, err, test.name)
+		if test.expected == nil {
+			assert.Nil(t, wf.Spec.TTLStrategy, test.name)
+			continue
+		}
+		if assert.NotNil(t, wf.Spec.TTLStrategy, test.name) {
+			assert.Equal(t, test.expected, wf.Spec.TTLStrategy.SecondsAfterCompletion, test.name)
+		}
+	}
+}
+
+// TestClient_CreateWorkflowExecution_ActiveDeadline makes sure the deadline of the workflow execution is set on the submitted workflow
+// and that a deadline that is not positive is rejected
+func TestClient_CreateWorkflowExecution_ActiveDeadline(t *testing.T) {
+	tests := []struct {
+		name      string
+		deadline  *int64
+		errorCode codes.Code
+	}{
+		{"none", nil, codes.OK},
+		{"positive", ptr.Int64(3600), codes.OK},
+		{"zero", ptr.Int64(0), codes.InvalidArgument},
+		{"negative", ptr.Int64(-1), codes.InvalidArgument},
+	}
+
+	namespace := "onepanel"
+	for _, test := range tests {
+		c := DefaultTestClient()
+		clearDatabase(t)
+
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name:                  "test",
+			ActiveDeadlineSeconds: test.deadline,
+		}, wt)
+		if test.errorCode != codes.OK {
+			userErr, ok := err.(*util.UserError)
+			assert.True(t, ok, test.name)
+			if ok {
+				assert.Equal(t, test.errorCode, userErr.Code, test.name)
+			}
+
+			workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+			assert.Nil(t, err, test.name)
+			assert.Empty(t, workflows.Items, test.name)
+			continue
+		}
+		assert.Nil(t, err, test.name)
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.deadline, wf.Spec.ActiveDeadlineSeconds, test.name)
+	}
+}
+
+// TestClient_CreateWorkflowExecution_Priority makes sure workflows are scheduled with the priority class they are created with,
+// if the namespace allows it
+func TestClient_CreateWorkflowExecution_Priority(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["priorityClasses"] = "[low, high]"
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		priority  string
+		errorCode codes.Code
+	}{
+		{"none", configMap, "", codes.OK},
+		{"allowed", configMap, "high", codes.OK},
+		{"not allowed", configMap, "urgent", codes.InvalidArgument},
+		{"no priority classes", mockSystemConfigMap, "high", codes.InvalidArgument},
+	}
+
+	namespace := "onepanel"
+	for _, test := range tests {
+		c := NewTestClient(database, test.configMap, mockSystemSecret)
+		clearDatabase(t)
+
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name:     "test",
+			Priority: test.priority,
+		}, wt)
+		if test.errorCode != codes.OK {
+			userErr, ok := err.(*util.UserError)
+			assert.True(t, ok, test.name)
+			if ok {
+				assert.Equal(t, test.errorCode, userErr.Code, test.name)
+			}
+
+			workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+			assert.Nil(t, err, test.name)
+			assert.Empty(t, workflows.Items, test.name)
+			continue
+		}
+		assert.Nil(t, err, test.name)
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.priority, wf.Spec.PodPriorityClassName, test.name)
+	}
+}
+
+// Test_validateToleration makes sure malformed tolerations are rejected
+func Test_validateToleration(t *testing.T) {
+	valid := []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists},
+		{Operator: corev1.TolerationOpExists},
+		{Key: "node.kubernetes.io/unreachable", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.Int64(300)},
+	}
+	for _, toleration := range valid {
+		assert.Nil(t, validateToleration(toleration), toleration.String())
+	}
+
+	invalid := []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Value: "true"},
+		{Value: "true"},
+		{Key: "nvidia.com/gpu", Operator: "In"},
+		{Key: "nvidia.com/gpu", Effect: "NoRun"},
+		{Key: "not a key"},
+		{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: ptr.Int64(300)},
+	}
+	for _, toleration := range invalid {
+		assertUserErrorCode(t, validateToleration(toleration), codes.InvalidArgument)
+	}
+}
+
+// TestClient_CreateWorkflowExecution_Scheduling makes sure the node selector and tolerations are set in the argo workflow,
+// and that malformed ones are rejected without creating it
+func TestClient_CreateWorkflowExecution_Scheduling(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toleration := corev1.Toleration{
+		Key:      "nvidia.com/gpu",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		NodeSelector: map[string]string{
+			"beta.kubernetes.io/instance-type": "p2.xlarge",
+		},
+		Tolerations: []corev1.Toleration{toleration},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "p2.xlarge", wf.Spec.NodeSelector["beta.kubernetes.io/instance-type"])
+	assert.Equal(t, []corev1.Toleration{toleration}, wf.Spec.Tolerations)
+
+	invalid := []*WorkflowExecution{
+		{Name: "invalid-key", NodeSelector: map[string]string{"instance type": "p2.xlarge"}},
+		{Name: "invalid-value", NodeSelector: map[string]string{"beta.kubernetes.io/instance-type": "p2 xlarge"}},
+		{Name: "invalid-toleration", Tolerations: []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Value: "true"}}},
+	}
+	for _, workflow := range invalid {
+		_, err := c.CreateWorkflowExecution(namespace, workflow, wt)
+		assertUserErrorCode(t, err, codes.InvalidArgument)
+	}
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, workflows.Items, 1)
+}
+
+// TestClient_CreateWorkflowExecution_FailFast makes sure the DAGs of the argo workflow fail fast if asked for,
+// and are left as they are otherwise
+func TestClient_CreateWorkflowExecution_FailFast(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, failFast := range []bool{true, false} {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name:     fmt.Sprintf("fail-fast-%v", failFast),
+			FailFast: failFast,
+		}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dags := 0
+		for _, template := range wf.Spec.Templates {
+			if template.DAG == nil {
+				continue
+			}
+			dags++
+			if failFast {
+				assert.Equal(t, ptr.Bool(true), template.DAG.FailFast, template.Name)
+			} else {
+				assert.Nil(t, template.DAG.FailFast, template.Name)
+			}
+		}
+		assert.NotZero(t, dags)
+	}
+}
+
+// TestClient_CreateWorkflowExecution_SecretRef makes sure a parameter's secret is passed to argo,
+// but not saved or returned
+func TestClient_CreateWorkflowExecution_SecretRef(t *testing.T) {
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "onepanel",
+		},
+		Data: map[string][]byte{
+			"source": []byte("https://secret.onepanel.io/repository.git"),
+		},
+	})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "source", SecretRef: ptr.String("api-keys/source")},
+		},
+	}, wt)
+	assert.Nil(t, err)
+	assert.Nil(t, we.Parameters[0].Value)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	parameters := make(map[string]string)
+	for _, p := range wf.Spec.Arguments.Parameters {
+		parameters[p.Name] = *p.Value
+	}
+	assert.Equal(t, "https://secret.onepanel.io/repository.git", parameters["source"])
+
+	saved := ""
+	err = database.Get(&saved, "SELECT parameters FROM workflow_executions WHERE name = $1", we.UID)
+	assert.Nil(t, err)
+	assert.NotContains(t, saved, "secret.onepanel.io")
+	assert.Contains(t, saved, "api-keys/source")
+}
+
+// TestClient_saveWorkflowExecutionFinalManifest_ParametersError makes sure that if the parameters of the workflow execution
+// can not be loaded, the workflow is neither returned nor saved, as the values of its secrets can not be redacted
+func TestClient_saveWorkflowExecutionFinalManifest_ParametersError(t *testing.T) {
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "onepanel",
+		},
+		Data: map[string][]byte{
+			"source": []byte("https://secret.onepanel.io/repository.git"),
+		},
+	})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "source", SecretRef: ptr.String("api-keys/source")},
+		},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every query of this client fails
+	failing := closedDatabaseTestClient(t)
+	failing.argoprojV1alpha1 = c.argoprojV1alpha1
+
+	workflow, err := failing.typeRedactedWorkflow(namespace, we.UID, wf)
+	assertUserErrorCode(t, err, codes.Internal)
+	assert.Nil(t, workflow)
+
+	err = failing.saveWorkflowExecutionFinalManifest(namespace, we.UID, wfv1.NodeSucceeded)
+	assert.NotNil(t, err)
+
+	var finalManifest *string
+	err = database.Get(&finalManifest, "SELECT final_manifest FROM workflow_executions WHERE name = $1", we.UID)
+	assert.Nil(t, err)
+	assert.Nil(t, finalManifest)
+}
+
+// Test_redactWorkflowSecrets makes sure the values of the arguments taken from secrets are redacted wherever they appear
+func Test_redactWorkflowSecrets(t *testing.T) {
+	secret := "https://secret.onepanel.io/repository.git"
+	wf := &wfv1.Workflow{
+		Spec: wfv1.WorkflowSpec{
+			Arguments: wfv1.Arguments{
+				Parameters: []wfv1.Parameter{
+					{Name: "source", Value: ptr.String(secret)},
+					{Name: "command", Value: ptr.String("python mnist/main.py")},
+				},
+			},
+		},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test": wfv1.NodeStatus{
+					Inputs: &wfv1.Inputs{
+						Parameters: []wfv1.Parameter{{Name: "repository", Value: ptr.String(secret)}},
+					},
+				},
+			},
+		},
+	}
+
+	unchanged, err := redactWorkflowSecrets(wf, []Parameter{{Name: "source", Value: ptr.String(secret)}})
+	assert.Nil(t, err)
+	assert.True(t, unchanged == wf)
+
+	redacted, err := redactWorkflowSecrets(wf, []Parameter{{Name: "source", SecretRef: ptr.String("api-keys/source")}})
+	assert.Nil(t, err)
+	assert.Equal(t, redactedParameterValue, *redacted.Spec.Arguments.Parameters[0].Value)
+	assert.Equal(t, "python mnist/main.py", *redacted.Spec.Arguments.Parameters[1].Value)
+	assert.Equal(t, redactedParameterValue, *redacted.Status.Nodes["test"].Inputs.Parameters[0].Value)
+	assert.Equal(t, secret, *wf.Spec.Arguments.Parameters[0].Value)
+}
+
+// TestClient_GetWorkflowExecution_SecretRef makes sure the value of a parameter's secret is never returned,
+// nor saved in the final manifest, and that the parameters returned can be used to create a workflow execution again
+func TestClient_GetWorkflowExecution_SecretRef(t *testing.T) {
+	secret := "https://secret.onepanel.io/repository.git"
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "onepanel",
+		},
+		Data: map[string][]byte{
+			"source": []byte(secret),
+		},
+	})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "source", SecretRef: ptr.String("api-keys/source")},
+		},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Argo passes the value to the inputs of the steps and marks the workflow finished
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Phase = wfv1.NodeSucceeded
+	wf.Status.Nodes = wfv1.Nodes{
+		we.UID: wfv1.NodeStatus{
+			ID:    we.UID,
+			Name:  we.UID,
+			Phase: wfv1.NodeSucceeded,
+			Inputs: &wfv1.Inputs{
+				Parameters: []wfv1.Parameter{{Name: "source", Value: ptr.String(secret)}},
+			},
+		},
+	}
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	workflow, err := c.GetWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, workflow.Manifest, secret)
+	assert.Contains(t, workflow.Manifest, redactedParameterValue)
+	if assert.Len(t, workflow.Parameters, 1) {
+		assert.Equal(t, redactedParameterValue, *workflow.Parameters[0].Value)
+		assert.Equal(t, "api-keys/source", *workflow.Parameters[0].SecretRef)
+	}
+
+	finalManifest := ""
+	err = database.Get(&finalManifest, "SELECT final_manifest FROM workflow_executions WHERE name = $1", we.UID)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, finalManifest)
+	assert.NotContains(t, finalManifest, secret)
+
+	again, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name:       "again",
+		Parameters: workflow.Parameters,
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(again.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	for _, p := range wf.Spec.Arguments.Parameters {
+		if p.Name == "source" {
+			assert.Equal(t, secret, *p.Value)
+		}
+	}
+}
+
+// TestClient_RetryWorkflowExecution_SecretRef makes sure the value of a parameter's secret is not returned
+// when the workflow execution is retried or resubmitted
+func TestClient_RetryWorkflowExecution_SecretRef(t *testing.T) {
+	secret := "https://secret.onepanel.io/repository.git"
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "onepanel",
+		},
+		Data: map[string][]byte{
+			"source": []byte(secret),
+		},
+	})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "source", SecretRef: ptr.String("api-keys/source")},
+		},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status = wfv1.WorkflowStatus{
+		Phase:      wfv1.NodeFailed,
+		StartedAt:  metav1.Now(),
+		FinishedAt: metav1.Now(),
+	}
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	retried, err := c.RetryWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, retried.Manifest, secret)
+	assert.Contains(t, retried.Manifest, redactedParameterValue)
+
+	resubmitted, err := c.ResubmitWorkflowExecution(namespace, we.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, resubmitted.Manifest, secret)
+	assert.Contains(t, resubmitted.Manifest, redactedParameterValue)
+
+	// The workflows still run with the value of the secret
+	wf, err = c.ArgoprojV1alpha1().Workflows(namespace).Get(resubmitted.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	for _, p := range wf.Spec.Arguments.Parameters {
+		if p.Name == "source" {
+			assert.Equal(t, secret, *p.Value)
+		}
+	}
+}
+
+// TestClient_CreateWorkflowExecution_AuditEvent makes sure creating a workflow execution is recorded with the caller,
+// without the values of secrets
+func TestClient_CreateWorkflowExecution_AuditEvent(t *testing.T) {
+	c := NewTestClient(database, mockSystemConfigMap, mockSystemSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "onepanel",
+		},
+		Data: map[string][]byte{
+			"source": []byte("https://secret.onepanel.io/repository.git"),
+		},
+	})
+	c.Identity = "system:serviceaccount:onepanel:admin"
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+		Parameters: []Parameter{
+			{Name: "source", SecretRef: ptr.String("api-keys/source")},
+		},
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := c.ListAuditEvents(namespace, we.UID, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, AuditActionCreateWorkflowExecution, events[0].Action)
+		assert.Equal(t, "system:serviceaccount:onepanel:admin", events[0].Identity)
+		assert.Equal(t, we.UID, events[0].ResourceUID)
+		if assert.Len(t, events[0].Parameters, 1) {
+			assert.Equal(t, "source", events[0].Parameters[0].Name)
+			assert.Nil(t, events[0].Parameters[0].Value)
+		}
+	}
+
+	saved := ""
+	err = database.Get(&saved, "SELECT parameters FROM audit_events WHERE resource_uid = $1", we.UID)
+	assert.Nil(t, err)
+	assert.NotContains(t, saved, "secret.onepanel.io")
+	assert.Contains(t, saved, "api-keys/source")
+
+	count, err := c.CountAuditEvents(namespace, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// TestClient_CreateWorkflowExecution_SecretRef_Invalid makes sure invalid secret references are rejected
+func TestClient_CreateWorkflowExecution_SecretRef_Invalid(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		secretRef string
+		code      codes.Code
+	}{
+		{"other/api-keys/source", codes.PermissionDenied},
+		{"api-keys/source", codes.NotFound},
+		{"onepanel/source", codes.NotFound},
+		{"api-keys", codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		_, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Parameters: []Parameter{
+				{Name: "source", SecretRef: ptr.String(test.secretRef)},
+			},
+		}, wt)
+		userErr, ok := err.(*util.UserError)
+		assert.True(t, ok, test.secretRef)
+		if ok {
+			assert.Equal(t, test.code, userErr.Code, test.secretRef)
+		}
+	}
+}
+
+func Test_newWorkflowExecutionLimiter(t *testing.T) {
+	unlimited := newWorkflowExecutionLimiter("", "")
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.Allow("onepanel"))
+	}
+
+	invalid := newWorkflowExecutionLimiter("fast", "")
+	for i := 0; i < 100; i++ {
+		assert.True(t, invalid.Allow("onepanel"))
+	}
+
+	limited := newWorkflowExecutionLimiter("0.001", "3")
+	for i := 0; i < 3; i++ {
+		assert.True(t, limited.Allow("onepanel"))
+	}
+	assert.False(t, limited.Allow("onepanel"))
+}
+
+// Test_getWorkflowResources makes sure the resources of every container of the pod templates are summed
+func Test_getWorkflowResources(t *testing.T) {
+	resources := func(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+		requirements := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{},
+			Limits:   corev1.ResourceList{},
+		}
+		if cpuRequest != "" {
+			requirements.Requests[corev1.ResourceCPU] = resource.MustParse(cpuRequest)
+		}
+		if memoryRequest != "" {
+			requirements.Requests[corev1.ResourceMemory] = resource.MustParse(memoryRequest)
+		}
+		if cpuLimit != "" {
+			requirements.Limits[corev1.ResourceCPU] = resource.MustParse(cpuLimit)
+		}
+		if memoryLimit != "" {
+			requirements.Limits[corev1.ResourceMemory] = resource.MustParse(memoryLimit)
+		}
+		return requirements
+	}
+
+	wf := &wfv1.Workflow{
+		Spec: wfv1.WorkflowSpec{
+			Templates: []wfv1.Template{
+				{
+					Name: "main",
+					DAG:  &wfv1.DAGTemplate{},
+				},
+				{
+					Name: "train",
+					Container: &corev1.Container{
+						Resources: resources("2", "4Gi", "4", "8Gi"),
+					},
+					Sidecars: []wfv1.UserContainer{
+						{Container: corev1.Container{Resources: resources("100m", "128Mi", "", "")}},
+					},
+				},
+				{
+					Name: "preprocess",
+					Script: &wfv1.ScriptTemplate{
+						Container: corev1.Container{
+							Resources: resources("500m", "1Gi", "", "2Gi"),
+						},
+					},
+					InitContainers: []wfv1.UserContainer{
+						{Container: corev1.Container{Resources: resources("", "", "250m", "")}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, WorkflowResources{
+		CPURequest:    2600,
+		CPULimit:      4250,
+		MemoryRequest: (4*1024 + 128 + 1024) * 1024 * 1024,
+		MemoryLimit:   10 * 1024 * 1024 * 1024,
+	}, getWorkflowResources(wf))
+
+	assert.Equal(t, WorkflowResources{}, getWorkflowResources(nil))
+}
+
+// Test_getWorkflowSteps makes sure each node that ran in a pod is mapped to its pod, and other nodes are left out
+func Test_getWorkflowSteps(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test": {
+					ID:          "test",
+					Name:        "test",
+					DisplayName: "test",
+					Type:        wfv1.NodeTypeSteps,
+					Phase:       wfv1.NodeRunning,
+				},
+				"test-1": {
+					ID:          "test-1",
+					Name:        "test[0].train",
+					DisplayName: "train",
+					Type:        wfv1.NodeTypePod,
+					Phase:       wfv1.NodeSucceeded,
+				},
+				"test-2": {
+					ID:          "test-2",
+					Name:        "test[1].evaluate",
+					DisplayName: "evaluate",
+					Type:        wfv1.NodeTypePod,
+					Phase:       wfv1.NodeRunning,
+				},
+				"test-3": {
+					ID:          "test-3",
+					Name:        "test[1].publish",
+					DisplayName: "publish",
+					Type:        wfv1.NodeTypePod,
+					Phase:       wfv1.NodeSkipped,
+				},
+				"test-4": {
+					ID:          "test-4",
+					Name:        "test[2].approve",
+					DisplayName: "approve",
+					Type:        wfv1.NodeTypeSuspend,
+					Phase:       wfv1.NodeRunning,
+				},
+			},
+		},
+	}
+
+	steps := getWorkflowSteps(wf)
+	assert.Equal(t, []*WorkflowStepMetrics{
+		{
+			NodeName:    "test[0].train",
+			DisplayName: "train",
+			PodName:     "test-1",
+			Metrics:     []*Metric{},
+		},
+		{
+			NodeName:    "test[1].evaluate",
+			DisplayName: "evaluate",
+			PodName:     "test-2",
+			Metrics:     []*Metric{},
+		},
+	}, steps)
+
+	assert.Len(t, getWorkflowSteps(&wfv1.Workflow{}), 0)
+}
+
+// TestClient_GetWorkflowExecutionStepMetrics_NoPods makes sure a workflow without pods has no step metrics
+func TestClient_GetWorkflowExecutionStepMetrics_NoPods(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	steps, err := c.GetWorkflowExecutionStepMetrics(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Len(t, steps, 0)
+
+	_, err = c.GetWorkflowExecutionStepMetrics(namespace, "not-found")
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+}
+
+// TestClient_renderWorkflow_CostLabels makes sure the namespace cost labels are added to the workflow options and the workflow,
+// replacing labels with the same key set by the client
+func TestClient_renderWorkflow_CostLabels(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["costLabels"] = `
+cost-center: ml-research
+team: vision
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+
+	workflowTemplate := &WorkflowTemplate{Manifest: defaultWorkflowTemplate}
+	workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf := &workflows[0]
+
+	opts := &WorkflowExecutionOptions{
+		Labels: map[string]string{
+			"team":  "other",
+			"owner": "alice",
+		},
+	}
+	err = c.renderWorkflow("onepanel", ptr.Uint64(1), wf, opts)
+	assert.Nil(t, err)
+
+	expected := map[string]string{
+		"cost-center": "ml-research",
+		"team":        "vision",
+		"owner":       "alice",
+	}
+	assert.Equal(t, expected, opts.Labels)
+	for key, value := range expected {
+		assert.Equal(t, value, wf.Labels[key])
+	}
+}
+
+// TestClient_CreateWorkflowExecution_CostLabels makes sure the submitted workflow has the namespace cost labels
+// and that invalid cost labels are rejected
+func TestClient_CreateWorkflowExecution_CostLabels(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["costLabels"] = "cost-center: ml-research\n"
+	invalidConfigMap := mockSystemConfigMap.DeepCopy()
+	invalidConfigMap.Data["costLabels"] = "cost-center: not a valid label value\n"
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		expected  map[string]string
+		errorCode codes.Code
+	}{
+		{"none", mockSystemConfigMap, map[string]string{}, codes.OK},
+		{"namespace", configMap, map[string]string{"cost-center": "ml-research"}, codes.OK},
+		{"invalid", invalidConfigMap, nil, codes.InvalidArgument},
+	}
+
+	namespace := "onepanel"
+	for _, test := range tests {
+		c := NewTestClient(database, test.configMap, mockSystemSecret)
+		clearDatabase(t)
+
+		wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     "test",
+			Manifest: defaultWorkflowTemplate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name: "test",
+		}, wt)
+		if test.errorCode != codes.OK {
+			userErr, ok := err.(*util.UserError)
+			assert.True(t, ok, test.name)
+			if ok {
+				assert.Equal(t, test.errorCode, userErr.Code, test.name)
+			}
+			continue
+		}
+		assert.Nil(t, err, test.name)
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, wt.UID, wf.Labels[workflowTemplateUIDLabelKey], test.name)
+		_, ok := wf.Labels["cost-center"]
+		assert.Equal(t, len(test.expected) > 0, ok, test.name)
+		for key, value := range test.expected {
+			assert.Equal(t, value, wf.Labels[key], test.name)
+		}
+	}
+}
+
+// TestClient_TerminateWorkflowExecution_Reason makes sure the reason a workflow execution is terminated is saved and returned
+func TestClient_TerminateWorkflowExecution_Reason(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	_, we := createRunningWorkflowExecution(t, c, namespace)
+
+	reason := "Training diverged after epoch 3."
+	err := c.TerminateWorkflowExecution(namespace, we.UID, reason)
+	assert.Nil(t, err)
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, reason, wf.Annotations[workflowTerminationReasonAnnotationKey])
+
+	workflow, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, reason, workflow.TerminationReason)
+
+	events, err := c.ListAuditEvents(namespace, we.UID, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, AuditActionTerminateWorkflowExecution, events[0].Action)
+		assert.Equal(t, reason, events[0].Reason)
+	}
+}
+
+// TestClient_TerminateWorkflowExecutions makes sure only the workflow executions in the confirmed phase are terminated
+func TestClient_TerminateWorkflowExecutions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phases := []wfv1.NodePhase{wfv1.NodeRunning, wfv1.NodeRunning, wfv1.NodeSucceeded, wfv1.NodeFailed, ""}
+	uids := make([]string, len(phases))
+	for i, phase := range phases {
+		we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+			Name: fmt.Sprintf("test-%v", i),
+		}, wt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uids[i] = we.UID
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wf.Status.Phase = phase
+		if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = c.TerminateWorkflowExecutions(namespace, "", "", "Maintenance.")
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+	_, err = c.TerminateWorkflowExecutions(namespace, wfv1.NodeRunning, wfv1.NodePending, "Maintenance.")
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+	_, err = c.TerminateWorkflowExecutions(namespace, wfv1.NodeSucceeded, wfv1.NodeSucceeded, "Maintenance.")
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	count, err := c.TerminateWorkflowExecutions(namespace, "", wfv1.NodeRunning, "Maintenance.")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	for i, uid := range uids {
+		phase := ""
+		err = database.Get(&phase, "SELECT phase FROM workflow_executions WHERE uid = $1", uid)
+		assert.Nil(t, err)
+		assert.Equal(t, phases[i] == wfv1.NodeRunning, phase == "Terminated", uid)
+
+		wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(uid, metav1.GetOptions{})
+		assert.Nil(t, err)
+		if phases[i] == wfv1.NodeRunning {
+			assert.Equal(t, "Maintenance.", wf.Annotations[workflowTerminationReasonAnnotationKey], uid)
+		} else {
+			assert.Empty(t, wf.Annotations[workflowTerminationReasonAnnotationKey], uid)
+		}
+	}
+}
+
+// TestClient_renderWorkflow_NamespaceConfig makes sure the onepanel config map of the namespace is loaded once
+// per render, and not again by each step that uses it
+func TestClient_renderWorkflow_NamespaceConfig(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["workflowTTLSecondsAfterFinished"] = "3600"
+	configMap.Data["priorityClasses"] = "- high"
+	configMap.Data["costLabels"] = "team: ml"
+	configMap.Data["defaultResources"] = "requests:\n  cpu: 500m\n"
+	c := NewTestClient(database, configMap, mockSystemSecret)
+
+	namespace := "onepanel"
+
+	// The system config is cached, so it is loaded before counting
+	if _, err := c.GetSystemConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := 0
+	c.Interface.(*fake.Clientset).PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == namespace && action.(k8stesting.GetAction).GetName() == "onepanel" {
+			gets++
+		}
+		return false, nil, nil
+	})
+
+	wf := &wfv1.Workflow{
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			Templates: []wfv1.Template{
+				{
+					Name:      "main",
+					Container: &corev1.Container{Image: "alpine"},
+				},
+			},
+		},
+	}
+	err := c.renderWorkflow(namespace, ptr.Uint64(1), wf, &WorkflowExecutionOptions{Priority: "high"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, gets)
+
+	assert.Equal(t, "high", wf.Spec.PodPriorityClassName)
+	assert.Equal(t, "ml", wf.Labels["team"])
+	if assert.NotNil(t, wf.Spec.TTLStrategy) {
+		assert.Equal(t, ptr.Int32(3600), wf.Spec.TTLStrategy.SecondsAfterCompletion)
+	}
+}
+
+// TestClient_applyDefaultResources makes sure the namespace default resources are only injected
+// into containers that do not declare them
+func TestClient_applyDefaultResources(t *testing.T) {
+	configMap := mockSystemConfigMap.DeepCopy()
+	configMap.Data["defaultResources"] = `
+requests:
+  cpu: 500m
+  memory: 1Gi
+limits:
+  cpu: "1"
+  memory: 2Gi
+`
+	c := NewTestClient(database, configMap, mockSystemSecret)
+
+	wf := &wfv1.Workflow{
+		Spec: wfv1.WorkflowSpec{
+			Templates: []wfv1.Template{
+				{
+					Name: "main",
+					DAG:  &wfv1.DAGTemplate{},
+				},
+				{
+					Name:      "train",
+					Container: &corev1.Container{Image: "alpine"},
+				},
+				{
+					Name: "preprocess",
+					Script: &wfv1.ScriptTemplate{
+						Container: corev1.Container{
+							Image: "alpine",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("250m"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	namespaceConfig, err := c.GetNamespaceConfig("onepanel")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = applyDefaultResources("onepanel", namespaceConfig, wf)
+	assert.Nil(t, err)
+
+	assertResources := func(expected map[string]string, actual corev1.ResourceList) {
+		assert.Len(t, actual, len(expected))
+		for name, value := range expected {
+			quantity, ok := actual[corev1.ResourceName(name)]
+			assert.True(t, ok, name)
+			assert.Equal(t, 0, quantity.Cmp(resource.MustParse(value)), name)
+		}
+	}
+
+	assert.Nil(t, wf.Spec.Templates[0].Container)
+
+	train := wf.Spec.Templates[1].Container.Resources
+	assertResources(map[string]string{"cpu": "500m", "memory": "1Gi"}, train.Requests)
+	assertResources(map[string]string{"cpu": "1", "memory": "2Gi"}, train.Limits)
+
+	// The declared cpu limit is kept and no cpu request above it is added
+	preprocess := wf.Spec.Templates[2].Script.Container.Resources
+	assertResources(map[string]string{"memory": "1Gi"}, preprocess.Requests)
+	assertResources(map[string]string{"cpu": "250m", "memory": "2Gi"}, preprocess.Limits)
+}
+
+// TestClient_GetNamespaceDefaultResources makes sure missing default resources are nil and invalid ones are rejected
+func TestClient_GetNamespaceDefaultResources(t *testing.T) {
+	c := DefaultTestClient()
+	resources, err := c.GetNamespaceDefaultResources("onepanel")
+	assert.Nil(t, err)
+	assert.Nil(t, resources)
+
+	resources, err = c.GetNamespaceDefaultResources("missing")
+	assert.Nil(t, err)
+	assert.Nil(t, resources)
+
+	for _, data := range []string{"requests: [1]", "requests:\n  cpu: -1\n", "requests:\n  cpu: 2\nlimits:\n  cpu: 1\n"} {
+		configMap := mockSystemConfigMap.DeepCopy()
+		configMap.Data["defaultResources"] = data
+		c := NewTestClient(database, configMap, mockSystemSecret)
+
+		_, err := c.GetNamespaceDefaultResources("onepanel")
+		userErr, ok := err.(*util.UserError)
+		assert.True(t, ok, data)
+		if ok {
+			assert.Equal(t, codes.InvalidArgument, userErr.Code, data)
+		}
+	}
+}
+
+// TestClient_GetWorkflowExecutionChildWorkflows makes sure the workflows spawned by a workflow execution are listed,
+// whether they are owned by it or labelled with its name
+func TestClient_GetWorkflowExecutionChildWorkflows(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+	}, wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake clientset does not set the kubernetes uid owner references refer to
+	parent, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.UID = "parent-uid"
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(parent); err != nil {
+		t.Fatal(err)
+	}
+
+	createdAt := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	children := []*wfv1.Workflow{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "owned-child",
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(createdAt.Add(time.Minute)),
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow", Name: we.UID, UID: "parent-uid"},
+				},
+			},
+			Status: wfv1.WorkflowStatus{Phase: wfv1.NodeRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "labelled-child",
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(createdAt),
+				Labels: map[string]string{
+					parentWorkflowLabelKey: we.UID,
+				},
+			},
+			Status: wfv1.WorkflowStatus{Phase: wfv1.NodeSucceeded},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unrelated",
+				Namespace: namespace,
+				Labels: map[string]string{
+					parentWorkflowLabelKey: "other",
+				},
+			},
+		},
+	}
+	for _, child := range children {
+		if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Create(child); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Listing every workflow of the namespace is left to the callers that need the children
+	workflow, err := c.GetWorkflowExecution(namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Empty(t, workflow.ChildWorkflows)
+
+	childWorkflows := c.GetWorkflowExecutionChildWorkflows(namespace, workflow.ArgoWorkflow)
+	if assert.Len(t, childWorkflows, 2) {
+		assert.Equal(t, "labelled-child", childWorkflows[0].UID)
+		assert.Equal(t, wfv1.NodeSucceeded, childWorkflows[0].Phase)
+		assert.Equal(t, "owned-child", childWorkflows[1].UID)
+		assert.Equal(t, wfv1.NodeRunning, childWorkflows[1].Phase)
+	}
+}
+
+// Test_getWorkflowNodes makes sure the nodes of the workflow status are returned sorted by name,
+// and only nodes whose outputs were taken from the memoization cache are marked as memoized
+func Test_getWorkflowNodes(t *testing.T) {
+	wf := &wfv1.Workflow{
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test": {
+					ID:          "test",
+					Name:        "test",
+					DisplayName: "test",
+					Type:        wfv1.NodeTypeDAG,
+					Phase:       wfv1.NodeSucceeded,
+					Children:    []string{"test-1", "test-2"},
+				},
+				"test-1": {
+					ID:          "test-1",
+					Name:        "test.preprocess",
+					DisplayName: "preprocess",
+					Type:        wfv1.NodeTypePod,
+					Phase:       wfv1.NodeSucceeded,
+					MemoizationStatus: &wfv1.MemoizationStatus{
+						Hit:       true,
+						Key:       "dataset-v1",
+						CacheName: "preprocess-cache",
+					},
+				},
+				"test-2": {
+					ID:          "test-2",
+					Name:        "test.train",
+					DisplayName: "train",
+					Type:        wfv1.NodeTypePod,
+					Phase:       wfv1.NodeSucceeded,
+					MemoizationStatus: &wfv1.MemoizationStatus{
+						Hit:       false,
+						Key:       "model-v1",
+						CacheName: "train-cache",
+					},
+				},
+			},
+		},
+	}
+
+	nodes := getWorkflowNodes(wf)
+	if assert.Len(t, nodes, 3) {
+		assert.Equal(t, "test", nodes[0].Name)
+		assert.Equal(t, []string{"test-1", "test-2"}, nodes[0].Children)
+		assert.False(t, nodes[0].Memoized)
+
+		assert.Equal(t, "test.preprocess", nodes[1].Name)
+		assert.Equal(t, wfv1.NodeTypePod, nodes[1].Type)
+		assert.True(t, nodes[1].Memoized)
+
+		assert.Equal(t, "test.train", nodes[2].Name)
+		assert.False(t, nodes[2].Memoized)
+	}
+
+	assert.Empty(t, getWorkflowNodes(nil))
+}
+
+// Test_getWorkflowNodes_ExitCode makes sure the exit code of pod nodes is surfaced, like 137 for a pod that was killed,
+// and nodes without one have none
+func Test_getWorkflowNodes_ExitCode(t *testing.T) {
+	wf := &wfv1.Workflow{
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"test": {
+					ID:       "test",
+					Name:     "test",
+					Type:     wfv1.NodeTypeDAG,
+					Phase:    wfv1.NodeFailed,
+					Children: []string{"test-1", "test-2", "test-3"},
+				},
+				"test-1": {
+					ID:      "test-1",
+					Name:    "test.preprocess",
+					Type:    wfv1.NodeTypePod,
+					Phase:   wfv1.NodeSucceeded,
+					Outputs: &wfv1.Outputs{ExitCode: ptr.String("0")},
+				},
+				"test-2": {
+					ID:      "test-2",
+					Name:    "test.train",
+					Type:    wfv1.NodeTypePod,
+					Phase:   wfv1.NodeFailed,
+					Message: "OOMKilled",
+					Outputs: &wfv1.Outputs{ExitCode: ptr.String("137")},
+				},
+				"test-3": {
+					ID:      "test-3",
+					Name:    "test.upload",
+					Type:    wfv1.NodeTypePod,
+					Phase:   wfv1.NodeFailed,
+					Outputs: &wfv1.Outputs{},
+				},
+			},
+		},
+	}
+
+	nodes := getWorkflowNodes(wf)
+	if assert.Len(t, nodes, 4) {
+		assert.Nil(t, nodes[0].ExitCode)
+
+		assert.Equal(t, "test.preprocess", nodes[1].Name)
+		assert.Equal(t, ptr.Int32(0), nodes[1].ExitCode)
+
+		assert.Equal(t, "test.train", nodes[2].Name)
+		assert.Equal(t, wfv1.NodeFailed, nodes[2].Phase)
+		assert.Equal(t, ptr.Int32(137), nodes[2].ExitCode)
+
+		assert.Equal(t, "test.upload", nodes[3].Name)
+		assert.Nil(t, nodes[3].ExitCode)
+	}
+}
@@ -3,9 +3,14 @@ package v1
 import (
 	"encoding/json"
 	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/pkg/util/ptr"
 	"github.com/onepanelio/core/pkg/util/sql"
 	"github.com/onepanelio/core/pkg/util/types"
 	uid2 "github.com/onepanelio/core/pkg/util/uid"
+	corev1 "k8s.io/api/core/v1"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -26,7 +31,225 @@ type WorkflowExecution struct {
 	WorkflowTemplate *WorkflowTemplate `db:"workflow_template"`
 	Labels           types.JSONLabels
 	Metrics          Metrics
+	Retries          int32   `db:"retries"`    // number of times the execution was retried or resubmitted
+	CreatedBy        string  `db:"created_by"` // identity of the caller that created the execution, see Client.Identity
+	IdempotencyKey   string  // optional, creating an execution with the key of a recent one returns that one instead
+	FinalManifest    *string `db:"final_manifest"` // argo workflow as it was when it finished
+	Archived         bool    // true if the argo workflow no longer exists and it was loaded from FinalManifest
+	Resources        WorkflowResources
 	ArgoWorkflow     *wfv1.Workflow
+	// EstimatedFinishAt is when a running workflow execution is expected to finish, if there is enough history to know.
+	EstimatedFinishAt *time.Time
+	// EstimatedCost is the price of the resources requested by the workflow execution while it ran, or so far if it is running.
+	// Nil if it has not started or the namespace has no pricing.
+	EstimatedCost *float64
+	// UnschedulablePods are the pending pods of a running workflow execution that can not be scheduled
+	UnschedulablePods []*UnschedulablePod
+	// ProvisioningVolumes are the persistent volume claims of a running workflow execution that are not bound to a volume yet
+	ProvisioningVolumes []*ProvisioningVolume
+	// TerminationReason is why the workflow execution was terminated, if a reason was given
+	TerminationReason string
+	// ChildWorkflows are the workflows spawned by the workflow execution. Only loaded by GetWorkflowExecutionChildWorkflows.
+	ChildWorkflows []*ChildWorkflow
+	// Nodes are the nodes of the argo workflow status, like steps and DAG tasks
+	Nodes []*WorkflowNode
+	// Warnings are things the user should know about the workflow execution, like its workflow template version being superseded
+	Warnings []string
+	// TTLSecondsAfterFinished is how long the argo workflow is kept after it finishes. Optional, defaults to the namespace's.
+	TTLSecondsAfterFinished *int32
+	// ActiveDeadlineSeconds is how long the argo workflow can run before it is failed. Optional.
+	ActiveDeadlineSeconds *int64
+	// Priority is the name of the priority class the pods of the argo workflow are scheduled with. Optional.
+	Priority string
+	// NodeSelector is merged into the node selector of the argo workflow. Optional.
+	NodeSelector map[string]string
+	// Tolerations are added to the tolerations of the argo workflow. Optional.
+	Tolerations []corev1.Toleration
+	// FailFast stops the DAGs of the argo workflow from starting new tasks once one of their tasks fails. Optional.
+	FailFast bool
+	// Attempts are the runs of a workflow execution that was retried or resubmitted, oldest first, ending with the current one
+	Attempts []*WorkflowExecutionAttempt
+}
+
+// WorkflowExecutionAttempt is a run of a workflow execution, with its outcome.
+// Runs are recorded when the workflow execution is retried or resubmitted, see Client.RetryWorkflowExecution.
+type WorkflowExecutionAttempt struct {
+	Phase      wfv1.NodePhase
+	StartedAt  *time.Time `db:"started_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+}
+
+// newWorkflowExecutionAttempt returns the attempt the argo workflow is running, or last ran
+func newWorkflowExecutionAttempt(wf *wfv1.Workflow) *WorkflowExecutionAttempt {
+	attempt := &WorkflowExecutionAttempt{
+		Phase: wf.Status.Phase,
+	}
+	if !wf.Status.StartedAt.IsZero() {
+		attempt.StartedAt = ptr.Time(wf.Status.StartedAt.UTC())
+	}
+	if !wf.Status.FinishedAt.IsZero() {
+		attempt.FinishedAt = ptr.Time(wf.Status.FinishedAt.UTC())
+	}
+
+	return attempt
+}
+
+// equal returns true if both attempts have the same outcome and timestamps
+func (a *WorkflowExecutionAttempt) equal(b *WorkflowExecutionAttempt) bool {
+	equalTime := func(x, y *time.Time) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Equal(*y)
+	}
+
+	return a.Phase == b.Phase && equalTime(a.StartedAt, b.StartedAt) && equalTime(a.FinishedAt, b.FinishedAt)
+}
+
+// UnschedulablePod is a pod of a workflow that can not be scheduled, and why, like insufficient memory
+type UnschedulablePod struct {
+	PodName string
+	Reason  string
+	Message string
+}
+
+// ProvisioningVolume is a persistent volume claim of a workflow that is not bound to a volume, like one waiting
+// on its storage class to provision it, and why, from its latest event
+type ProvisioningVolume struct {
+	ClaimName string
+	Phase     corev1.PersistentVolumeClaimPhase
+	Reason    string
+	Message   string
+}
+
+// ChildWorkflow is a workflow spawned by another workflow, found by its owner reference or parentWorkflowLabelKey label.
+// UID is the name of the argo workflow, which is the uid of its workflow execution if it has one.
+type ChildWorkflow struct {
+	UID       string
+	Phase     wfv1.NodePhase
+	CreatedAt time.Time
+}
+
+// WorkflowNode is a node of a workflow execution, like a step or a DAG task
+type WorkflowNode struct {
+	ID          string
+	Name        string
+	DisplayName string
+	Type        wfv1.NodeType
+	Phase       wfv1.NodePhase
+	Children    []string // IDs of the nodes started by the node
+	Memoized    bool     // true if the outputs of the node were taken from the memoization cache, instead of running it
+	ExitCode    *int32   // exit code of the main container of a pod node, nil if it has not exited or is not a pod
+}
+
+// getWorkflowNodes returns the nodes of the status of the workflow, sorted by name
+func getWorkflowNodes(wf *wfv1.Workflow) (nodes []*WorkflowNode) {
+	nodes = make([]*WorkflowNode, 0)
+	if wf == nil {
+		return
+	}
+
+	for _, node := range wf.Status.Nodes {
+		nodes = append(nodes, &WorkflowNode{
+			ID:          node.ID,
+			Name:        node.Name,
+			DisplayName: node.DisplayName,
+			Type:        node.Type,
+			Phase:       node.Phase,
+			Children:    node.Children,
+			Memoized:    node.MemoizationStatus != nil && node.MemoizationStatus.Hit,
+			ExitCode:    workflowNodeExitCode(node.Outputs),
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return
+}
+
+// workflowNodeExitCode parses the exit code argo records in the outputs of a node.
+// Nodes without outputs, or an exit code that is not a number, have none and nil is returned.
+func workflowNodeExitCode(outputs *wfv1.Outputs) *int32 {
+	if outputs == nil || outputs.ExitCode == nil {
+		return nil
+	}
+
+	exitCode, err := strconv.ParseInt(strings.TrimSpace(*outputs.ExitCode), 10, 32)
+	if err != nil {
+		return nil
+	}
+
+	return ptr.Int32(int32(exitCode))
+}
+
+// WorkflowResources are the cpu and memory requests and limits declared by the pods of a workflow, summed.
+// CPU values are in millicores and memory values are in bytes.
+type WorkflowResources struct {
+	CPURequest    int64
+	CPULimit      int64
+	MemoryRequest int64
+	MemoryLimit   int64
+}
+
+// add adds the requests and limits of the container
+func (r *WorkflowResources) add(container *corev1.Container) {
+	r.CPURequest += container.Resources.Requests.Cpu().MilliValue()
+	r.CPULimit += container.Resources.Limits.Cpu().MilliValue()
+	r.MemoryRequest += container.Resources.Requests.Memory().Value()
+	r.MemoryLimit += container.Resources.Limits.Memory().Value()
+}
+
+// ResourcePricing is the price of resources per hour, see Client.GetNamespacePricing
+type ResourcePricing struct {
+	CPUHour      float64 `json:"cpuHour"`      // price of a core for an hour
+	MemoryGBHour float64 `json:"memoryGBHour"` // price of a GB, 10^9 bytes, of memory for an hour
+}
+
+// cost returns the price of the requested resources over the duration
+func (r *WorkflowResources) cost(pricing *ResourcePricing, duration time.Duration) float64 {
+	cores := float64(r.CPURequest) / 1000
+	gigabytes := float64(r.MemoryRequest) / 1e9
+
+	return (cores*pricing.CPUHour + gigabytes*pricing.MemoryGBHour) * duration.Hours()
+}
+
+// getWorkflowResources sums the resources declared by the containers of the templates of the workflow that run a pod,
+// including init containers and sidecars. Each template is counted once.
+func getWorkflowResources(wf *wfv1.Workflow) (resources WorkflowResources) {
+	if wf == nil {
+		return
+	}
+
+	for i := range wf.Spec.Templates {
+		template := &wf.Spec.Templates[i]
+
+		switch {
+		case template.Container != nil:
+			resources.add(template.Container)
+		case template.Script != nil:
+			resources.add(&template.Script.Container)
+		default:
+			continue
+		}
+
+		for j := range template.InitContainers {
+			resources.add(&template.InitContainers[j].Container)
+		}
+		for j := range template.Sidecars {
+			resources.add(&template.Sidecars[j].Container)
+		}
+	}
+
+	return
+}
+
+// WorkflowExecutionResult is the outcome of creating one workflow execution of a batch.
+// Only one of WorkflowExecution and Err is set.
+type WorkflowExecutionResult struct {
+	WorkflowExecution *WorkflowExecution
+	Err               error
 }
 
 // WorkflowExecutionOptions are options you have for an executing workflow
@@ -39,6 +262,19 @@ type WorkflowExecutionOptions struct {
 	Labels         map[string]string
 	ListOptions    *ListOptions
 	PodGCStrategy  *PodGCStrategy
+	IdempotencyKey string
+	// TTLSecondsAfterFinished, if set, is how long the argo workflow is kept after it finishes
+	TTLSecondsAfterFinished *int32
+	// ActiveDeadlineSeconds, if set, is how long the argo workflow can run before it is failed
+	ActiveDeadlineSeconds *int64
+	// Priority, if set, is the name of the priority class the pods of the argo workflow are scheduled with
+	Priority string
+	// NodeSelector, if set, is merged into the node selector of the argo workflow
+	NodeSelector map[string]string
+	// Tolerations, if set, are added to the tolerations of the argo workflow
+	Tolerations []corev1.Toleration
+	// FailFast, if true, makes every DAG of the argo workflow fail fast
+	FailFast bool
 }
 
 // WorkflowExecutionStatistic is a record keeping track of what happened to a workflow execution
@@ -60,6 +296,20 @@ type WorkflowExecutionStatus struct {
 	FinishedAt *time.Time     `db:"finished_at" json:"finishedAt"`
 }
 
+// WorkflowExecutionOutput is a global output parameter of a finished workflow execution
+type WorkflowExecutionOutput struct {
+	Name  string
+	Value string
+}
+
+// WorkflowStepMetrics are the metrics of the pod that ran a step, or node, of a workflow execution
+type WorkflowStepMetrics struct {
+	NodeName    string
+	DisplayName string
+	PodName     string
+	Metrics     []*Metric
+}
+
 // GenerateUID generates a uid from the input name and sets it on the workflow execution
 func (we *WorkflowExecution) GenerateUID(name string) error {
 	result, err := uid2.GenerateUID(name, 63)
@@ -73,6 +323,7 @@ func (we *WorkflowExecution) GenerateUID(name string) error {
 }
 
 // LoadParametersFromBytes loads Parameters from the WorkflowExecution's ParameterBytes field.
+// Parameters taken from a secret have redactedParameterValue as their value, the value of the secret is never loaded.
 func (we *WorkflowExecution) LoadParametersFromBytes() ([]Parameter, error) {
 	loadedParameters := make([]Parameter, 0)
 
@@ -87,6 +338,12 @@ func (we *WorkflowExecution) LoadParametersFromBytes() ([]Parameter, error) {
 		loadedParameters = make([]Parameter, 0)
 	}
 
+	for i := range loadedParameters {
+		if loadedParameters[i].SecretRef != nil {
+			loadedParameters[i].Value = ptr.String(redactedParameterValue)
+		}
+	}
+
 	we.Parameters = loadedParameters
 
 	return we.Parameters, err
@@ -103,6 +360,15 @@ func (we *WorkflowExecution) GetParameterValue(name string) *string {
 	return nil
 }
 
+// GetResourceVersion returns the kubernetes resource version of the argo workflow, or an empty string if it is not loaded
+func (we *WorkflowExecution) GetResourceVersion() string {
+	if we.ArgoWorkflow == nil {
+		return ""
+	}
+
+	return we.ArgoWorkflow.ResourceVersion
+}
+
 // getWorkflowExecutionColumns returns all of the columns for workflowExecution modified by alias, destination.
 // see formatColumnSelect
 func getWorkflowExecutionColumns(aliasAndDestination ...string) []string {
@@ -117,6 +383,8 @@ func getWorkflowExecutionColumns(aliasAndDestination ...string) []string {
 		"finished_at",
 		"labels",
 		"metrics",
+		"retries",
+		"created_by",
 	}
 	return sql.FormatColumnSelect(columns, aliasAndDestination...)
 }
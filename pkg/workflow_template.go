@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/onepanelio/core/pkg/util/request"
 	pagination "github.com/onepanelio/core/pkg/util/request/pagination"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -15,19 +16,59 @@ import (
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	argojson "github.com/argoproj/pkg/json"
 	"github.com/ghodss/yaml"
+	"github.com/lib/pq"
 	"github.com/onepanelio/core/pkg/util"
 	"github.com/onepanelio/core/pkg/util/label"
+	"github.com/onepanelio/core/pkg/util/ptr"
+	"github.com/pmezard/go-difflib/difflib"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// versionLabelRegex matches version labels like v2.1-stable
+var versionLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+
+// validateVersionLabel makes sure the version label is at most 63 characters of alphanumerics, '.', '_' or '-'
+// and is not a number, so it is never confused with a version
+func validateVersionLabel(versionLabel string) error {
+	if len(versionLabel) > 63 || !versionLabelRegex.MatchString(versionLabel) {
+		return util.NewUserError(codes.InvalidArgument, "Version label must be at most 63 alphanumeric characters, '.', '_' or '-', and start and end with an alphanumeric character.")
+	}
+	if _, err := strconv.ParseInt(versionLabel, 10, 64); err == nil {
+		return util.NewUserError(codes.InvalidArgument, "Version label can not be a number.")
+	}
+
+	return nil
+}
+
+// WorkflowTemplateVersionStable is the version to get the version of a workflow template pinned as stable,
+// see SetWorkflowTemplateStableVersion
+const WorkflowTemplateVersionStable int64 = -1
+
+// ParseWorkflowTemplateVersionAlias returns the version to get a workflow template with for the alias,
+// 0 for "latest" and WorkflowTemplateVersionStable for "stable"
+func ParseWorkflowTemplateVersionAlias(alias string) (int64, error) {
+	switch alias {
+	case "latest":
+		return 0, nil
+	case "stable":
+		return WorkflowTemplateVersionStable, nil
+	}
+
+	return 0, util.NewUserError(codes.InvalidArgument, "Workflow template version alias must be latest or stable.")
+}
+
 // WorkflowTemplateFilter represents the available ways we can filter WorkflowTemplates
 type WorkflowTemplateFilter struct {
-	Labels []*Label
+	Labels   []*Label
+	Category string // empty string means any category
 }
 
-// applyLabelSelectQuery returns a query builder that adds where statements to filter by labels in the request,
+// workflowTemplateCategoryMaxLength is the longest category a workflow template can have
+const workflowTemplateCategoryMaxLength = 63
+
+// applyLabelSelectQuery returns a query builder that adds where statements to filter by labels and category in the request,
 // if there are any
 func applyLabelSelectQuery(sb sq.SelectBuilder, request *request.Request) sq.SelectBuilder {
 	if request.Filter != nil {
@@ -40,12 +81,64 @@ func applyLabelSelectQuery(sb sq.SelectBuilder, request *request.Request) sq.Sel
 				sb = sb.Where("wt.labels @> ?", labelsJSON)
 			}
 		}
+		if ok && filter.Category != "" {
+			sb = sb.Where(sq.Eq{"wt.category": filter.Category})
+		}
 	}
 
 	return sb
 }
 
-// createWorkflowTemplateVersionDB inserts a record into workflow_template_versions using the current time accurate to nanoseconds
+// workflowTemplateVersionConflictRetries is how many more times creating a workflow template version is attempted
+// when another version got the same version number
+const workflowTemplateVersionConflictRetries = 3
+
+// isWorkflowTemplateVersionConflict returns true if the error is from inserting a version number the workflow template already has
+func isWorkflowTemplateVersionConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "workflow_template_versions_version_idx"
+}
+
+// lockWorkflowTemplateDB locks the workflow template row until the end of the transaction of runner,
+// so versions of the workflow template are created one at a time.
+func lockWorkflowTemplateDB(runner sq.BaseRunner, workflowTemplateID uint64) error {
+	return sb.Select("id").
+		From("workflow_templates").
+		Where(sq.Eq{
+			"id": workflowTemplateID,
+		}).
+		Suffix("FOR UPDATE").
+		RunWith(runner).
+		QueryRow().
+		Scan(&workflowTemplateID)
+}
+
+// nextWorkflowTemplateVersionDB returns the version number of a new version of the workflow template:
+// the current time accurate to nanoseconds, or one more than the latest version number if that is not after it.
+// This keeps version numbers unique and increasing even if the clock goes back or versions are created within the same nanosecond.
+func nextWorkflowTemplateVersionDB(runner sq.BaseRunner, workflowTemplateID uint64) (version int64, err error) {
+	var maxVersion int64
+	err = sb.Select("COALESCE(MAX(version), 0)").
+		From("workflow_template_versions").
+		Where(sq.Eq{
+			"workflow_template_id": workflowTemplateID,
+		}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&maxVersion)
+	if err != nil {
+		return
+	}
+
+	version = time.Now().UnixNano()
+	if version <= maxVersion {
+		version = maxVersion + 1
+	}
+
+	return
+}
+
+// createWorkflowTemplateVersionDB inserts a record into workflow_template_versions, see nextWorkflowTemplateVersionDB for the version number.
 // the data is returned in the resulting WorkflowTemplateVersion struct.
 func createWorkflowTemplateVersionDB(runner sq.BaseRunner, workflowTemplateVersion *WorkflowTemplateVersion, parameters []Parameter) (err error) {
 	if workflowTemplateVersion == nil {
@@ -58,7 +151,10 @@ func createWorkflowTemplateVersionDB(runner sq.BaseRunner, workflowTemplateVersi
 		return fmt.Errorf("workflowTemplateVersion.WorkflowTemplate.ID must be > 0. %v given", workflowTemplateVersion.WorkflowTemplate.ID)
 	}
 
-	workflowTemplateVersion.Version = time.Now().UnixNano()
+	workflowTemplateVersion.Version, err = nextWorkflowTemplateVersionDB(runner, workflowTemplateVersion.WorkflowTemplate.ID)
+	if err != nil {
+		return
+	}
 
 	pj, err := json.Marshal(parameters)
 	if err != nil {
@@ -77,6 +173,7 @@ func createWorkflowTemplateVersionDB(runner sq.BaseRunner, workflowTemplateVersi
 			"manifest":             workflowTemplateVersion.Manifest,
 			"parameters":           pj,
 			"labels":               workflowTemplateVersion.Labels,
+			"version_label":        workflowTemplateVersion.VersionLabel,
 		}).
 		Suffix("RETURNING id").
 		RunWith(runner).
@@ -116,6 +213,10 @@ func createLatestWorkflowTemplateVersionDB(runner sq.BaseRunner, workflowTemplat
 		return fmt.Errorf("workflowTemplateVersion.WorkflowTemplate.ID must be > 0. %v given", workflowTemplateVersion.WorkflowTemplate.ID)
 	}
 
+	if err := lockWorkflowTemplateDB(runner, workflowTemplateVersion.WorkflowTemplate.ID); err != nil {
+		return err
+	}
+
 	_, err = sb.Update("workflow_template_versions").
 		Set("is_latest", false).
 		Where(sq.Eq{
@@ -149,11 +250,14 @@ func (c *Client) createWorkflowTemplate(namespace string, workflowTemplate *Work
 
 	err = sb.Insert("workflow_templates").
 		SetMap(sq.Eq{
-			"uid":       workflowTemplate.UID,
-			"name":      workflowTemplate.Name,
-			"namespace": namespace,
-			"is_system": workflowTemplate.IsSystem,
-			"labels":    workflowTemplate.Labels,
+			"uid":        workflowTemplate.UID,
+			"name":       workflowTemplate.Name,
+			"namespace":  namespace,
+			"is_system":  workflowTemplate.IsSystem,
+			"labels":     workflowTemplate.Labels,
+			"created_by": c.Identity,
+			"updated_by": c.Identity,
+			"category":   workflowTemplate.Category,
 		}).
 		Suffix("RETURNING id").
 		RunWith(tx).
@@ -205,6 +309,8 @@ func (c *Client) createWorkflowTemplate(namespace string, workflowTemplate *Work
 
 	workflowTemplate.ArgoWorkflowTemplate = argoWft
 	workflowTemplate.Version = workflowTemplateVersion.Version
+	workflowTemplate.CreatedBy = c.Identity
+	workflowTemplate.UpdatedBy = c.Identity
 
 	return workflowTemplate, workflowTemplateVersion, nil
 }
@@ -340,7 +446,7 @@ func (c *Client) getWorkflowTemplate(namespace, uid string, version int64) (work
 	// A new workflow template version is created upon a change, so we use it's created_at
 	// as a modified_at for the workflow template.
 	sb := c.workflowTemplatesSelectBuilder(namespace).
-		Columns("wtv.manifest", "wtv.version", "wtv.id workflow_template_version_id", "wtv.created_at modified_at").
+		Columns("wtv.manifest", "wtv.version", "wtv.version_label", "wtv.id workflow_template_version_id", "wtv.created_at modified_at").
 		Join("workflow_template_versions wtv ON wt.id = wtv.workflow_template_id").
 		Where(sq.Eq{
 			"wt.uid":         uid,
@@ -389,23 +495,24 @@ func (c *Client) getWorkflowTemplate(namespace, uid string, version int64) (work
 }
 
 // listWorkflowTemplateVersions grabs WorkflowTemplateVersions and returns them as WorkflowTemplates.
-func (c *Client) listWorkflowTemplateVersions(namespace, uid string) (workflowTemplateVersions []*WorkflowTemplate, err error) {
-	dbVersions, err := c.selectWorkflowTemplateVersionsDB(namespace, uid)
+func (c *Client) listWorkflowTemplateVersions(namespace, uid string, paginator *pagination.PaginationRequest) (workflowTemplateVersions []*WorkflowTemplate, err error) {
+	dbVersions, err := c.selectWorkflowTemplateVersionsDB(namespace, uid, paginator)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, version := range dbVersions {
 		newItem := WorkflowTemplate{
-			ID:         version.WorkflowTemplate.ID,
-			CreatedAt:  version.CreatedAt.UTC(),
-			UID:        version.UID,
-			Name:       version.WorkflowTemplate.Name,
-			Manifest:   version.Manifest,
-			Version:    version.Version,
-			IsLatest:   version.IsLatest,
-			IsArchived: version.WorkflowTemplate.IsArchived,
-			Labels:     version.Labels,
+			ID:           version.WorkflowTemplate.ID,
+			CreatedAt:    version.CreatedAt.UTC(),
+			UID:          version.UID,
+			Name:         version.WorkflowTemplate.Name,
+			Manifest:     version.Manifest,
+			Version:      version.Version,
+			VersionLabel: version.VersionLabel,
+			IsLatest:     version.IsLatest,
+			IsArchived:   version.WorkflowTemplate.IsArchived,
+			Labels:       version.Labels,
 		}
 
 		workflowTemplateVersions = append(workflowTemplateVersions, &newItem)
@@ -492,12 +599,69 @@ func (c *Client) validateWorkflowTemplate(namespace string, workflowTemplate *Wo
 	return
 }
 
+// getWorkflowTemplateWarnings returns a warning for every deprecated field the workflow template uses.
+// Unlike validation errors, warnings do not stop the workflow template from being created.
+func getWorkflowTemplateWarnings(workflowTemplate *WorkflowTemplate) (warnings []string) {
+	workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+	if err != nil {
+		return
+	}
+
+	for _, workflow := range workflows {
+		if workflow.Spec.TTLSecondsAfterFinished != nil {
+			warnings = append(warnings, "ttlSecondsAfterFinished is deprecated, use ttlStrategy.secondsAfterCompletion instead")
+		}
+		for _, template := range workflow.Spec.Templates {
+			if template.Template != "" || template.TemplateRef != nil {
+				warnings = append(warnings, fmt.Sprintf("template %q uses template or templateRef, which are deprecated and ignored", template.Name))
+			}
+		}
+	}
+
+	return
+}
+
+// ValidateWorkflowTemplate validates the workflow template manifest the same way CreateWorkflowTemplate does,
+// without creating anything. A valid manifest has no validation errors.
+func (c *Client) ValidateWorkflowTemplate(namespace, manifest string) (validationErrors []*WorkflowTemplateValidationError) {
+	workflowTemplate := &WorkflowTemplate{
+		Manifest: manifest,
+	}
+
+	// Not logged like in validateWorkflowTemplate, invalid manifests are expected while they are being edited
+	var finalBytes []byte
+	normalized, err := NormalizeWorkflowTemplateManifest(manifest)
+	if err == nil {
+		workflowTemplate.Manifest = normalized
+		finalBytes, err = workflowTemplate.WrapSpec()
+	}
+	if err == nil {
+		err = c.ValidateWorkflowExecution(namespace, finalBytes)
+	}
+	if err != nil {
+		validationErrors = append(validationErrors, newWorkflowTemplateValidationError(err))
+	}
+
+	return
+}
+
 func (c *Client) CreateWorkflowTemplate(namespace string, workflowTemplate *WorkflowTemplate) (*WorkflowTemplate, error) {
 	// validate workflow template
 	if err := c.validateWorkflowTemplate(namespace, workflowTemplate); err != nil {
 		return nil, util.NewUserError(codes.InvalidArgument, err.Error())
 	}
 
+	if err := c.lintWorkflowTemplate(namespace, workflowTemplate); err != nil {
+		return nil, err
+	}
+
+	warnings := getWorkflowTemplateWarnings(workflowTemplate)
+
+	workflowTemplate.Category = strings.TrimSpace(workflowTemplate.Category)
+	if len(workflowTemplate.Category) > workflowTemplateCategoryMaxLength {
+		return nil, util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Category must be %v characters or less.", workflowTemplateCategoryMaxLength))
+	}
+
 	newWorkflowTemplate, _, err := c.createWorkflowTemplate(namespace, workflowTemplate)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -507,6 +671,7 @@ func (c *Client) CreateWorkflowTemplate(namespace string, workflowTemplate *Work
 		}).Error("Could not create workflow template.")
 		return nil, util.NewUserErrorWrap(err, "Workflow template")
 	}
+	newWorkflowTemplate.Warnings = warnings
 
 	return newWorkflowTemplate, nil
 }
@@ -526,6 +691,42 @@ func (c *Client) CreateWorkflowTemplateVersion(namespace string, workflowTemplat
 		return nil, util.NewUserError(codes.InvalidArgument, err.Error())
 	}
 
+	if err := c.lintWorkflowTemplate(namespace, workflowTemplate); err != nil {
+		return nil, err
+	}
+
+	if workflowTemplate.VersionLabel != nil {
+		if err := validateVersionLabel(*workflowTemplate.VersionLabel); err != nil {
+			return nil, err
+		}
+
+		existing, err := c.getWorkflowTemplateVersionByLabel(namespace, workflowTemplate.UID, *workflowTemplate.VersionLabel)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, util.NewUserError(codes.AlreadyExists, "Version label is already used by another version.")
+		}
+	}
+
+	// Versions are created one at a time, so a conflict is unexpected but not a reason to fail
+	for attempt := 0; ; attempt++ {
+		newWorkflowTemplate, err := c.createWorkflowTemplateVersion(namespace, workflowTemplate)
+		if isWorkflowTemplateVersionConflict(err) && attempt < workflowTemplateVersionConflictRetries {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       workflowTemplate.UID,
+				"Attempt":   attempt,
+			}).Warn("Workflow template version number conflict, retrying.")
+			continue
+		}
+
+		return newWorkflowTemplate, err
+	}
+}
+
+// createWorkflowTemplateVersion creates the new workflow template version in the database and argo, see CreateWorkflowTemplateVersion
+func (c *Client) createWorkflowTemplateVersion(namespace string, workflowTemplate *WorkflowTemplate) (*WorkflowTemplate, error) {
 	tx, err := c.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -546,6 +747,7 @@ func (c *Client) CreateWorkflowTemplateVersion(namespace string, workflowTemplat
 		WorkflowTemplate: workflowTemplateDB,
 		Manifest:         workflowTemplate.Manifest,
 		Labels:           workflowTemplate.Labels,
+		VersionLabel:     workflowTemplate.VersionLabel,
 	}
 
 	err = createLatestWorkflowTemplateVersionDB(tx, workflowTemplateVersion)
@@ -597,9 +799,10 @@ func (c *Client) CreateWorkflowTemplateVersion(namespace string, workflowTemplat
 		return nil, err
 	}
 
-	// Make sure the associated workflow template has the latest labels
+	// Make sure the associated workflow template has the latest labels, and who changed it last
 	_, err = sb.Update("workflow_templates").
 		Set("labels", workflowTemplate.Labels).
+		Set("updated_by", c.Identity).
 		Where(sq.Eq{
 			"id": workflowTemplateDB.ID,
 		}).
@@ -615,10 +818,67 @@ func (c *Client) CreateWorkflowTemplateVersion(namespace string, workflowTemplat
 
 	workflowTemplate.ID = workflowTemplateDB.ID
 	workflowTemplate.Version = workflowTemplateVersion.Version
+	workflowTemplate.CreatedBy = workflowTemplateDB.CreatedBy
+	workflowTemplate.UpdatedBy = c.Identity
+	workflowTemplate.Category = workflowTemplateDB.Category
 
 	return workflowTemplate, nil
 }
 
+// isWorkflowTemplateChanged returns true if the workflow template is different from latest,
+// other than cosmetically. Manifests are compared in their normalized form, see NormalizeWorkflowTemplateManifest.
+// A workflow template with a version label is always a change, as the label belongs to a new version.
+func isWorkflowTemplateChanged(latest, workflowTemplate *WorkflowTemplate) (changed bool, err error) {
+	if workflowTemplate.VersionLabel != nil {
+		return true, nil
+	}
+
+	if len(latest.Labels) != len(workflowTemplate.Labels) {
+		return true, nil
+	}
+	for key, value := range workflowTemplate.Labels {
+		if latestValue, ok := latest.Labels[key]; !ok || latestValue != value {
+			return true, nil
+		}
+	}
+
+	latestManifest, err := NormalizeWorkflowTemplateManifest(latest.Manifest)
+	if err != nil {
+		return false, err
+	}
+	manifest, err := NormalizeWorkflowTemplateManifest(workflowTemplate.Manifest)
+	if err != nil {
+		return false, err
+	}
+
+	return latestManifest != manifest, nil
+}
+
+// CreateWorkflowTemplateVersionIfChanged creates a new workflow template version, like CreateWorkflowTemplateVersion,
+// unless the workflow template only differs cosmetically from the latest version.
+// In that case no version is created and the latest version is returned.
+func (c *Client) CreateWorkflowTemplateVersionIfChanged(namespace string, workflowTemplate *WorkflowTemplate) (*WorkflowTemplate, error) {
+	if workflowTemplate.UID == "" {
+		return nil, fmt.Errorf("uid required for CreateWorkflowTemplateVersionIfChanged")
+	}
+
+	latest, err := c.getWorkflowTemplate(namespace, workflowTemplate.UID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow template not found.")
+	}
+
+	// An invalid manifest is a change, CreateWorkflowTemplateVersion reports why it is invalid
+	changed, err := isWorkflowTemplateChanged(latest, workflowTemplate)
+	if err != nil || changed {
+		return c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+	}
+
+	return latest, nil
+}
+
 // UpdateWorkflowTemplateVersion will update a given WorkflowTemplateVersion in the database.
 // The intent is to change specific database values for a WorkflowTemplateVersion.
 // - wtv.ID has to be set and greater than 0
@@ -631,25 +891,227 @@ func (c *Client) UpdateWorkflowTemplateVersion(wtv *WorkflowTemplateVersion) err
 
 // GetWorkflowTemplate returns a WorkflowTemplate with data loaded from various sources
 // If version is 0, it returns the latest version data.
+// If version is WorkflowTemplateVersionStable, it returns the data of the version pinned as stable.
 //
 // Data loaded includes
 // * Database Information
 // * ArgoWorkflowTemplate
 // * Labels
+//
+// Manifests using deprecated fields are returned converted to the current schema, see migrateWorkflowTemplateManifest.
+// The stored manifest is not changed.
+//
+// A missing workflow template is a codes.NotFound error, any other failure is a codes.Internal error.
 func (c *Client) GetWorkflowTemplate(namespace, uid string, version int64) (workflowTemplate *WorkflowTemplate, err error) {
+	if version == WorkflowTemplateVersionStable {
+		version, err = c.getWorkflowTemplateStableVersion(namespace, uid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	workflowTemplate, err = c.getWorkflowTemplate(namespace, uid, version)
 	if err != nil {
 		log.WithFields(log.Fields{
-			"Namespace":        namespace,
-			"WorkflowTemplate": workflowTemplate,
-			"Error":            err.Error(),
+			"Namespace": namespace,
+			"UID":       uid,
+			"Version":   version,
+			"Error":     err.Error(),
 		}).Error("Get Workflow Template failed.")
-		return nil, util.NewUserError(codes.Unknown, "Unknown error.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow template.")
 	}
 	if workflowTemplate == nil {
+		// A workflow template can be left without versions if creating its first version failed
+		hasNoVersions, err := c.workflowTemplateHasNoVersions(namespace, uid)
+		if err == nil && hasNoVersions {
+			return nil, util.NewUserError(codes.FailedPrecondition, "Workflow template has no versions.")
+		}
+
 		return nil, util.NewUserError(codes.NotFound, "Workflow template not found.")
 	}
 
+	// Older versions may use deprecated fields, they are converted on read so clients only see the current schema
+	workflowTemplate.Manifest, err = migrateWorkflowTemplateManifest(workflowTemplate.Manifest)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Version":   version,
+			"Error":     err.Error(),
+		}).Error("Migrating workflow template manifest failed.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow template.")
+	}
+
+	return
+}
+
+// getWorkflowTemplateStableVersion returns the version of the non-archived workflow template pinned as stable.
+// It is a codes.FailedPrecondition error if no version is pinned.
+func (c *Client) getWorkflowTemplateStableVersion(namespace, uid string) (int64, error) {
+	stableVersion := sql.NullInt64{}
+	err := sb.Select("stable_version").
+		From("workflow_templates").
+		Where(sq.Eq{
+			"namespace":   namespace,
+			"uid":         uid,
+			"is_archived": false,
+		}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&stableVersion)
+	if err == sql.ErrNoRows {
+		return 0, util.NewUserError(codes.NotFound, "Workflow template not found.")
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Get Workflow Template stable version failed.")
+		return 0, util.NewUserError(codes.Internal, "Unable to get workflow template.")
+	}
+	if !stableVersion.Valid {
+		return 0, util.NewUserError(codes.FailedPrecondition, "Workflow template has no stable version.")
+	}
+
+	return stableVersion.Int64, nil
+}
+
+// workflowTemplateHasNoVersions returns true if the non-archived workflow template exists, but has no versions
+func (c *Client) workflowTemplateHasNoVersions(namespace, uid string) (bool, error) {
+	templates := 0
+	err := c.countWorkflowTemplateSelectBuilder(namespace).
+		Where(sq.Eq{
+			"wt.uid":         uid,
+			"wt.is_archived": false,
+		}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&templates)
+	if err != nil || templates == 0 {
+		return false, err
+	}
+
+	versions, err := c.CountWorkflowTemplateVersions(namespace, uid)
+	if err != nil {
+		return false, err
+	}
+
+	return versions == 0, nil
+}
+
+// ResolveWorkflowTemplatePlaceholders returns the manifest with the system placeholders replaced by their values in the namespace.
+// {{workflow.namespace}} -> namespace
+// {{.ArtifactRepositoryType}} -> s3 or gcs, if the namespace has an artifact repository
+// Other placeholders, like the workflow name or parameters, are left untouched as they are only known when the workflow runs.
+func (c *Client) ResolveWorkflowTemplatePlaceholders(namespace, manifest string) string {
+	replacements := []string{"{{workflow.namespace}}", namespace}
+
+	config, err := c.GetNamespaceConfig(namespace)
+	if err == nil {
+		artifactRepositoryType := "s3"
+		if config.ArtifactRepository.GCS != nil {
+			artifactRepositoryType = "gcs"
+		}
+		replacements = append(replacements, "{{.ArtifactRepositoryType}}", artifactRepositoryType)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(manifest)
+}
+
+// GetWorkflowTemplateByVersionLabel returns a WorkflowTemplate like GetWorkflowTemplate,
+// for the version that was created with versionLabel.
+func (c *Client) GetWorkflowTemplateByVersionLabel(namespace, uid, versionLabel string) (workflowTemplate *WorkflowTemplate, err error) {
+	wtv, err := c.getWorkflowTemplateVersionByLabel(namespace, uid, versionLabel)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace":    namespace,
+			"UID":          uid,
+			"VersionLabel": versionLabel,
+			"Error":        err.Error(),
+		}).Error("Get Workflow Template version failed.")
+		return nil, util.NewUserError(codes.Internal, "Unable to get workflow template version.")
+	}
+	if wtv == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workflow template version not found.")
+	}
+
+	return c.GetWorkflowTemplate(namespace, uid, wtv.Version)
+}
+
+// getWorkflowTemplateVersionByLabel returns the version of the non-archived workflow template with the version label.
+// If there is none, (nil, nil) is returned.
+func (c *Client) getWorkflowTemplateVersionByLabel(namespace, uid, versionLabel string) (workflowTemplateVersion *WorkflowTemplateVersion, err error) {
+	workflowTemplateVersion = &WorkflowTemplateVersion{}
+
+	query := c.workflowTemplatesVersionSelectBuilder(namespace).
+		Where(sq.Eq{
+			"wt.uid":            uid,
+			"wt.is_archived":    false,
+			"wtv.version_label": versionLabel,
+		})
+
+	if err = c.DB.Getx(workflowTemplateVersion, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return
+}
+
+// DiffWorkflowTemplateVersions returns a unified diff of the manifests of two versions of a non-archived workflow template,
+// from fromVersion to toVersion. The diff is empty if the manifests are the same.
+func (c *Client) DiffWorkflowTemplateVersions(namespace, uid string, fromVersion, toVersion int64) (diff string, err error) {
+	versions := make(map[int64]*WorkflowTemplateVersion)
+	for _, version := range []int64{fromVersion, toVersion} {
+		wtv, err := c.getWorkflowTemplateVersionByNumber(namespace, uid, version)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Version":   version,
+				"Error":     err.Error(),
+			}).Error("Get Workflow Template version failed.")
+			return "", util.NewUserError(codes.Internal, "Unable to get workflow template version.")
+		}
+		if wtv == nil {
+			return "", util.NewUserError(codes.NotFound, fmt.Sprintf("Workflow template version %v not found.", version))
+		}
+		versions[version] = wtv
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(versions[fromVersion].Manifest),
+		B:        difflib.SplitLines(versions[toVersion].Manifest),
+		FromFile: fmt.Sprintf("version %v", fromVersion),
+		ToFile:   fmt.Sprintf("version %v", toVersion),
+		Context:  3,
+	})
+}
+
+// getWorkflowTemplateVersionByNumber returns the version of the non-archived workflow template.
+// If there is none, (nil, nil) is returned.
+func (c *Client) getWorkflowTemplateVersionByNumber(namespace, uid string, version int64) (workflowTemplateVersion *WorkflowTemplateVersion, err error) {
+	workflowTemplateVersion = &WorkflowTemplateVersion{}
+
+	query := c.workflowTemplatesVersionSelectBuilder(namespace).
+		Where(sq.Eq{
+			"wt.uid":         uid,
+			"wt.is_archived": false,
+			"wtv.version":    version,
+		})
+
+	if err = c.DB.Getx(workflowTemplateVersion, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
 	return
 }
 
@@ -692,9 +1154,10 @@ func (c *Client) CountWorkflowTemplateVersions(namespace, uid string) (count uin
 	return
 }
 
-// ListWorkflowTemplateVersions returns all the WorkflowTemplates for a given namespace and uid.
-func (c *Client) ListWorkflowTemplateVersions(namespace, uid string) (workflowTemplateVersions []*WorkflowTemplate, err error) {
-	workflowTemplateVersions, err = c.listWorkflowTemplateVersions(namespace, uid)
+// ListWorkflowTemplateVersions returns the versions of the WorkflowTemplate for a given namespace and uid, newest version first.
+// If paginator is nil, all of the versions are returned.
+func (c *Client) ListWorkflowTemplateVersions(namespace, uid string, paginator *pagination.PaginationRequest) (workflowTemplateVersions []*WorkflowTemplate, err error) {
+	workflowTemplateVersions, err = c.listWorkflowTemplateVersions(namespace, uid, paginator)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
@@ -707,12 +1170,39 @@ func (c *Client) ListWorkflowTemplateVersions(namespace, uid string) (workflowTe
 	return
 }
 
+// ListWorkflowTemplateVersionSummaries returns the version, created at and is latest fields of every version
+// of the workflow template, newest version first.
+func (c *Client) ListWorkflowTemplateVersionSummaries(namespace, uid string) (workflowTemplateVersions []*WorkflowTemplateVersion, err error) {
+	workflowTemplateVersions = make([]*WorkflowTemplateVersion, 0)
+
+	query := sb.Select("wtv.version", "wtv.is_latest", "wtv.created_at").
+		From("workflow_template_versions wtv").
+		Join("workflow_templates wt ON wt.id = wtv.workflow_template_id").
+		Where(sq.Eq{
+			"wt.namespace":   namespace,
+			"wt.uid":         uid,
+			"wt.is_archived": false,
+		}).
+		OrderBy("wtv.version DESC")
+
+	if err = c.DB.Selectx(&workflowTemplateVersions, query); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Workflow template versions not found.")
+		return nil, util.NewUserError(codes.NotFound, "Workflow template versions not found.")
+	}
+
+	return
+}
+
 // ListWorkflowTemplateVersionsModels returns all the WorkflowTemplateVersions from the database.
 // This function is a work-around for ListWorkflowTemplateVersions. Once that function is refactored,
 // this function should no longer be necessary and removed.
 // See: https://github.com/onepanelio/core/issues/436
 func (c *Client) ListWorkflowTemplateVersionsModels(namespace, uid string) (workflowTemplateVersions []*WorkflowTemplateVersion, err error) {
-	workflowTemplateVersions, err = c.selectWorkflowTemplateVersionsDB(namespace, uid)
+	workflowTemplateVersions, err = c.selectWorkflowTemplateVersionsDB(namespace, uid, nil)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
@@ -797,7 +1287,63 @@ func (c *Client) getLatestWorkflowTemplate(namespace, uid string) (*WorkflowTemp
 	return c.getWorkflowTemplate(namespace, uid, 0) //version=0 means latest
 }
 
-func (c *Client) ArchiveWorkflowTemplate(namespace, uid string) (archived bool, err error) {
+// terminateRunningWorkflowExecutions terminates the workflow executions, created from the workflow template with the uid,
+// that have not finished yet. Workflows are matched by the workflow template uid label.
+func (c *Client) terminateRunningWorkflowExecutions(namespace, uid string) error {
+	var workflows *v1alpha1.WorkflowList
+	err := c.runKubeCall("ListWorkflows", func() (err error) {
+		workflows, err = c.ArgoprojV1alpha1().Workflows(namespace).List(v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%v=%v", workflowTemplateUIDLabelKey, uid),
+		})
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, wf := range workflows.Items {
+		if wf.Status.Phase != "" && wf.Status.Phase != v1alpha1.NodePending && wf.Status.Phase != v1alpha1.NodeRunning {
+			continue
+		}
+
+		if err := c.TerminateWorkflowExecution(namespace, wf.Name, "Workflow template was archived."); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountWorkflowTemplateUsage returns the number of argo workflows that were created from the workflow template.
+// If version is 0, workflows of all versions are counted, otherwise only those of the version.
+func (c *Client) CountWorkflowTemplateUsage(namespace, uid string, version int64) (count int, err error) {
+	labelSelector := fmt.Sprintf("%v=%v", workflowTemplateUIDLabelKey, uid)
+	if version != 0 {
+		labelSelector += fmt.Sprintf(",%v=%v", workflowTemplateVersionLabelKey, version)
+	}
+
+	var workflows *v1alpha1.WorkflowList
+	err = c.runKubeCall("ListWorkflows", func() (err error) {
+		workflows, err = c.ArgoprojV1alpha1().Workflows(namespace).List(v1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		return
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to count workflow template usage.")
+		return 0, err
+	}
+
+	return len(workflows.Items), nil
+}
+
+// ArchiveWorkflowTemplate archives the workflow template, along with its cron workflows and workflow executions.
+// If terminateRunning is true, workflow executions of the template that are still running are terminated first.
+func (c *Client) ArchiveWorkflowTemplate(namespace, uid string, terminateRunning bool) (archived bool, err error) {
 	workflowTemplate, err := c.getLatestWorkflowTemplate(namespace, uid)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -811,7 +1357,18 @@ func (c *Client) ArchiveWorkflowTemplate(namespace, uid string) (archived bool,
 		return false, util.NewUserError(codes.NotFound, "Workflow template not found.")
 	}
 
-	wftVersions, err := c.listWorkflowTemplateVersions(namespace, uid)
+	if terminateRunning {
+		if err := c.terminateRunningWorkflowExecutions(namespace, uid); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Terminate running Workflow Executions failed.")
+			return false, util.NewUserError(codes.Unknown, "Unable to archive workflow template.")
+		}
+	}
+
+	wftVersions, err := c.listWorkflowTemplateVersions(namespace, uid, nil)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
@@ -909,6 +1466,288 @@ func (c *Client) ArchiveWorkflowTemplate(namespace, uid string) (archived bool,
 	return true, nil
 }
 
+// DeleteWorkflowTemplate permanently deletes the workflow template, archived or not, along with its versions,
+// cron workflows and the database records of its workflow executions.
+// If the template has any workflow executions, finished or still in the cluster, codes.FailedPrecondition is returned,
+// unless force is true, in which case the workflow executions and their workflows are deleted too.
+// The database records are deleted before the resources in the cluster. If deleting the resources fails,
+// the template is still deleted and codes.Unknown is returned.
+// Templates of workspace templates can not be deleted.
+func (c *Client) DeleteWorkflowTemplate(namespace, uid string, force bool) error {
+	workflowTemplates := make([]*WorkflowTemplate, 0)
+	query := sb.Select("id", "is_system").
+		From("workflow_templates").
+		Where(sq.Eq{
+			"namespace": namespace,
+			"uid":       uid,
+		})
+	if err := c.DB.Selectx(&workflowTemplates, query); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Get Workflow Template failed.")
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+	if len(workflowTemplates) == 0 {
+		return util.NewUserError(codes.NotFound, "Workflow template not found.")
+	}
+
+	ids := make([]uint64, 0)
+	for _, workflowTemplate := range workflowTemplates {
+		if workflowTemplate.IsSystem {
+			return util.NewUserError(codes.FailedPrecondition, "Workflow templates of workspace templates can not be deleted.")
+		}
+		ids = append(ids, workflowTemplate.ID)
+	}
+
+	versionIDs := make([]uint64, 0)
+	query = sb.Select("id").
+		From("workflow_template_versions").
+		Where(sq.Eq{"workflow_template_id": ids})
+	if err := c.DB.Selectx(&versionIDs, query); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Get Workflow Template Versions failed.")
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+
+	// Finished workflow executions are kept in the database after their workflows are gone from the cluster,
+	// so both are counted to not delete the history of the template without force
+	usageCount, err := c.CountWorkflowTemplateUsage(namespace, uid, 0)
+	if err != nil {
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+	executionCount := 0
+	query = sb.Select("COUNT(*)").
+		From("workflow_executions").
+		Where(sq.Eq{"workflow_template_version_id": versionIDs})
+	if err := c.DB.Getx(&executionCount, query); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Count Workflow Executions failed.")
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+	if (usageCount > 0 || executionCount > 0) && !force {
+		return util.NewUserError(codes.FailedPrecondition, fmt.Sprintf("Workflow template has %v workflow executions and %v workflows in the cluster. Delete them first, or force the deletion.", executionCount, usageCount))
+	}
+
+	cronWorkflowNames := make([]string, 0)
+	query = sb.Select("name").
+		From("cron_workflows").
+		Where(sq.Eq{
+			"workflow_template_version_id": versionIDs,
+			"is_archived":                  false,
+		})
+	if err := c.DB.Selectx(&cronWorkflowNames, query); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Get Cron Workflows failed.")
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+
+	// The database is changed first, so if it fails the cluster is left as it is.
+	// Workflow executions and cron workflows reference the versions, so they are deleted first.
+	// The versions are deleted along with the workflow templates.
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deletes := []sq.DeleteBuilder{
+		sb.Delete("workflow_executions").Where(sq.Eq{"workflow_template_version_id": versionIDs}),
+		sb.Delete("cron_workflows").Where(sq.Eq{"workflow_template_version_id": versionIDs}),
+		sb.Delete("workflow_templates").Where(sq.Eq{"id": ids}),
+	}
+	for _, query := range deletes {
+		if _, err := query.RunWith(tx).Exec(); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Delete Workflow Template DB failed.")
+			return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Delete Workflow Template DB failed.")
+		return util.NewUserError(codes.Unknown, "Unable to delete workflow template.")
+	}
+
+	// Every resource in the cluster is attempted, even if deleting one fails, and ones that are already gone are skipped
+	cleanedUp := true
+	if usageCount > 0 {
+		if err := c.deleteWorkflowTemplateWorkflows(namespace, uid); err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Error":     err.Error(),
+			}).Error("Delete Workflows failed.")
+			cleanedUp = false
+		}
+	}
+
+	for _, name := range cronWorkflowNames {
+		err := c.ArgoprojV1alpha1().CronWorkflows(namespace).Delete(name, nil)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			log.WithFields(log.Fields{
+				"Namespace": namespace,
+				"UID":       uid,
+				"Name":      name,
+				"Error":     err.Error(),
+			}).Error("Delete Cron Workflow failed.")
+			cleanedUp = false
+		}
+	}
+
+	if err := c.deleteArgoWorkflowTemplates(namespace, uid); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Delete Argo Workflow Templates failed.")
+		cleanedUp = false
+	}
+
+	if !cleanedUp {
+		return util.NewUserError(codes.Unknown, "Workflow template was deleted, but not all of its resources in the cluster.")
+	}
+
+	return nil
+}
+
+// TransferWorkflowTemplateOwnership makes owner the owner, CreatedBy, of the workflow template.
+// The versions of the workflow template, and who created them, are kept as they are.
+func (c *Client) TransferWorkflowTemplateOwnership(namespace, uid, owner string) (*WorkflowTemplate, error) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return nil, util.NewUserError(codes.InvalidArgument, "Owner is required.")
+	}
+
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, uid, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sb.Update("workflow_templates").
+		Set("created_by", owner).
+		Where(sq.Eq{"id": workflowTemplate.ID}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Owner":     owner,
+			"Error":     err.Error(),
+		}).Error("Transfer Workflow Template Ownership DB failed.")
+		return nil, util.NewUserError(codes.Unknown, "Unable to transfer workflow template ownership.")
+	}
+
+	previousOwner := workflowTemplate.CreatedBy
+	c.createAuditEvent(namespace, AuditActionTransferWorkflowTemplateOwnership, uid, []Parameter{
+		{Name: "previousOwner", Value: &previousOwner},
+		{Name: "owner", Value: &owner},
+	}, "")
+
+	workflowTemplate.CreatedBy = owner
+
+	return workflowTemplate, nil
+}
+
+// SetWorkflowTemplateStableVersion pins the version of the workflow template as stable,
+// so it is used instead of the latest version when WorkflowTemplateVersionStable is asked for.
+// The workflow template is returned with the data of that version.
+func (c *Client) SetWorkflowTemplateStableVersion(namespace, uid string, version int64) (*WorkflowTemplate, error) {
+	if version <= 0 {
+		return nil, util.NewUserError(codes.InvalidArgument, "Version is required.")
+	}
+
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, uid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sb.Update("workflow_templates").
+		Set("stable_version", version).
+		Where(sq.Eq{"id": workflowTemplate.ID}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Version":   version,
+			"Error":     err.Error(),
+		}).Error("Set Workflow Template Stable Version DB failed.")
+		return nil, util.NewUserError(codes.Unknown, "Unable to set workflow template stable version.")
+	}
+
+	parameters := []Parameter{
+		{Name: "version", Value: ptr.String(strconv.FormatInt(version, 10))},
+	}
+	if workflowTemplate.StableVersion != nil {
+		parameters = append(parameters, Parameter{Name: "previousVersion", Value: ptr.String(strconv.FormatInt(*workflowTemplate.StableVersion, 10))})
+	}
+	c.createAuditEvent(namespace, AuditActionSetWorkflowTemplateStableVersion, uid, parameters, "")
+
+	workflowTemplate.StableVersion = &version
+
+	return workflowTemplate, nil
+}
+
+// deleteWorkflowTemplateWorkflows deletes the argo workflows created from any version of the workflow template
+func (c *Client) deleteWorkflowTemplateWorkflows(namespace, uid string) error {
+	return c.runKubeMutation("DeleteWorkflows", func() error {
+		workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%v=%v", workflowTemplateUIDLabelKey, uid),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, wf := range workflows.Items {
+			if err := c.ArgoprojV1alpha1().Workflows(namespace).Delete(wf.Name, nil); err != nil && !strings.Contains(err.Error(), "not found") {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// deleteArgoWorkflowTemplates deletes the argo workflow templates of every version of the workflow template
+func (c *Client) deleteArgoWorkflowTemplates(namespace, uid string) error {
+	return c.runKubeMutation("DeleteWorkflowTemplates", func() error {
+		workflowTemplates, err := c.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%v=%v", label.WorkflowTemplateUid, uid),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, wft := range workflowTemplates.Items {
+			if err := c.ArgoprojV1alpha1().WorkflowTemplates(namespace).Delete(wft.Name, nil); err != nil && !strings.Contains(err.Error(), "not found") {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // createArgoWorkflowTemplate creates an argo workflow template from the workflowTemplate struct
 // the argo template stores the version information.
 func createArgoWorkflowTemplate(workflowTemplate *WorkflowTemplate, version int64) (*v1alpha1.WorkflowTemplate, error) {
@@ -989,8 +1828,8 @@ func (c *Client) listArgoWorkflowTemplates(namespace, workflowTemplateUid string
 }
 
 // listDBWorkflowTemplateVersions gets all of the workflow template versions for a specified workflow template uid
-// archived ones are ignored. Returned in created_at desc order.
-func (c *Client) selectWorkflowTemplateVersionsDB(namespace, workflowTemplateUID string) (versions []*WorkflowTemplateVersion, err error) {
+// archived ones are ignored. Returned in version desc order. If paginator is nil, all of the versions are returned.
+func (c *Client) selectWorkflowTemplateVersionsDB(namespace, workflowTemplateUID string, paginator *pagination.PaginationRequest) (versions []*WorkflowTemplateVersion, err error) {
 	versions = make([]*WorkflowTemplateVersion, 0)
 
 	sb := c.workflowTemplatesVersionSelectBuilder(namespace).
@@ -999,7 +1838,8 @@ func (c *Client) selectWorkflowTemplateVersionsDB(namespace, workflowTemplateUID
 			"wt.uid":         workflowTemplateUID,
 			"wt.is_archived": false,
 		}).
-		OrderBy("wtv.created_at DESC")
+		OrderBy("wtv.version DESC")
+	sb = *paginator.ApplyToSelect(&sb)
 
 	err = c.DB.Selectx(&versions, sb)
 
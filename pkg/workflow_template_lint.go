@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/onepanelio/core/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"strings"
+)
+
+// workflowTemplateLintRulesKey is the key of the onepanel config map in a namespace with the lint rules of the namespace
+const workflowTemplateLintRulesKey = "workflowTemplateLintRules"
+
+// WorkflowTemplateLintRule checks a workflow template follows a convention, beyond it being a valid workflow
+type WorkflowTemplateLintRule interface {
+	// Lint returns a message for every way the workflow template does not follow the rule
+	Lint(workflowTemplate *WorkflowTemplate) (violations []string, err error)
+}
+
+// RequiredLabelLintRule requires workflow templates to have the label Key
+type RequiredLabelLintRule struct {
+	Key string
+}
+
+// Lint returns a violation if the workflow template does not have the label
+func (r RequiredLabelLintRule) Lint(workflowTemplate *WorkflowTemplate) (violations []string, err error) {
+	if _, ok := workflowTemplate.Labels[r.Key]; !ok {
+		violations = append(violations, fmt.Sprintf("missing required label %q", r.Key))
+	}
+
+	return
+}
+
+// RequiredResourcesLintRule requires every container and script template of workflow templates to declare resources
+type RequiredResourcesLintRule struct{}
+
+// Lint returns a violation for every container or script template without resource requests or limits
+func (r RequiredResourcesLintRule) Lint(workflowTemplate *WorkflowTemplate) (violations []string, err error) {
+	workflows, err := getWorkflowsFromWorkflowTemplate(workflowTemplate)
+	if err != nil {
+		return
+	}
+
+	for _, workflow := range workflows {
+		for _, template := range workflow.Spec.Templates {
+			container := template.Container
+			if container == nil && template.Script != nil {
+				container = &template.Script.Container
+			}
+			if container == nil {
+				continue
+			}
+
+			if len(container.Resources.Requests) == 0 && len(container.Resources.Limits) == 0 {
+				violations = append(violations, fmt.Sprintf("template %q is missing a resources block", template.Name))
+			}
+		}
+	}
+
+	return
+}
+
+// WorkflowTemplateLintConfig is the lint rules of a namespace, as set in its onepanel config map
+type WorkflowTemplateLintConfig struct {
+	RequiredLabels   []string `yaml:"requiredLabels"`
+	RequireResources bool     `yaml:"requireResources"`
+}
+
+// Rules returns the lint rules of the config
+func (c *WorkflowTemplateLintConfig) Rules() (rules []WorkflowTemplateLintRule) {
+	for _, key := range c.RequiredLabels {
+		rules = append(rules, RequiredLabelLintRule{Key: key})
+	}
+
+	if c.RequireResources {
+		rules = append(rules, RequiredResourcesLintRule{})
+	}
+
+	return
+}
+
+// WithWorkflowTemplateLintRules returns a shallow copy of the client that lints workflow templates with rules,
+// in addition to the rules of the namespace config map
+func (c *Client) WithWorkflowTemplateLintRules(rules ...WorkflowTemplateLintRule) *Client {
+	client := *c
+	client.workflowTemplateLintRules = append(append([]WorkflowTemplateLintRule{}, c.workflowTemplateLintRules...), rules...)
+
+	return &client
+}
+
+// getWorkflowTemplateLintRules returns the lint rules of the client followed by the lint rules of the namespace
+func (c *Client) getWorkflowTemplateLintRules(namespace string) (rules []WorkflowTemplateLintRule, err error) {
+	rules = append(rules, c.workflowTemplateLintRules...)
+
+	configMap, err := c.getConfigMap(namespace, "onepanel")
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return rules, nil
+		}
+		return
+	}
+
+	data, ok := configMap.Data[workflowTemplateLintRulesKey]
+	if !ok {
+		return
+	}
+
+	config := &WorkflowTemplateLintConfig{}
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"Value":     data,
+			"Error":     err.Error(),
+		}).Error("getWorkflowTemplateLintRules failed parsing the lint rules.")
+		return nil, util.NewUserError(codes.InvalidArgument, "Namespace workflow template lint rules are not valid.")
+	}
+
+	return append(rules, config.Rules()...), nil
+}
+
+// lintWorkflowTemplate runs the lint rules of the namespace on the workflow template.
+// A codes.InvalidArgument error listing the violations is returned if any rule is not followed.
+func (c *Client) lintWorkflowTemplate(namespace string, workflowTemplate *WorkflowTemplate) error {
+	rules, err := c.getWorkflowTemplateLintRules(namespace)
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, rule := range rules {
+		ruleViolations, err := rule.Lint(workflowTemplate)
+		if err != nil {
+			return util.NewUserError(codes.InvalidArgument, err.Error())
+		}
+		violations = append(violations, ruleViolations...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return util.NewUserError(codes.InvalidArgument, "Workflow template does not follow the lint rules: "+strings.Join(violations, "; ")+".")
+}
@@ -2,10 +2,23 @@ package v1
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	argoFake "github.com/argoproj/argo/pkg/client/clientset/versioned/fake"
 	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/ptr"
+	"github.com/onepanelio/core/pkg/util/request"
+	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -182,9 +195,52 @@ func testClientCreateWorkflowTemplateInsertSameName(t *testing.T) {
 	assert.Equal(t, userErr.Code, codes.AlreadyExists)
 }
 
+// testClientCreateWorkflowTemplateConcurrentSameName creates two WorkflowTemplates with the same name at the same time.
+// The unique index on the name makes exactly one of them fail, without creating its argo workflow template.
+func testClientCreateWorkflowTemplateConcurrentSameName(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+				Name:     "test",
+				Manifest: defaultWorkflowTemplate,
+			})
+			errs <- err
+		}()
+	}
+
+	var failures []error
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	if assert.Len(t, failures, 1) {
+		userErr, ok := failures[0].(*util.UserError)
+		if assert.True(t, ok) {
+			assert.Equal(t, codes.AlreadyExists, userErr.Code)
+		}
+	}
+
+	count, err := c.CountWorkflowTemplatesByName(namespace, "test", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	argoWorkflowTemplates, err := c.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, argoWorkflowTemplates.Items, 1)
+}
+
 // TestClient_CreateWorkflowTemplate tests creating a workflow template
 func TestClient_CreateWorkflowTemplate(t *testing.T) {
 	testClientCreateWorkflowTemplateInsertSameName(t)
+	testClientCreateWorkflowTemplateConcurrentSameName(t)
 	testClientCreateWorkflowTemplateSuccess(t)
 	testClientCreateWorkflowTemplateTimestamp(t)
 }
@@ -273,12 +329,24 @@ func testClientCreateWorkflowTemplateVersionNew(t *testing.T) {
 	workflowTemplate := &WorkflowTemplate{
 		Name:     "test",
 		Manifest: defaultWorkflowTemplate,
+		Category: "CV",
 	}
 
-	c.CreateWorkflowTemplate(namespace, workflowTemplate)
-	_, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+	created, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
 
+	// The category belongs to the workflow template, so a new version keeps it without passing it again
+	version, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+		UID:      created.UID,
+		Name:     created.Name,
+		Manifest: defaultWorkflowTemplate,
+	})
 	assert.Nil(t, err)
+	if assert.NotNil(t, version) {
+		assert.Equal(t, "CV", version.Category)
+	}
 }
 
 // testClientCreateWorkflowTemplateVersionMarkOldNotLatest makes sure older versions are no longer marked as latest
@@ -339,7 +407,1242 @@ func testClientGetWorkflowTemplateNotFound(t *testing.T) {
 	assert.Equal(t, codes.NotFound, userErr.Code)
 }
 
+// testClientGetWorkflowTemplateDatabaseError makes sure a failing database is not reported as a missing workflow template
+func testClientGetWorkflowTemplateDatabaseError(t *testing.T) {
+	c := closedDatabaseTestClient(t)
+
+	wt, err := c.getWorkflowTemplate("onepanel", "uid-not-found", 0)
+	assert.Nil(t, wt)
+	assert.NotNil(t, err)
+
+	wt, err = c.GetWorkflowTemplate("onepanel", "uid-not-found", 0)
+	assert.Nil(t, wt)
+
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+
+	assert.Equal(t, codes.Internal, userErr.Code)
+}
+
+// testClientGetWorkflowTemplateNoRows makes sure a missing workflow template is not an error in the repository
+func testClientGetWorkflowTemplateNoRows(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	wt, err := c.getWorkflowTemplate("onepanel", "uid-not-found", 0)
+	assert.Nil(t, wt)
+	assert.Nil(t, err)
+}
+
+// testClientGetWorkflowTemplateNoVersions makes sure a workflow template left without versions
+// is a failed precondition instead of not found
+func testClientGetWorkflowTemplateNoVersions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As if creating the first version had failed
+	if _, err := c.DB.Exec("DELETE FROM workflow_template_versions WHERE workflow_template_id = $1", created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := c.GetWorkflowTemplate(namespace, created.UID, 0)
+	assert.Nil(t, wt)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, userErr.Code)
+	assert.Equal(t, "Workflow template has no versions.", userErr.Message)
+}
+
 func TestClient_GetWorkflowTemplate(t *testing.T) {
 	testClientGetWorkflowTemplateSuccess(t)
 	testClientGetWorkflowTemplateNotFound(t)
+	testClientGetWorkflowTemplateNoRows(t)
+	testClientGetWorkflowTemplateNoVersions(t)
+	testClientGetWorkflowTemplateDatabaseError(t)
+}
+
+const deprecatedWorkflowTemplate = `entrypoint: main
+ttlSecondsAfterFinished: 3600
+templates:
+- name: main
+  template: other
+  container:
+    image: alpine:latest
+    args: ["echo hello"]
+`
+
+// Test_migrateWorkflowTemplateManifest makes sure deprecated fields are converted to the current schema,
+// and manifests without them are left as they are
+func Test_migrateWorkflowTemplateManifest(t *testing.T) {
+	migrated, err := migrateWorkflowTemplateManifest(deprecatedWorkflowTemplate)
+	assert.Nil(t, err)
+	assert.Equal(t, `entrypoint: main
+templates:
+- container:
+    args:
+    - echo hello
+    image: alpine:latest
+  name: main
+ttlStrategy:
+  secondsAfterCompletion: 3600
+`, migrated)
+
+	// A ttlStrategy that is already set is kept
+	migrated, err = migrateWorkflowTemplateManifest("entrypoint: main\nttlSecondsAfterFinished: 3600\nttlStrategy:\n  secondsAfterCompletion: 60\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "entrypoint: main\nttlStrategy:\n  secondsAfterCompletion: 60\n", migrated)
+
+	migrated, err = migrateWorkflowTemplateManifest(defaultWorkflowTemplate)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultWorkflowTemplate, migrated)
+}
+
+// TestClient_GetWorkflowTemplate_Migration makes sure a workflow template with deprecated fields is returned
+// in the current schema, without changing the stored manifest
+func TestClient_GetWorkflowTemplate_Migration(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: "entrypoint: main\nttlSecondsAfterFinished: 3600\ntemplates:\n- name: main\n  container:\n    image: alpine:latest\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := c.GetWorkflowTemplate(namespace, created.UID, 0)
+	assert.Nil(t, err)
+	assert.NotContains(t, wt.Manifest, "ttlSecondsAfterFinished")
+	assert.Contains(t, wt.Manifest, "ttlStrategy:\n  secondsAfterCompletion: 3600")
+	assert.Empty(t, getWorkflowTemplateWarnings(wt))
+
+	manifest := ""
+	err = sb.Select("manifest").
+		From("workflow_template_versions").
+		Where(sq.Eq{"workflow_template_id": wt.ID}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&manifest)
+	assert.Nil(t, err)
+	assert.Contains(t, manifest, "ttlSecondsAfterFinished: 3600")
+}
+
+// TestClient_ListWorkflowTemplateVersionSummaries makes sure the summaries are ordered newest first
+// and only the newest version is marked as latest
+func TestClient_ListWorkflowTemplateVersionSummaries(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	created, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []int64{created.Version}
+	for i := 0; i < 2; i++ {
+		version, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, version.Version)
+	}
+
+	summaries, err := c.ListWorkflowTemplateVersionSummaries(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.Len(t, summaries, 3)
+
+	for i, summary := range summaries {
+		assert.Equal(t, versions[len(versions)-1-i], summary.Version)
+		assert.Equal(t, i == 0, summary.IsLatest)
+		assert.False(t, summary.CreatedAt.IsZero())
+	}
+}
+
+// TestClient_ListWorkflowTemplateVersions_Pagination makes sure versions are listed newest first, one page at a time
+func TestClient_ListWorkflowTemplateVersions_Pagination(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	created, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []int64{created.Version}
+	for i := 0; i < 4; i++ {
+		version, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, version.Version)
+	}
+
+	all, err := c.ListWorkflowTemplateVersions(namespace, created.UID, nil)
+	assert.Nil(t, err)
+	assert.Len(t, all, 5)
+	for i, wtv := range all {
+		assert.Equal(t, versions[len(versions)-1-i], wtv.Version)
+	}
+
+	paginator := pagination.New(1, 2)
+	var paged []*WorkflowTemplate
+	for page := 1; page <= 3; page++ {
+		result, err := c.ListWorkflowTemplateVersions(namespace, created.UID, paginator)
+		assert.Nil(t, err)
+		if page < 3 {
+			assert.Len(t, result, 2)
+		} else {
+			assert.Len(t, result, 1)
+		}
+		paged = append(paged, result...)
+		paginator = paginator.Advance()
+	}
+	assert.Equal(t, all, paged)
+
+	count, err := c.CountWorkflowTemplateVersions(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), count)
+	assert.Equal(t, int32(3), pagination.New(1, 2).CalculatePages(int(count)))
+}
+
+// TestClient_CreateWorkflowTemplateVersion_Concurrent creates versions of a workflow template at the same time.
+// Every version gets a unique version number, increasing in the order they were created, and only the last one is latest.
+func TestClient_CreateWorkflowTemplateVersion_Concurrent(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	concurrency := 5
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(i int) {
+			_, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+				UID:      created.UID,
+				Name:     "test",
+				Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", fmt.Sprintf("--epochs=%v", i+2), 1),
+			})
+			errs <- err
+		}(i)
+	}
+	for i := 0; i < concurrency; i++ {
+		assert.Nil(t, <-errs)
+	}
+
+	versions, err := c.ListWorkflowTemplateVersionsModels(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.Len(t, versions, concurrency+1)
+
+	seen := make(map[int64]bool)
+	latest := 0
+	for _, version := range versions {
+		assert.False(t, seen[version.Version], "version %v is not unique", version.Version)
+		seen[version.Version] = true
+		if version.IsLatest {
+			latest++
+		}
+	}
+	assert.Equal(t, 1, latest)
+
+	// the ids are in the order the versions were created
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ID < versions[j].ID
+	})
+	for i := 1; i < len(versions); i++ {
+		assert.Greater(t, versions[i].Version, versions[i-1].Version)
+	}
+	assert.True(t, versions[len(versions)-1].IsLatest)
+}
+
+func TestClient_CountWorkflowTemplateUsage(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	wt, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "other",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := c.CountWorkflowTemplateUsage(namespace, wt.UID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	for _, name := range []string{"first", "second"} {
+		if _, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: name}, wt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "other"}, other); err != nil {
+		t.Fatal(err)
+	}
+
+	firstVersion := wt.Version
+	if _, err := c.CreateWorkflowTemplateVersion(namespace, wt); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := c.GetLatestWorkflowTemplate(namespace, wt.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "third"}, latest); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = c.CountWorkflowTemplateUsage(namespace, wt.UID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = c.CountWorkflowTemplateUsage(namespace, wt.UID, firstVersion)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+// createRunningWorkflowExecution creates a workflow template with a workflow execution whose argo workflow is running
+func createRunningWorkflowExecution(t *testing.T, c *Client, namespace string) (*WorkflowTemplate, *WorkflowExecution) {
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{Name: "test"}, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf.Status.Phase = wfv1.NodeRunning
+	if _, err := c.ArgoprojV1alpha1().Workflows(namespace).Update(wf); err != nil {
+		t.Fatal(err)
+	}
+
+	return workflowTemplate, we
+}
+
+// testClientArchiveWorkflowTemplateTerminateRunning makes sure running workflow executions are terminated when asked
+func testClientArchiveWorkflowTemplateTerminateRunning(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, we := createRunningWorkflowExecution(t, c, namespace)
+
+	archived, err := c.ArchiveWorkflowTemplate(namespace, workflowTemplate.UID, true)
+	assert.Nil(t, err)
+	assert.True(t, archived)
+
+	phase := ""
+	err = database.Get(&phase, "SELECT phase FROM workflow_executions WHERE uid = $1", we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Terminated", phase)
+}
+
+// testClientArchiveWorkflowTemplateKeepRunning makes sure running workflow executions are not terminated by default
+func testClientArchiveWorkflowTemplateKeepRunning(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, we := createRunningWorkflowExecution(t, c, namespace)
+
+	archived, err := c.ArchiveWorkflowTemplate(namespace, workflowTemplate.UID, false)
+	assert.Nil(t, err)
+	assert.True(t, archived)
+
+	phase := ""
+	err = database.Get(&phase, "SELECT phase FROM workflow_executions WHERE uid = $1", we.UID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "Terminated", phase)
+}
+
+func TestClient_ArchiveWorkflowTemplate(t *testing.T) {
+	testClientArchiveWorkflowTemplateTerminateRunning(t)
+	testClientArchiveWorkflowTemplateKeepRunning(t)
+}
+
+// TestClient_GetWorkflowTemplateByVersionLabel makes sure a version created with a label can be fetched by it
+func TestClient_GetWorkflowTemplateByVersionLabel(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	created, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workflowTemplate.VersionLabel = ptr.String("v2.1-stable")
+	stable, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stableVersion := stable.Version
+
+	workflowTemplate.VersionLabel = nil
+	if _, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := c.GetWorkflowTemplateByVersionLabel(namespace, created.UID, "v2.1-stable")
+	assert.Nil(t, err)
+	assert.Equal(t, stableVersion, wt.Version)
+	assert.Equal(t, "v2.1-stable", *wt.VersionLabel)
+
+	latest, err := c.GetLatestWorkflowTemplate(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, stableVersion, latest.Version)
+	assert.Nil(t, latest.VersionLabel)
+
+	_, err = c.GetWorkflowTemplateByVersionLabel(namespace, created.UID, "v3")
+	assert.NotNil(t, err)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+}
+
+// TestClient_CreateWorkflowTemplateVersion_VersionLabel makes sure invalid and duplicate version labels are rejected
+func TestClient_CreateWorkflowTemplateVersion_VersionLabel(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	if _, err := c.CreateWorkflowTemplate(namespace, workflowTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	workflowTemplate.VersionLabel = ptr.String("stable")
+	if _, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		versionLabel string
+		code         codes.Code
+	}{
+		{"stable", codes.AlreadyExists},
+		{"12", codes.InvalidArgument},
+		{"-stable", codes.InvalidArgument},
+		{"not stable", codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		workflowTemplate.VersionLabel = ptr.String(test.versionLabel)
+		_, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+		assert.NotNil(t, err, test.versionLabel)
+		userErr, ok := err.(*util.UserError)
+		assert.True(t, ok, test.versionLabel)
+		if ok {
+			assert.Equal(t, test.code, userErr.Code, test.versionLabel)
+		}
+	}
+}
+
+// TestClient_CreateWorkflowTemplate_CreatedBy makes sure who created a workflow template and its latest version is saved and returned
+func TestClient_CreateWorkflowTemplate_CreatedBy(t *testing.T) {
+	c := DefaultTestClient()
+	c.Identity = "system:serviceaccount:onepanel:admin"
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "system:serviceaccount:onepanel:admin", workflowTemplate.CreatedBy)
+	assert.Equal(t, "system:serviceaccount:onepanel:admin", workflowTemplate.UpdatedBy)
+
+	editor := c.WithContext(c.Context())
+	editor.Identity = "system:serviceaccount:onepanel:editor"
+	if _, err := editor.CreateWorkflowTemplateVersion(namespace, workflowTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	workflowTemplate, err = c.GetWorkflowTemplate(namespace, workflowTemplate.UID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "system:serviceaccount:onepanel:admin", workflowTemplate.CreatedBy)
+	assert.Equal(t, "system:serviceaccount:onepanel:editor", workflowTemplate.UpdatedBy)
+
+	workflowTemplates, err := c.ListWorkflowTemplates(namespace, &request.Request{})
+	assert.Nil(t, err)
+	if assert.Len(t, workflowTemplates, 1) {
+		assert.Equal(t, "system:serviceaccount:onepanel:admin", workflowTemplates[0].CreatedBy)
+		assert.Equal(t, "system:serviceaccount:onepanel:editor", workflowTemplates[0].UpdatedBy)
+	}
+}
+
+// TestClient_CreateWorkflowTemplate_Warnings makes sure a workflow template using a deprecated field is created,
+// with a warning about the field
+func TestClient_CreateWorkflowTemplate_Warnings(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "deprecated",
+		Manifest: "ttlSecondsAfterFinished: 60\n" + defaultWorkflowTemplate,
+	})
+	assert.Nil(t, err)
+	if assert.NotNil(t, created) && assert.Len(t, created.Warnings, 1) {
+		assert.Contains(t, created.Warnings[0], "ttlSecondsAfterFinished is deprecated")
+	}
+
+	wt, err := c.GetWorkflowTemplate(namespace, created.UID, 0)
+	assert.Nil(t, err)
+	assert.NotNil(t, wt)
+
+	created, err = c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, created.Warnings)
+}
+
+// TestClient_TransferWorkflowTemplateOwnership makes sure the owner of a workflow template changes and its versions are kept
+func TestClient_TransferWorkflowTemplateOwnership(t *testing.T) {
+	c := DefaultTestClient()
+	c.Identity = "system:serviceaccount:onepanel:admin"
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+		UID:      workflowTemplate.UID,
+		Name:     "test",
+		Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	versionsBefore, err := c.ListWorkflowTemplateVersions(namespace, workflowTemplate.UID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transferred, err := c.TransferWorkflowTemplateOwnership(namespace, workflowTemplate.UID, " new-owner ")
+	assert.Nil(t, err)
+	assert.Equal(t, "new-owner", transferred.CreatedBy)
+
+	workflowTemplate, err = c.GetWorkflowTemplate(namespace, workflowTemplate.UID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "new-owner", workflowTemplate.CreatedBy)
+	assert.Equal(t, "system:serviceaccount:onepanel:admin", workflowTemplate.UpdatedBy)
+
+	count, err := c.CountWorkflowTemplateVersions(namespace, workflowTemplate.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	versionsAfter, err := c.ListWorkflowTemplateVersions(namespace, workflowTemplate.UID, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, versionsAfter, len(versionsBefore)) {
+		for i := range versionsBefore {
+			assert.Equal(t, versionsBefore[i].Version, versionsAfter[i].Version)
+			assert.Equal(t, versionsBefore[i].Manifest, versionsAfter[i].Manifest)
+		}
+	}
+
+	_, err = c.TransferWorkflowTemplateOwnership(namespace, workflowTemplate.UID, " ")
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+
+	_, err = c.TransferWorkflowTemplateOwnership(namespace, "not-found", "new-owner")
+	userErr, ok = err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+}
+
+// TestClient_SetWorkflowTemplateStableVersion makes sure a version pinned as stable, and not the latest,
+// is used to create workflows from the stable version
+func TestClient_SetWorkflowTemplateStableVersion(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stableVersion := workflowTemplate.Version
+	for _, epochs := range []string{"--epochs=5", "--epochs=10"} {
+		if _, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+			UID:      workflowTemplate.UID,
+			Name:     "test",
+			Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", epochs, 1),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = c.GetWorkflowTemplate(namespace, workflowTemplate.UID, WorkflowTemplateVersionStable)
+	assertUserErrorCode(t, err, codes.FailedPrecondition)
+
+	pinned, err := c.SetWorkflowTemplateStableVersion(namespace, workflowTemplate.UID, stableVersion)
+	assert.Nil(t, err)
+	assert.Equal(t, stableVersion, pinned.Version)
+	assert.Equal(t, stableVersion, *pinned.StableVersion)
+
+	version, err := ParseWorkflowTemplateVersionAlias("stable")
+	assert.Nil(t, err)
+	stable, err := c.GetWorkflowTemplate(namespace, workflowTemplate.UID, version)
+	assert.Nil(t, err)
+	assert.Equal(t, stableVersion, stable.Version)
+
+	latest, err := c.GetLatestWorkflowTemplate(namespace, workflowTemplate.UID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, stableVersion, latest.Version)
+	assert.Equal(t, stableVersion, *latest.StableVersion)
+
+	we, err := c.CreateWorkflowExecution(namespace, &WorkflowExecution{
+		Name: "test",
+	}, stable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wf, err := c.ArgoprojV1alpha1().Workflows(namespace).Get(we.UID, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fmt.Sprint(stableVersion), wf.Labels[workflowTemplateVersionLabelKey])
+
+	_, err = c.SetWorkflowTemplateStableVersion(namespace, workflowTemplate.UID, 0)
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	_, err = c.SetWorkflowTemplateStableVersion(namespace, workflowTemplate.UID, 1)
+	assertUserErrorCode(t, err, codes.NotFound)
+
+	_, err = c.GetWorkflowTemplate(namespace, "not-found", WorkflowTemplateVersionStable)
+	assertUserErrorCode(t, err, codes.NotFound)
+
+	_, err = ParseWorkflowTemplateVersionAlias("oldest")
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+}
+
+// TestClient_ListWorkflowTemplates_Category makes sure workflow templates keep their category and can be filtered by it
+func TestClient_ListWorkflowTemplates_Category(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	for name, category := range map[string]string{"resnet": "CV", "yolo": " CV ", "bert": "NLP", "other": ""} {
+		if _, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+			Name:     name,
+			Manifest: defaultWorkflowTemplate,
+			Category: category,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	workflowTemplate, err := c.GetWorkflowTemplate(namespace, "yolo", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "CV", workflowTemplate.Category)
+
+	tests := []struct {
+		category string
+		expected []string
+	}{
+		{"CV", []string{"resnet", "yolo"}},
+		{"NLP", []string{"bert"}},
+		{"Audio", []string{}},
+		{"", []string{"bert", "other", "resnet", "yolo"}},
+	}
+	for _, test := range tests {
+		resourceRequest := &request.Request{
+			Filter: WorkflowTemplateFilter{Category: test.category},
+		}
+
+		workflowTemplates, err := c.ListWorkflowTemplates(namespace, resourceRequest)
+		assert.Nil(t, err, test.category)
+		names := make([]string, 0)
+		for _, wt := range workflowTemplates {
+			if test.category != "" {
+				assert.Equal(t, test.category, wt.Category)
+			}
+			names = append(names, wt.Name)
+		}
+		sort.Strings(names)
+		assert.Equal(t, test.expected, names, test.category)
+
+		count, err := c.CountWorkflowTemplates(namespace, resourceRequest)
+		assert.Nil(t, err, test.category)
+		assert.Equal(t, len(test.expected), count, test.category)
+	}
+
+	_, err = c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "long",
+		Manifest: defaultWorkflowTemplate,
+		Category: strings.Repeat("a", workflowTemplateCategoryMaxLength+1),
+	})
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, codes.InvalidArgument, userErr.Code)
+	}
+}
+
+// TestClient_ValidateWorkflowTemplate makes sure valid manifests have no errors and invalid ones report where the problem is
+func TestClient_ValidateWorkflowTemplate(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	assert.Empty(t, c.ValidateWorkflowTemplate(namespace, defaultWorkflowTemplate))
+
+	missingImage := `entrypoint: main
+templates:
+- name: main
+  dag:
+    tasks:
+    - name: hello
+      template: hello
+- name: hello
+  container:
+    command: [echo, hello]
+`
+	validationErrors := c.ValidateWorkflowTemplate(namespace, missingImage)
+	assert.Len(t, validationErrors, 1)
+	assert.Equal(t, "templates.hello.container.image", validationErrors[0].Field)
+
+	invalidYAML := `entrypoint: main
+templates:
+- name: main
+  dag: [
+`
+	validationErrors = c.ValidateWorkflowTemplate(namespace, invalidYAML)
+	assert.Len(t, validationErrors, 1)
+	assert.NotZero(t, validationErrors[0].Line)
+
+	// nothing is created
+	count, err := c.CountWorkflowTemplatesByName(namespace, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), count)
+}
+
+// TestNormalizeWorkflowTemplateManifest makes sure manifests that only differ cosmetically normalize to the same canonical form
+func TestNormalizeWorkflowTemplateManifest(t *testing.T) {
+	canonical, err := NormalizeWorkflowTemplateManifest(`entrypoint: main
+templates:
+- name: main
+  container:
+    image: alpine
+    command: [echo, hello]
+`)
+	assert.Nil(t, err)
+
+	equivalent := []string{
+		// different key order
+		`templates:
+- container:
+    command: [echo, hello]
+    image: alpine
+  name: main
+entrypoint: main
+`,
+		// comments, indentation and quoting
+		`# says hello
+entrypoint: "main"
+templates:
+    -   name: 'main'   # the only template
+        container:
+            image: alpine
+            command:
+                - echo
+                - "hello"
+
+`,
+	}
+	for _, manifest := range equivalent {
+		normalized, err := NormalizeWorkflowTemplateManifest(manifest)
+		assert.Nil(t, err)
+		assert.Equal(t, canonical, normalized)
+	}
+
+	changed, err := NormalizeWorkflowTemplateManifest(`entrypoint: main
+templates:
+- name: main
+  container:
+    image: alpine
+    command: [echo, goodbye]
+`)
+	assert.Nil(t, err)
+	assert.NotEqual(t, canonical, changed)
+
+	_, err = NormalizeWorkflowTemplateManifest("entrypoint: [")
+	assert.NotNil(t, err)
+}
+
+// TestClient_CreateWorkflowTemplateVersionIfChanged makes sure cosmetic edits do not create new versions
+func TestClient_CreateWorkflowTemplateVersionIfChanged(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	original, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalVersion := original.Version
+
+	cosmetic, err := c.CreateWorkflowTemplateVersionIfChanged(namespace, &WorkflowTemplate{
+		UID:      original.UID,
+		Name:     "test",
+		Manifest: "# no changes\n" + defaultWorkflowTemplate,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, originalVersion, cosmetic.Version)
+
+	count, err := c.CountWorkflowTemplateVersions(namespace, original.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	changed, err := c.CreateWorkflowTemplateVersionIfChanged(namespace, &WorkflowTemplate{
+		UID:      original.UID,
+		Name:     "test",
+		Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, originalVersion, changed.Version)
+
+	count, err = c.CountWorkflowTemplateVersions(namespace, original.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func Test_newWorkflowTemplateValidationError(t *testing.T) {
+	tests := []struct {
+		message string
+		field   string
+		line    int32
+	}{
+		{"templates.main.container.image may not be empty", "templates.main.container.image", 0},
+		{"spec.entrypoint is required", "spec.entrypoint", 0},
+		{"templates.main: dag tasks are cyclic", "templates.main", 0},
+		{"error converting YAML to JSON: yaml: line 4: did not find expected node content", "", 4},
+		{"\"entrypoint\" template should be a DAG", "", 0},
+	}
+
+	for _, test := range tests {
+		validationError := newWorkflowTemplateValidationError(errors.New(test.message))
+		assert.Equal(t, test.message, validationError.Message)
+		assert.Equal(t, test.field, validationError.Field, test.message)
+		assert.Equal(t, test.line, validationError.Line, test.message)
+	}
+}
+
+// assertWorkflowTemplateDeleted makes sure nothing is left of the workflow template in the database or in argo
+func assertWorkflowTemplateDeleted(t *testing.T, c *Client, namespace, uid string) {
+	count := 0
+	err := database.Get(&count, "SELECT COUNT(*) FROM workflow_templates WHERE namespace = $1 AND uid = $2", namespace, uid)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	err = database.Get(&count, "SELECT COUNT(*) FROM workflow_executions WHERE namespace = $1", namespace)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	argoTemplates, err := c.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, argoTemplates.Items, 0)
+}
+
+// testClientDeleteWorkflowTemplateReferenced makes sure a workflow template is not deleted while workflows use it
+func testClientDeleteWorkflowTemplateReferenced(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, _ := createRunningWorkflowExecution(t, c, namespace)
+
+	err := c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, false)
+	assert.NotNil(t, err)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, userErr.Code)
+
+	wt, err := c.GetWorkflowTemplate(namespace, workflowTemplate.UID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, workflowTemplate.UID, wt.UID)
+}
+
+// testClientDeleteWorkflowTemplateUnreferenced makes sure a workflow template that was never executed is deleted,
+// along with its versions
+func testClientDeleteWorkflowTemplateUnreferenced(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateWorkflowTemplateVersion(namespace, workflowTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, false)
+	assert.Nil(t, err)
+	assertWorkflowTemplateDeleted(t, c, namespace, workflowTemplate.UID)
+
+	_, err = c.GetWorkflowTemplate(namespace, workflowTemplate.UID, 0)
+	assert.NotNil(t, err)
+}
+
+// testClientDeleteWorkflowTemplateHistory makes sure the records of finished workflow executions, whose workflows
+// are gone from the cluster, are only deleted along with the workflow template when it is forced
+func testClientDeleteWorkflowTemplateHistory(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, we := createRunningWorkflowExecution(t, c, namespace)
+	if err := c.ArgoprojV1alpha1().Workflows(namespace).Delete(we.UID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err := c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, false)
+	assertUserErrorCode(t, err, codes.FailedPrecondition)
+
+	count := 0
+	err = database.Get(&count, "SELECT COUNT(*) FROM workflow_executions WHERE namespace = $1 AND name = $2", namespace, we.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	err = c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, true)
+	assert.Nil(t, err)
+	assertWorkflowTemplateDeleted(t, c, namespace, workflowTemplate.UID)
+}
+
+// testClientDeleteWorkflowTemplateClusterFailed makes sure the workflow template is deleted from the database
+// even if its resources in the cluster can not be deleted, so deleting it is not left half done the other way around
+func testClientDeleteWorkflowTemplateClusterFailed(t *testing.T) {
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependReactor("delete", "workflowtemplates", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unavailable")
+	})
+
+	c := DefaultTestClient()
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, false)
+	assertUserErrorCode(t, err, codes.Unknown)
+
+	count := 0
+	err = database.Get(&count, "SELECT COUNT(*) FROM workflow_templates WHERE namespace = $1 AND uid = $2", namespace, workflowTemplate.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+// testClientDeleteWorkflowTemplateForce makes sure forcing the deletion deletes the workflows using the template
+func testClientDeleteWorkflowTemplateForce(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate, _ := createRunningWorkflowExecution(t, c, namespace)
+
+	err := c.DeleteWorkflowTemplate(namespace, workflowTemplate.UID, true)
+	assert.Nil(t, err)
+	assertWorkflowTemplateDeleted(t, c, namespace, workflowTemplate.UID)
+
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, workflows.Items, 0)
+}
+
+// testClientDeleteWorkflowTemplateNotFound makes sure deleting a workflow template that does not exist is an error
+func testClientDeleteWorkflowTemplateNotFound(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	err := c.DeleteWorkflowTemplate("onepanel", "not-found", false)
+	assert.NotNil(t, err)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+}
+
+func TestClient_DeleteWorkflowTemplate(t *testing.T) {
+	testClientDeleteWorkflowTemplateReferenced(t)
+	testClientDeleteWorkflowTemplateUnreferenced(t)
+	testClientDeleteWorkflowTemplateHistory(t)
+	testClientDeleteWorkflowTemplateClusterFailed(t)
+	testClientDeleteWorkflowTemplateForce(t)
+	testClientDeleteWorkflowTemplateNotFound(t)
+}
+
+// TestClient_DiffWorkflowTemplateVersions makes sure the diff shows the lines removed and added between two versions
+func TestClient_DiffWorkflowTemplateVersions(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	first, err := c.CreateWorkflowTemplate(namespace, &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstVersion := first.Version
+
+	second, err := c.CreateWorkflowTemplateVersion(namespace, &WorkflowTemplate{
+		UID:      first.UID,
+		Name:     "test",
+		Manifest: strings.Replace(defaultWorkflowTemplate, "--epochs=1", "--epochs=5", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := c.DiffWorkflowTemplateVersions(namespace, first.UID, firstVersion, second.Version)
+	assert.Nil(t, err)
+	assert.Contains(t, diff, fmt.Sprintf("--- version %v\n", firstVersion))
+	assert.Contains(t, diff, fmt.Sprintf("+++ version %v\n", second.Version))
+	assert.Contains(t, diff, "\n-      value: \"python mnist/main.py --epochs=1\"\n")
+	assert.Contains(t, diff, "\n+      value: \"python mnist/main.py --epochs=5\"\n")
+
+	diff, err = c.DiffWorkflowTemplateVersions(namespace, first.UID, firstVersion, firstVersion)
+	assert.Nil(t, err)
+	assert.Equal(t, "", diff)
+
+	_, err = c.DiffWorkflowTemplateVersions(namespace, first.UID, firstVersion, 1)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, userErr.Code)
+}
+
+// testClientCreateWorkflowTemplateLintMissingLabel makes sure a workflow template missing a required label is not created
+func testClientCreateWorkflowTemplateLintMissingLabel(t *testing.T) {
+	c := DefaultTestClient().WithWorkflowTemplateLintRules(RequiredLabelLintRule{Key: "team"})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	_, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	assert.NotNil(t, err)
+	assert.IsType(t, &util.UserError{}, err)
+	userErr := err.(*util.UserError)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+	assert.Equal(t, `Workflow template does not follow the lint rules: missing required label "team".`, userErr.Message)
+
+	count, err := c.CountWorkflowTemplatesByName(namespace, "test", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), count)
+}
+
+// testClientCreateWorkflowTemplateLintVersion makes sure new versions of a workflow template are linted as well
+func testClientCreateWorkflowTemplateLintVersion(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+		Labels:   map[string]string{"team": "vision"},
+	}
+	_, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	assert.Nil(t, err)
+
+	linted := c.WithWorkflowTemplateLintRules(RequiredLabelLintRule{Key: "team"}, RequiredLabelLintRule{Key: "owner"})
+
+	_, err = linted.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+	assert.NotNil(t, err)
+	assert.IsType(t, &util.UserError{}, err)
+	userErr := err.(*util.UserError)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+	assert.Equal(t, `Workflow template does not follow the lint rules: missing required label "owner".`, userErr.Message)
+
+	workflowTemplate.Labels["owner"] = "ml-platform"
+	_, err = linted.CreateWorkflowTemplateVersion(namespace, workflowTemplate)
+	assert.Nil(t, err)
+}
+
+// testClientCreateWorkflowTemplateLintNamespaceConfig makes sure the lint rules of the namespace config map are used
+func testClientCreateWorkflowTemplateLintNamespaceConfig(t *testing.T) {
+	namespace := "lint"
+	c := NewTestClient(database, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "onepanel",
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			"workflowTemplateLintRules": "requiredLabels: [team]\nrequireResources: true\n",
+		},
+	})
+	clearDatabase(t)
+
+	workflowTemplate := &WorkflowTemplate{
+		Name:     "test",
+		Manifest: defaultWorkflowTemplate,
+	}
+
+	_, err := c.CreateWorkflowTemplate(namespace, workflowTemplate)
+	assert.NotNil(t, err)
+	assert.IsType(t, &util.UserError{}, err)
+	userErr := err.(*util.UserError)
+	assert.Equal(t, codes.InvalidArgument, userErr.Code)
+	assert.Equal(t, `Workflow template does not follow the lint rules: missing required label "team"; template "pytorch" is missing a resources block; template "slack-notify-success" is missing a resources block.`, userErr.Message)
+}
+
+// TestClient_CreateWorkflowTemplate_Lint tests the lint rules are run when creating workflow templates
+func TestClient_CreateWorkflowTemplate_Lint(t *testing.T) {
+	testClientCreateWorkflowTemplateLintMissingLabel(t)
+	testClientCreateWorkflowTemplateLintVersion(t)
+	testClientCreateWorkflowTemplateLintNamespaceConfig(t)
+}
+
+func TestRequiredResourcesLintRule_Lint(t *testing.T) {
+	rule := RequiredResourcesLintRule{}
+
+	violations, err := rule.Lint(&WorkflowTemplate{
+		Manifest: `entrypoint: main
+templates:
+- name: main
+  dag:
+    tasks:
+    - name: train
+      template: train
+    - name: report
+      template: report
+- name: train
+  container:
+    image: python:3
+    resources:
+      limits:
+        cpu: 1
+- name: report
+  script:
+    image: python:3
+    source: print("done")
+`,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`template "report" is missing a resources block`}, violations)
+}
+
+// TestClient_ResolveWorkflowTemplatePlaceholders makes sure the system placeholders are replaced with their values in the namespace
+// and that other placeholders are left untouched
+func TestClient_ResolveWorkflowTemplatePlaceholders(t *testing.T) {
+	c := DefaultTestClient()
+
+	manifest := `entrypoint: main
+templates:
+- name: main
+  container:
+    image: onepanel/filesyncer:{{.ArtifactRepositoryType}}
+    args:
+    - --namespace={{workflow.namespace}}
+    - --name={{workflow.name}}
+    - --source={{workflow.parameters.source}}
+    - --key={{workflow.namespace}}/{{pod.name}}
+`
+
+	resolved := c.ResolveWorkflowTemplatePlaceholders("onepanel", manifest)
+	assert.Equal(t, `entrypoint: main
+templates:
+- name: main
+  container:
+    image: onepanel/filesyncer:s3
+    args:
+    - --namespace=onepanel
+    - --name={{workflow.name}}
+    - --source={{workflow.parameters.source}}
+    - --key=onepanel/{{pod.name}}
+`, resolved)
+
+	// Without an artifact repository, only the namespace is known
+	resolved = c.ResolveWorkflowTemplatePlaceholders("unconfigured", manifest)
+	assert.Contains(t, resolved, "image: onepanel/filesyncer:{{.ArtifactRepositoryType}}")
+	assert.Contains(t, resolved, "--namespace=unconfigured")
+	assert.Contains(t, resolved, "--name={{workflow.name}}")
 }
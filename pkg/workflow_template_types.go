@@ -10,6 +10,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -26,11 +28,16 @@ type WorkflowTemplate struct {
 	Namespace                        string
 	Name                             string
 	Manifest                         string
-	Version                          int64 // The latest version, unix timestamp
-	Versions                         int64 `db:"versions"` // How many versions there are of this template total.
+	Version                          int64   // The latest version, unix timestamp
+	VersionLabel                     *string `db:"version_label"` // Optional name of the version, unique per workflow template
+	Versions                         int64   `db:"versions"`      // How many versions there are of this template total.
 	IsLatest                         bool
-	IsArchived                       bool `db:"is_archived"`
-	IsSystem                         bool `db:"is_system"`
+	IsArchived                       bool   `db:"is_archived"`
+	IsSystem                         bool   `db:"is_system"`
+	CreatedBy                        string `db:"created_by"` // identity of the caller that created the template, see Client.Identity
+	UpdatedBy                        string `db:"updated_by"` // identity of the caller that created the latest version
+	Category                         string // optional, like CV or NLP, to organize templates
+	StableVersion                    *int64 `db:"stable_version"` // version pinned as stable, if any, see WorkflowTemplateVersionStable
 	ArgoWorkflowTemplate             *wfv1.WorkflowTemplate
 	Labels                           types.JSONLabels
 	WorkflowExecutionStatisticReport *WorkflowExecutionStatisticReport
@@ -39,6 +46,107 @@ type WorkflowTemplate struct {
 	Resource                         *string // utility in case we are specifying a workflow template for a specific resource
 	ResourceUID                      *string // see Resource field
 	Parameters                       []Parameter
+	Warnings                         []string // non-fatal problems found when creating the workflow template, like deprecated fields
+}
+
+// WorkflowTemplateValidationError is a problem found when validating a workflow template manifest
+type WorkflowTemplateValidationError struct {
+	Message string
+	Field   string // path of the field with the problem, like templates.main.container.image, if known
+	Line    int32  // line of the manifest with the problem, if known
+}
+
+// validationErrorFieldRegex matches the field path argo starts its validation errors with
+var validationErrorFieldRegex = regexp.MustCompile(`^((?:spec\.)?(?:templates|arguments|entrypoint|onExit|podGC|volumeClaimTemplates|volumes)(?:[.\[][^\s:]*)?)[\s:]`)
+
+// validationErrorLineRegex matches the line yaml parsing errors refer to
+var validationErrorLineRegex = regexp.MustCompile(`line (\d+):`)
+
+// newWorkflowTemplateValidationError creates a WorkflowTemplateValidationError from the error,
+// setting the field and line if the error message has them
+func newWorkflowTemplateValidationError(err error) *WorkflowTemplateValidationError {
+	validationError := &WorkflowTemplateValidationError{
+		Message: err.Error(),
+	}
+
+	if match := validationErrorFieldRegex.FindStringSubmatch(validationError.Message); match != nil {
+		validationError.Field = match[1]
+	}
+
+	if match := validationErrorLineRegex.FindStringSubmatch(validationError.Message); match != nil {
+		if line, err := strconv.ParseInt(match[1], 10, 32); err == nil {
+			validationError.Line = int32(line)
+		}
+	}
+
+	return validationError
+}
+
+// NormalizeWorkflowTemplateManifest returns the canonical form of a workflow template manifest.
+// Keys are sorted, comments are stripped and indentation, quoting and flow style are made consistent,
+// so manifests that only differ cosmetically have the same canonical form.
+func NormalizeWorkflowTemplateManifest(manifest string) (string, error) {
+	var content interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &content); err != nil {
+		return "", err
+	}
+
+	normalized, err := yaml.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// migrateWorkflowTemplateManifest converts a manifest that uses deprecated fields to the current schema,
+// see getWorkflowTemplateWarnings. ttlSecondsAfterFinished is moved to ttlStrategy.secondsAfterCompletion,
+// unless that is already set, and the template and templateRef fields of templates, which are ignored, are removed.
+// A manifest without deprecated fields is returned as is, keeping its comments and formatting.
+func migrateWorkflowTemplateManifest(manifest string) (string, error) {
+	spec := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal([]byte(manifest), spec); err != nil {
+		return "", err
+	}
+
+	migrated := false
+	if ttl, ok := spec["ttlSecondsAfterFinished"]; ok {
+		ttlStrategy, ok := spec["ttlStrategy"].(map[interface{}]interface{})
+		if !ok {
+			ttlStrategy = make(map[interface{}]interface{})
+		}
+		if _, ok := ttlStrategy["secondsAfterCompletion"]; !ok {
+			ttlStrategy["secondsAfterCompletion"] = ttl
+		}
+		spec["ttlStrategy"] = ttlStrategy
+		delete(spec, "ttlSecondsAfterFinished")
+		migrated = true
+	}
+
+	templates, _ := spec["templates"].([]interface{})
+	for _, item := range templates {
+		template, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"template", "templateRef"} {
+			if _, ok := template[key]; ok {
+				delete(template, key)
+				migrated = true
+			}
+		}
+	}
+
+	if !migrated {
+		return manifest, nil
+	}
+
+	result, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
 }
 
 // GenerateUID generates a uid from the input name and sets it on the workflow template
@@ -275,6 +383,6 @@ func (wt *WorkflowTemplate) AddWorkflowTemplateParametersFromAnnotations(spec ma
 // getWorkflowTemplateColumns returns all of the columns for workflowTemplate modified by alias, destination.
 // see formatColumnSelect
 func getWorkflowTemplateColumns(aliasAndDestination ...string) []string {
-	columns := []string{"id", "created_at", "uid", "name", "namespace", "modified_at", "is_archived", "labels"}
+	columns := []string{"id", "created_at", "uid", "name", "namespace", "modified_at", "is_archived", "labels", "created_by", "updated_by", "category", "stable_version"}
 	return sql.FormatColumnSelect(columns, aliasAndDestination...)
 }
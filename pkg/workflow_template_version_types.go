@@ -13,7 +13,8 @@ type WorkflowTemplateVersion struct {
 	ID               uint64
 	UID              string
 	Version          int64
-	IsLatest         bool `db:"is_latest"`
+	VersionLabel     *string `db:"version_label"` // optional, a name for the version like v2.1-stable
+	IsLatest         bool    `db:"is_latest"`
 	Manifest         string
 	CreatedAt        time.Time         `db:"created_at"`
 	WorkflowTemplate *WorkflowTemplate `db:"workflow_template"`
@@ -41,6 +42,6 @@ func WorkflowTemplateVersionsToIDs(resources []*WorkflowTemplateVersion) (ids []
 // getWorkflowTemplateVersionColumns returns all of the columns for workflow template versions modified by alias, destination.
 // see formatColumnSelect
 func getWorkflowTemplateVersionColumns(aliasAndDestination ...string) []string {
-	columns := []string{"id", "created_at", "version", "is_latest", "manifest", "parameters", "labels"}
+	columns := []string{"id", "created_at", "version", "version_label", "is_latest", "manifest", "parameters", "labels"}
 	return sql.FormatColumnSelect(columns, aliasAndDestination...)
 }
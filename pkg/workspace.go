@@ -15,6 +15,7 @@ import (
 	"github.com/onepanelio/core/pkg/util/request"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 	"strings"
 	"time"
 )
@@ -119,6 +120,20 @@ func updateWorkspaceStatusBuilder(namespace, uid string, status *WorkspaceStatus
 	return ub
 }
 
+// createWorkspaceEventDB records that the workspace with the id moved to phase in the workspace events table
+func createWorkspaceEventDB(runner sq.BaseRunner, workspaceID uint64, phase WorkspacePhase) error {
+	_, err := sb.Insert("workspace_events").
+		SetMap(sq.Eq{
+			"workspace_id": workspaceID,
+			"phase":        phase,
+			"message":      workspaceEventMessages[phase],
+		}).
+		RunWith(runner).
+		Exec()
+
+	return err
+}
+
 // mergeWorkspaceParameters combines two parameter arrays. If a parameter in newParameters is not in
 // the existing ones, it is added. If it is, it is ignored.
 func mergeWorkspaceParameters(existingParameters, newParameters []Parameter) (parameters []Parameter) {
@@ -186,6 +201,9 @@ func (c *Client) createWorkspace(namespace string, parameters []byte, workspace
 	if workspace.WorkspaceTemplate.WorkflowTemplate == nil {
 		return nil, fmt.Errorf("workspace.WorkspaceTemplate.WorkflowTemplate is nil")
 	}
+	if workspace.Replicas == 0 {
+		workspace.Replicas = 1
+	}
 
 	systemConfig, err := c.GetSystemConfig()
 	if err != nil {
@@ -249,6 +267,12 @@ func (c *Client) createWorkspace(namespace string, parameters []byte, workspace
 			"workspace_template_id":      workspace.WorkspaceTemplate.ID,
 			"workspace_template_version": workspace.WorkspaceTemplate.Version,
 			"labels":                     workspace.Labels,
+			"replicas":                   workspace.Replicas,
+			"command":                    workspace.Command,
+			"args":                       workspace.Args,
+			"schedule_start":             workspace.ScheduleStart,
+			"schedule_stop":              workspace.ScheduleStop,
+			"schedule_timezone":          workspace.ScheduleTimezone,
 		}).
 		Suffix("RETURNING id, created_at").
 		RunWith(c.DB).
@@ -262,6 +286,10 @@ func (c *Client) createWorkspace(namespace string, parameters []byte, workspace
 		return nil, util.NewUserError(codes.Unknown, err.Error())
 	}
 
+	if err := createWorkspaceEventDB(c.DB, workspace.ID, WorkspaceLaunching); err != nil {
+		return nil, err
+	}
+
 	return workspace, nil
 }
 
@@ -285,15 +313,20 @@ func (c *Client) addResourceRequestsAndLimitsToWorkspaceTemplate(t wfv1.Template
 	if !ok {
 		return nil, errors.New("unable to type check statefulset manifest")
 	}
+	containers, ok := templateSpec["containers"].([]interface{})
+	if !ok {
+		return nil, errors.New("unable to type check statefulset manifest")
+	}
+	if err := overrideWorkspaceContainerCommand(containers, workspace); err != nil {
+		return nil, err
+	}
 	extraContainer := generateExtraContainerWithHostPortToSequesterNode()
 	if extraContainer != nil {
-		containers, ok := templateSpec["containers"].([]interface{})
-		if !ok {
-			return nil, errors.New("unable to type check statefulset manifest")
-		}
-
 		templateSpec["containers"] = append([]interface{}{extraContainer}, containers...)
 	}
+	if workspace.Replicas > 0 {
+		spec["replicas"] = workspace.Replicas
+	}
 	resultManifest, err := yaml.Marshal(statefulSet)
 	if err != nil {
 		return nil, err
@@ -301,6 +334,30 @@ func (c *Client) addResourceRequestsAndLimitsToWorkspaceTemplate(t wfv1.Template
 	return resultManifest, nil
 }
 
+// overrideWorkspaceContainerCommand sets the command and args of the workspace template container,
+// the first container of the statefulset, to the ones of the workspace. Those the workspace does not set are left as is.
+func overrideWorkspaceContainerCommand(containers []interface{}, workspace *Workspace) error {
+	if len(workspace.Command) == 0 && len(workspace.Args) == 0 {
+		return nil
+	}
+	if len(containers) == 0 {
+		return errors.New("statefulset manifest has no containers")
+	}
+
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return errors.New("unable to type check statefulset manifest")
+	}
+	if len(workspace.Command) > 0 {
+		container["command"] = []string(workspace.Command)
+	}
+	if len(workspace.Args) > 0 {
+		container["args"] = []string(workspace.Args)
+	}
+
+	return nil
+}
+
 // generateExtraContainerWithHostPortToSequesterNode will add an extra container to a workspace.
 // The extra container have a hostPort set. Kubernetes will ensure the hostPort does not get conflict
 // between containers, scheduling a new node as needed.
@@ -404,11 +461,37 @@ func (c *Client) startWorkspace(namespace string, parameters []byte, workspace *
 		return nil, util.NewUserError(codes.Unknown, err.Error())
 	}
 
+	if err := createWorkspaceEventDB(c.DB, workspace.ID, WorkspaceLaunching); err != nil {
+		return nil, err
+	}
+
 	return workspace, nil
 }
 
-// CreateWorkspace creates a workspace by triggering the corresponding workflow
+// CreateWorkspace creates a workspace by triggering the corresponding workflow.
+// A workspace without replicas set has a single replica.
+// The command and args of the workspace, if set, override the ones of the workspace template container.
+// A workspace with a schedule is paused and resumed by ReconcileWorkspaceSchedules.
 func (c *Client) CreateWorkspace(namespace string, workspace *Workspace) (*Workspace, error) {
+	if workspace.Replicas == 0 {
+		workspace.Replicas = 1
+	}
+	if err := validateWorkspaceReplicas(workspace.Replicas); err != nil {
+		return nil, err
+	}
+
+	if err := validateWorkspaceCommand(workspace.Command, workspace.Args); err != nil {
+		return nil, err
+	}
+
+	if err := validateWorkspaceSchedule(workspace.ScheduleStart, workspace.ScheduleStop, workspace.ScheduleTimezone); err != nil {
+		return nil, err
+	}
+
+	if err := validateLabels(workspace.Labels); err != nil {
+		return nil, err
+	}
+
 	if err := workspace.GenerateUID(workspace.Name); err != nil {
 		return nil, err
 	}
@@ -457,8 +540,24 @@ func (c *Client) CreateWorkspace(namespace string, workspace *Workspace) (*Works
 	}
 	workspace.WorkspaceTemplate = workspaceTemplate
 
+	// The volume is created before the workspace, so the stateful set uses it instead of creating an empty one
+	claimName := ""
+	if workspace.SourceSnapshotID != "" {
+		snapshot, err := c.getVolumeSnapshot(namespace, workspace.SourceSnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		claimName, err = c.createWorkspaceVolumeFromSnapshot(namespace, workspace, snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	workspace, err = c.createWorkspace(namespace, parameters, workspace)
 	if err != nil {
+		if claimName != "" {
+			c.deleteWorkspaceVolume(namespace, claimName)
+		}
 		return nil, err
 	}
 
@@ -553,6 +652,54 @@ func (c *Client) GetWorkspace(namespace, uid string) (workspace *Workspace, err
 	return
 }
 
+// GetWorkspaceReady returns true if all the containers of the workspace pass their readiness probes.
+// The pods of the workspace are listed for it, so unlike the rest of the workspace, readiness is not loaded by GetWorkspace,
+// only when asked for. Readiness is informational, so if the pods can not be listed, the workspace is reported as not ready.
+func (c *Client) GetWorkspaceReady(namespace, uid string) bool {
+	var pods *corev1.PodList
+	err := c.runKubeCall("ListPods", func() (err error) {
+		pods, err = c.CoreV1().Pods(namespace).List(ListOptions{
+			LabelSelector: fmt.Sprintf("app=%v", uid),
+		})
+		return
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": namespace,
+			"UID":       uid,
+			"Error":     err.Error(),
+		}).Error("Unable to list workspace pods.")
+		return false
+	}
+
+	return workspacePodsReady(pods.Items)
+}
+
+// workspacePodsReady returns true if there is at least one pod, and the containers of each pod pass their readiness probes
+func workspacePodsReady(pods []corev1.Pod) bool {
+	if len(pods) == 0 {
+		return false
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			return false
+		}
+
+		containersReady := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.ContainersReady && condition.Status == corev1.ConditionTrue {
+				containersReady = true
+			}
+		}
+		if !containersReady {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UpdateWorkspaceStatus updates workspace status and times based on phase
 func (c *Client) UpdateWorkspaceStatus(namespace, uid string, status *WorkspaceStatus) (err error) {
 	// A succeeded status is passed in when a DAG succeeds. We don't need to do anything in this case.
@@ -579,7 +726,34 @@ func (c *Client) UpdateWorkspaceStatus(namespace, uid string, status *WorkspaceS
 		}
 	}
 
-	result, err := updateWorkspaceStatusBuilder(namespace, uid, status).
+	var workspaceID uint64
+	err = updateWorkspaceStatusBuilder(namespace, uid, status).
+		Suffix("RETURNING id").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&workspaceID)
+	if err == sql.ErrNoRows {
+		return util.NewUserError(codes.NotFound, "Workspace not found.")
+	}
+	if err != nil {
+		return err
+	}
+
+	return createWorkspaceEventDB(c.DB, workspaceID, status.Phase)
+}
+
+// RecordWorkspaceActivity records that the workspace is being used now, like when its user interface sends a heartbeat.
+// Only workspaces that are not terminated have their activity recorded.
+func (c *Client) RecordWorkspaceActivity(namespace, uid string) error {
+	result, err := sb.Update("workspaces").
+		Set("last_activity_at", time.Now().UTC()).
+		Where(sq.And{
+			sq.Eq{
+				"namespace": namespace,
+				"uid":       uid,
+			},
+			sq.NotEq{"phase": WorkspaceTerminated},
+		}).
 		RunWith(c.DB).
 		Exec()
 	if err != nil {
@@ -590,11 +764,100 @@ func (c *Client) UpdateWorkspaceStatus(namespace, uid string, status *WorkspaceS
 	if err != nil {
 		return err
 	}
-
 	if rowsAffected == 0 {
 		return util.NewUserError(codes.NotFound, "Workspace not found.")
 	}
 
+	return nil
+}
+
+// GetWorkspaceEvents returns the status transitions of the workspace, oldest first, paginated by the request.
+func (c *Client) GetWorkspaceEvents(namespace, uid string, request *request.Request) (events []*WorkspaceEvent, err error) {
+	workspace, err := c.GetWorkspace(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workspace not found.")
+	}
+
+	events = make([]*WorkspaceEvent, 0)
+	query := sb.Select("id", "workspace_id", "phase", "message", "created_at").
+		From("workspace_events").
+		Where(sq.Eq{"workspace_id": workspace.ID}).
+		OrderBy("created_at", "id")
+	query = *request.ApplyPaginationToSelect(&query)
+
+	err = c.DB.Selectx(&events, query)
+
+	return
+}
+
+// CountWorkspaceEvents returns the number of status transitions of the workspace with the uid
+func (c *Client) CountWorkspaceEvents(namespace, uid string) (count int, err error) {
+	err = sb.Select("COUNT(*)").
+		From("workspace_events we").
+		Join("workspaces w ON w.id = we.workspace_id").
+		Where(sq.Eq{
+			"w.namespace": namespace,
+			"w.uid":       uid,
+		}).
+		Where(sq.NotEq{"w.phase": WorkspaceTerminated}).
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&count)
+
+	return
+}
+
+// GetWorkspaceMetrics returns the current resource usage of the pod running the workspace, along with the requests
+// and limits of its containers. A codes.Unavailable error is returned if the metrics server is not installed.
+func (c *Client) GetWorkspaceMetrics(namespace, uid string) (metrics *WorkspaceMetrics, err error) {
+	workspace, err := c.GetWorkspace(namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, util.NewUserError(codes.NotFound, "Workspace not found.")
+	}
+
+	pods, err := c.CoreV1().Pods(namespace).List(ListOptions{
+		LabelSelector: fmt.Sprintf("app=%v", uid),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, util.NewUserError(codes.FailedPrecondition, "Workspace is not running.")
+	}
+	pod := pods.Items[0]
+
+	podMetrics, err := c.getPodMetrics(namespace, pod.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]ContainerMetrics)
+	for _, container := range podMetrics.Containers {
+		usage[container.Name] = container
+	}
+
+	metrics = &WorkspaceMetrics{
+		PodName: pod.Name,
+	}
+	for _, container := range pod.Spec.Containers {
+		containerUsage := usage[container.Name].Usage
+		metrics.Containers = append(metrics.Containers, &WorkspaceContainerMetrics{
+			Name:          container.Name,
+			CPUUsage:      containerUsage.Cpu().MilliValue(),
+			CPURequest:    container.Resources.Requests.Cpu().MilliValue(),
+			CPULimit:      container.Resources.Limits.Cpu().MilliValue(),
+			MemoryUsage:   containerUsage.Memory().Value(),
+			MemoryRequest: container.Resources.Requests.Memory().Value(),
+			MemoryLimit:   container.Resources.Limits.Memory().Value(),
+		})
+	}
+
 	return
 }
 
@@ -686,8 +949,9 @@ func (c *Client) CountWorkspaces(namespace string, request *request.Request) (co
 	return
 }
 
-// updateWorkspace updates the workspace to the indicated status
-func (c *Client) updateWorkspace(namespace, uid, workspaceAction, resourceAction string, status *WorkspaceStatus, parameters ...Parameter) (err error) {
+// updateWorkspace updates the workspace to the indicated status.
+// If replicas is more than 0, the workspace is scaled to that number of replicas.
+func (c *Client) updateWorkspace(namespace, uid, workspaceAction, resourceAction string, status *WorkspaceStatus, replicas int32, parameters ...Parameter) (err error) {
 	workspace, err := c.GetWorkspace(namespace, uid)
 	if err != nil {
 		return util.NewUserError(codes.Unknown, err.Error())
@@ -695,6 +959,9 @@ func (c *Client) updateWorkspace(namespace, uid, workspaceAction, resourceAction
 	if workspace == nil {
 		return util.NewUserError(codes.NotFound, "Workspace not found.")
 	}
+	if replicas > 0 {
+		workspace.Replicas = replicas
+	}
 
 	config, err := c.GetSystemConfig()
 	if err != nil {
@@ -756,40 +1023,59 @@ func (c *Client) updateWorkspace(namespace, uid, workspaceAction, resourceAction
 
 	// Update parameters if they are passed in
 	if len(parameters) != 0 {
-		sb.Set("parameters", parametersJSON)
+		sb = sb.Set("parameters", parametersJSON)
+	}
+	if replicas > 0 {
+		sb = sb.Set("replicas", replicas)
 	}
 
-	_, err = sb.RunWith(c.DB).
-		Exec()
+	var workspaceID uint64
+	err = sb.Suffix("RETURNING id").
+		RunWith(c.DB).
+		QueryRow().
+		Scan(&workspaceID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return
+	}
 
-	return
+	return createWorkspaceEventDB(c.DB, workspaceID, status.Phase)
 }
 
-func (c *Client) UpdateWorkspace(namespace, uid string, parameters []Parameter) (err error) {
-	return c.updateWorkspace(namespace, uid, "update", "apply", &WorkspaceStatus{Phase: WorkspaceUpdating}, parameters...)
+// UpdateWorkspace updates the parameters of the workspace and scales it to replicas.
+// If replicas is 0, the workspace keeps its number of replicas.
+func (c *Client) UpdateWorkspace(namespace, uid string, parameters []Parameter, replicas int32) (err error) {
+	if replicas != 0 {
+		if err := validateWorkspaceReplicas(replicas); err != nil {
+			return err
+		}
+	}
+
+	return c.updateWorkspace(namespace, uid, "update", "apply", &WorkspaceStatus{Phase: WorkspaceUpdating}, replicas, parameters...)
 }
 
 func (c *Client) PauseWorkspace(namespace, uid string) (err error) {
-	return c.updateWorkspace(namespace, uid, "pause", "delete", &WorkspaceStatus{Phase: WorkspacePausing})
+	return c.updateWorkspace(namespace, uid, "pause", "delete", &WorkspaceStatus{Phase: WorkspacePausing}, 0)
 }
 
 func (c *Client) ResumeWorkspace(namespace, uid string) (err error) {
-	return c.updateWorkspace(namespace, uid, "create", "apply", &WorkspaceStatus{Phase: WorkspaceLaunching})
+	return c.updateWorkspace(namespace, uid, "create", "apply", &WorkspaceStatus{Phase: WorkspaceLaunching}, 0)
 }
 
 func (c *Client) DeleteWorkspace(namespace, uid string) (err error) {
-	return c.updateWorkspace(namespace, uid, "delete", "delete", &WorkspaceStatus{Phase: WorkspaceTerminating})
+	return c.updateWorkspace(namespace, uid, "delete", "delete", &WorkspaceStatus{Phase: WorkspaceTerminating}, 0)
 }
 
 // ArchiveWorkspace archives by setting the workspace to delete or terminate.
 // Kicks off DB archiving and k8s cleaning.
 func (c *Client) ArchiveWorkspace(namespace, uid string, parameters ...Parameter) (err error) {
-	return c.updateWorkspace(namespace, uid, "delete", "delete", &WorkspaceStatus{Phase: WorkspaceTerminating}, parameters...)
+	return c.updateWorkspace(namespace, uid, "delete", "delete", &WorkspaceStatus{Phase: WorkspaceTerminating}, 0, parameters...)
 }
 
-// GetWorkspaceStatisticsForNamespace loads statistics for workspaces for the provided namespace
-func (c *Client) GetWorkspaceStatisticsForNamespace(namespace string) (report *WorkspaceStatisticReport, err error) {
-	statsSelect := `
+// workspaceStatisticsSelect counts the workspaces in each phase, used by the workspace statistic reports
+const workspaceStatisticsSelect = `
 		MAX(w.created_at) last_created,
 		COUNT(*) FILTER (WHERE phase = 'Launching') launching,
 		COUNT(*) FILTER (WHERE phase = 'Running') running,
@@ -806,7 +1092,9 @@ func (c *Client) GetWorkspaceStatisticsForNamespace(namespace string) (report *W
 		COUNT(*) FILTER (WHERE phase LIKE 'Failed%') failed,
 		COUNT(*) total`
 
-	query := sb.Select(statsSelect).
+// GetWorkspaceStatisticsForNamespace loads statistics for workspaces for the provided namespace
+func (c *Client) GetWorkspaceStatisticsForNamespace(namespace string) (report *WorkspaceStatisticReport, err error) {
+	query := sb.Select(workspaceStatisticsSelect).
 		From("workspaces w").
 		Where(sq.Eq{
 			"w.namespace": namespace,
@@ -817,3 +1105,44 @@ func (c *Client) GetWorkspaceStatisticsForNamespace(namespace string) (report *W
 
 	return
 }
+
+// GetWorkspaceStatisticsForNamespaces loads statistics for workspaces, one report per namespace.
+// If no namespaces are provided, a report is returned for every namespace that has workspaces.
+// Requested namespaces without any workspaces get an empty report.
+func (c *Client) GetWorkspaceStatisticsForNamespaces(namespaces []string) (reports []*NamespaceWorkspaceStatisticReport, err error) {
+	query := sb.Select("w.namespace", workspaceStatisticsSelect).
+		From("workspaces w").
+		GroupBy("w.namespace").
+		OrderBy("w.namespace")
+
+	if len(namespaces) > 0 {
+		query = query.Where(sq.Eq{
+			"w.namespace": namespaces,
+		})
+	}
+
+	reports = make([]*NamespaceWorkspaceStatisticReport, 0)
+	if err = c.DB.Selectx(&reports, query); err != nil {
+		return nil, err
+	}
+
+	if len(namespaces) == 0 {
+		return
+	}
+
+	reportsByNamespace := make(map[string]*NamespaceWorkspaceStatisticReport)
+	for _, report := range reports {
+		reportsByNamespace[report.Namespace] = report
+	}
+
+	result := make([]*NamespaceWorkspaceStatisticReport, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		report, ok := reportsByNamespace[namespace]
+		if !ok {
+			report = &NamespaceWorkspaceStatisticReport{Namespace: namespace}
+		}
+		result = append(result, report)
+	}
+
+	return result, nil
+}
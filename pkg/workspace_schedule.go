@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// validateWorkspaceSchedule makes sure start and stop are both set, or both empty, and are cron expressions,
+// like 0 8 * * 1-5, and that the timezone, if set, is known
+func validateWorkspaceSchedule(start, stop, timezone string) error {
+	if start == "" && stop == "" {
+		if timezone != "" {
+			return util.NewUserError(codes.InvalidArgument, "Schedule timezone can only be set along with a schedule.")
+		}
+		return nil
+	}
+	if start == "" || stop == "" {
+		return util.NewUserError(codes.InvalidArgument, "Both the schedule start and stop must be set.")
+	}
+
+	if _, err := cron.ParseStandard(start); err != nil {
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Schedule start is not a valid cron expression: %v.", err))
+	}
+	if _, err := cron.ParseStandard(stop); err != nil {
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Schedule stop is not a valid cron expression: %v.", err))
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return util.NewUserError(codes.InvalidArgument, fmt.Sprintf("Schedule timezone %v is not known.", timezone))
+	}
+
+	return nil
+}
+
+// parseWorkspaceSchedule parses the start and stop cron expressions and the timezone of the workspace schedule
+func parseWorkspaceSchedule(workspace *Workspace) (start, stop cron.Schedule, location *time.Location, err error) {
+	start, err = cron.ParseStandard(workspace.ScheduleStart)
+	if err != nil {
+		return
+	}
+	stop, err = cron.ParseStandard(workspace.ScheduleStop)
+	if err != nil {
+		return
+	}
+	location, err = time.LoadLocation(workspace.ScheduleTimezone)
+
+	return
+}
+
+// workspaceScheduledToRun returns true if the workspace should be running at now according to its schedule.
+// It should be running if, in its timezone, the schedule stops it before it next starts it.
+func workspaceScheduledToRun(workspace *Workspace, now time.Time) (bool, error) {
+	start, stop, location, err := parseWorkspaceSchedule(workspace)
+	if err != nil {
+		return false, err
+	}
+
+	now = now.In(location)
+	nextStart := start.Next(now)
+	nextStop := stop.Next(now)
+	if nextStop.IsZero() {
+		return false, nil
+	}
+	if nextStart.IsZero() {
+		return true, nil
+	}
+
+	return nextStop.Before(nextStart), nil
+}
+
+// workspaceScheduleFired returns true if the schedule started or stopped the workspace after lastRun, up to now
+func workspaceScheduleFired(workspace *Workspace, lastRun, now time.Time) (bool, error) {
+	start, stop, location, err := parseWorkspaceSchedule(workspace)
+	if err != nil {
+		return false, err
+	}
+
+	lastRun = lastRun.In(location)
+	for _, schedule := range []cron.Schedule{start, stop} {
+		if next := schedule.Next(lastRun); !next.IsZero() && !next.After(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ReconcileWorkspaceSchedules pauses the running workspaces of every namespace whose schedule stopped them since the
+// last reconciliation, and resumes the paused ones whose schedule started them, at now.
+// Only the start and stop times act on a workspace, so one that was paused or resumed by hand in between is left alone
+// until the next one. A workspace seen for the first time is only recorded, as there is no last reconciliation to compare to.
+// Workspaces without a schedule, or in any other phase, like launching, are left as is.
+// A workspace that can not be paused or resumed is logged and does not stop the others.
+func (c *Client) ReconcileWorkspaceSchedules(now time.Time) error {
+	workspaces := make([]*Workspace, 0)
+	query := sb.Select("id", "namespace", "uid", "phase \"status.phase\"", "schedule_start", "schedule_stop", "schedule_timezone", "schedule_reconciled_at").
+		From("workspaces").
+		Where(sq.Eq{
+			"phase": []WorkspacePhase{WorkspaceRunning, WorkspacePaused},
+		}).
+		Where(sq.NotEq{
+			"schedule_start": "",
+		})
+	if err := c.DB.Selectx(&workspaces, query); err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		ids = append(ids, workspace.ID)
+		if workspace.ScheduleReconciledAt == nil {
+			continue
+		}
+
+		fired, err := workspaceScheduleFired(workspace, *workspace.ScheduleReconciledAt, now)
+		var shouldRun bool
+		if err == nil && fired {
+			shouldRun, err = workspaceScheduledToRun(workspace, now)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": workspace.Namespace,
+				"UID":       workspace.UID,
+				"Error":     err.Error(),
+			}).Error("Invalid workspace schedule.")
+			continue
+		}
+		if !fired {
+			continue
+		}
+
+		switch {
+		case shouldRun && workspace.Status.Phase == WorkspacePaused:
+			err = c.ResumeWorkspace(workspace.Namespace, workspace.UID)
+		case !shouldRun && workspace.Status.Phase == WorkspaceRunning:
+			err = c.PauseWorkspace(workspace.Namespace, workspace.UID)
+		default:
+			continue
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Namespace": workspace.Namespace,
+				"UID":       workspace.UID,
+				"Phase":     workspace.Status.Phase,
+				"Error":     err.Error(),
+			}).Error("Unable to apply workspace schedule.")
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := sb.Update("workspaces").
+		Set("schedule_reconciled_at", now.UTC()).
+		Where(sq.Eq{"id": ids}).
+		RunWith(c.DB).
+		Exec()
+
+	return err
+}
+
+// RunWorkspaceScheduleReconciler reconciles the workspace schedules every interval until ctx is done.
+// See ReconcileWorkspaceSchedules.
+func (c *Client) RunWorkspaceScheduleReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := c.ReconcileWorkspaceSchedules(now); err != nil {
+				log.WithFields(log.Fields{
+					"Error": err.Error(),
+				}).Error("Unable to reconcile workspace schedules.")
+			}
+		}
+	}
+}
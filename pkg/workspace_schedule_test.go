@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// Test_validateWorkspaceSchedule makes sure a schedule needs both a start and a stop, valid cron expressions and a known timezone
+func Test_validateWorkspaceSchedule(t *testing.T) {
+	assert.Nil(t, validateWorkspaceSchedule("", "", ""))
+	assert.Nil(t, validateWorkspaceSchedule("0 8 * * 1-5", "0 19 * * 1-5", ""))
+	assert.Nil(t, validateWorkspaceSchedule("0 8 * * 1-5", "0 19 * * 1-5", "Europe/Berlin"))
+
+	invalid := [][]string{
+		{"", "", "Europe/Berlin"},
+		{"0 8 * * 1-5", "", ""},
+		{"", "0 19 * * 1-5", ""},
+		{"every morning", "0 19 * * 1-5", ""},
+		{"0 8 * * 1-5", "0 25 * * *", ""},
+		{"0 8 * * 1-5", "0 19 * * 1-5", "Mars/Olympus_Mons"},
+	}
+	for _, schedule := range invalid {
+		err := validateWorkspaceSchedule(schedule[0], schedule[1], schedule[2])
+		assertUserErrorCode(t, err, codes.InvalidArgument)
+	}
+}
+
+// Test_workspaceScheduledToRun makes sure a workspace is scheduled to run between its start and stop, in its timezone
+func Test_workspaceScheduledToRun(t *testing.T) {
+	workspace := &Workspace{
+		ScheduleStart:    "0 8 * * 1-5",
+		ScheduleStop:     "0 19 * * 1-5",
+		ScheduleTimezone: "Europe/Berlin",
+	}
+
+	// Berlin is an hour ahead of UTC in December. December 7, 2020 is a Monday.
+	tests := []struct {
+		now      time.Time
+		expected bool
+	}{
+		{time.Date(2020, 12, 7, 6, 59, 0, 0, time.UTC), false},
+		{time.Date(2020, 12, 7, 7, 0, 0, 0, time.UTC), true},
+		{time.Date(2020, 12, 7, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2020, 12, 7, 17, 59, 0, 0, time.UTC), true},
+		{time.Date(2020, 12, 7, 18, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 12, 7, 23, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 12, 12, 12, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		shouldRun, err := workspaceScheduledToRun(workspace, test.now)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, shouldRun, test.now.String())
+	}
+}
+
+// TestClient_ReconcileWorkspaceSchedules makes sure running workspaces are paused outside their schedule,
+// paused ones are resumed inside it, and workspaces without a schedule are left as is
+func TestClient_ReconcileWorkspaceSchedules(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	scheduled := createTestWorkspace(t, c, namespace, "scheduled")
+	unscheduled := createTestWorkspace(t, c, namespace, "unscheduled")
+
+	_, err := sb.Update("workspaces").
+		SetMap(sq.Eq{
+			"schedule_start":    "0 8 * * 1-5",
+			"schedule_stop":     "0 19 * * 1-5",
+			"schedule_timezone": "Europe/Berlin",
+		}).
+		Where(sq.Eq{"uid": scheduled.UID}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setPhase := func(uid string, phase WorkspacePhase) {
+		if err := c.UpdateWorkspaceStatus(namespace, uid, &WorkspaceStatus{Phase: phase}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertPhase := func(uid string, phase WorkspacePhase) {
+		workspace, err := c.GetWorkspace(namespace, uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, phase, workspace.Status.Phase, uid)
+	}
+
+	setPhase(scheduled.UID, WorkspaceRunning)
+	setPhase(unscheduled.UID, WorkspaceRunning)
+
+	// Monday at 10 in Berlin, inside the window. The first reconciliation only records the workspaces.
+	morning := time.Date(2020, 12, 7, 9, 0, 0, 0, time.UTC)
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(morning))
+	assertPhase(scheduled.UID, WorkspaceRunning)
+
+	// Monday at 20 in Berlin, outside the window
+	evening := time.Date(2020, 12, 7, 19, 0, 0, 0, time.UTC)
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(evening))
+	assertPhase(scheduled.UID, WorkspacePausing)
+	assertPhase(unscheduled.UID, WorkspaceRunning)
+
+	setPhase(scheduled.UID, WorkspacePaused)
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(evening))
+	assertPhase(scheduled.UID, WorkspacePaused)
+
+	// Tuesday at 10 in Berlin
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(morning.Add(24*time.Hour)))
+	assertPhase(scheduled.UID, WorkspaceLaunching)
+	assertPhase(unscheduled.UID, WorkspaceRunning)
+}
+
+// TestClient_ReconcileWorkspaceSchedules_ManualPause makes sure a workspace paused by hand inside its schedule is not
+// resumed until the schedule next starts it
+func TestClient_ReconcileWorkspaceSchedules_ManualPause(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	workspace := createTestWorkspace(t, c, namespace, "scheduled")
+
+	_, err := sb.Update("workspaces").
+		SetMap(sq.Eq{
+			"schedule_start":    "0 8 * * 1-5",
+			"schedule_stop":     "0 19 * * 1-5",
+			"schedule_timezone": "Europe/Berlin",
+		}).
+		Where(sq.Eq{"uid": workspace.UID}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateWorkspaceStatus(namespace, workspace.UID, &WorkspaceStatus{Phase: WorkspaceRunning}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Monday at 10 in Berlin, inside the window
+	morning := time.Date(2020, 12, 7, 9, 0, 0, 0, time.UTC)
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(morning))
+
+	if err := c.UpdateWorkspaceStatus(namespace, workspace.UID, &WorkspaceStatus{Phase: WorkspacePaused}); err != nil {
+		t.Fatal(err)
+	}
+	for _, now := range []time.Time{morning.Add(time.Hour), morning.Add(3 * time.Hour), morning.Add(9 * time.Hour)} {
+		assert.Nil(t, c.ReconcileWorkspaceSchedules(now))
+		paused, err := c.GetWorkspace(namespace, workspace.UID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, WorkspacePaused, paused.Status.Phase, now.String())
+	}
+
+	// Tuesday at 10 in Berlin, the schedule started it again
+	assert.Nil(t, c.ReconcileWorkspaceSchedules(morning.Add(24*time.Hour)))
+	resumed, err := c.GetWorkspace(namespace, workspace.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, WorkspaceLaunching, resumed.Status.Phase)
+}
@@ -870,7 +870,7 @@ func (c *Client) createWorkspaceTemplate(namespace string, workspaceTemplate *Wo
 		QueryRow().
 		Scan(&workspaceTemplate.ID, &workspaceTemplate.CreatedAt)
 	if err != nil {
-		_, errCleanUp := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID)
+		_, errCleanUp := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID, false)
 		errorMsg := "Error with insert into workspace_templates. "
 		if errCleanUp != nil {
 			errorMsg += "Error with clean-up: ArchiveWorkflowTemplate. "
@@ -882,7 +882,7 @@ func (c *Client) createWorkspaceTemplate(namespace string, workspaceTemplate *Wo
 	err = createWorkspaceTemplateVersionDB(tx, workspaceTemplate)
 	if err != nil {
 		errorMsg := "Error with insert into workspace_templates_versions. "
-		_, errCleanUp := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID)
+		_, errCleanUp := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID, false)
 		if errCleanUp != nil {
 			err = fmt.Errorf("%w; %s", err, errCleanUp)
 			errorMsg += "Error with clean-up: ArchiveWorkflowTemplate. "
@@ -891,7 +891,7 @@ func (c *Client) createWorkspaceTemplate(namespace string, workspaceTemplate *Wo
 	}
 
 	if err = tx.Commit(); err != nil {
-		_, errArchive := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID)
+		_, errArchive := c.ArchiveWorkflowTemplate(namespace, workspaceTemplate.WorkflowTemplate.UID, false)
 		if errArchive != nil {
 			err = fmt.Errorf("%w; %s", err, errArchive)
 		}
@@ -1352,7 +1352,7 @@ func (c *Client) ArchiveWorkspaceTemplate(namespace string, uid string) (archive
 		return false, util.NewUserError(codes.Unknown, "Unable to archive workspace template.")
 	}
 
-	_, err = c.ArchiveWorkflowTemplate(namespace, wsTemp.UID)
+	_, err = c.ArchiveWorkflowTemplate(namespace, wsTemp.UID, false)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"Namespace": namespace,
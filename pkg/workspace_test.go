@@ -1,11 +1,23 @@
 package v1
 
 import (
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	argoFake "github.com/argoproj/argo/pkg/client/clientset/versioned/fake"
+	"github.com/ghodss/yaml"
 	"github.com/lib/pq"
 	"github.com/onepanelio/core/pkg/util"
 	"github.com/onepanelio/core/pkg/util/ptr"
+	"github.com/onepanelio/core/pkg/util/request"
+	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"testing"
 	"time"
 )
@@ -118,6 +130,256 @@ func testClientPrivateCreateWorkspaceSuccess(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// TestClient_GetWorkspaceEvents makes sure status transitions are recorded and returned oldest first
+func TestClient_GetWorkspaceEvents(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	workspace := &Workspace{
+		Name: "test",
+		Parameters: []Parameter{
+			{
+				Name:  "workflow-execution-name",
+				Value: ptr.String("test"),
+			},
+		},
+		WorkspaceTemplate: &WorkspaceTemplate{
+			Name:     "test",
+			Manifest: jupyterLabWorkspaceManifest,
+		},
+	}
+	workspace.GenerateUID("test")
+
+	if _, err := c.CreateWorkspaceTemplate(namespace, workspace.WorkspaceTemplate); err != nil {
+		t.Fatal(err)
+	}
+	ws, err := c.createWorkspace(namespace, []byte("[]"), workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phases := []WorkspacePhase{WorkspaceRunning, WorkspacePausing, WorkspacePaused}
+	for _, phase := range phases {
+		if err := c.UpdateWorkspaceStatus(namespace, ws.UID, &WorkspaceStatus{Phase: phase}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := c.GetWorkspaceEvents(namespace, ws.UID, &request.Request{})
+	assert.Nil(t, err)
+	assert.Len(t, events, 4)
+
+	count, err := c.CountWorkspaceEvents(namespace, ws.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, count)
+
+	lastPage, err := c.GetWorkspaceEvents(namespace, ws.UID, &request.Request{Pagination: pagination.New(2, 3)})
+	assert.Nil(t, err)
+	if assert.Len(t, lastPage, 1) {
+		assert.Equal(t, WorkspacePaused, lastPage[0].Phase)
+	}
+
+	expected := append([]WorkspacePhase{WorkspaceLaunching}, phases...)
+	for i, event := range events {
+		assert.Equal(t, expected[i], event.Phase)
+		assert.Equal(t, workspaceEventMessages[expected[i]], event.Message)
+		if i > 0 {
+			assert.False(t, event.CreatedAt.Before(events[i-1].CreatedAt))
+		}
+	}
+}
+
+// fakePodMetricsSource returns the same metrics for every pod
+type fakePodMetricsSource struct {
+	podMetrics *PodMetrics
+}
+
+func (f *fakePodMetricsSource) GetPodMetrics(namespace, name string) (*PodMetrics, error) {
+	return f.podMetrics, nil
+}
+
+// createRunningWorkspacePod creates a workspace along with a pod for it, as if the workspace was running
+func createRunningWorkspacePod(t *testing.T, c *Client, namespace string) *Workspace {
+	workspace := &Workspace{
+		Name: "test",
+		Parameters: []Parameter{
+			{
+				Name:  "workflow-execution-name",
+				Value: ptr.String("test"),
+			},
+		},
+		WorkspaceTemplate: &WorkspaceTemplate{
+			Name:     "test",
+			Manifest: jupyterLabWorkspaceManifest,
+		},
+	}
+	workspace.GenerateUID("test")
+
+	if _, err := c.CreateWorkspaceTemplate(namespace, workspace.WorkspaceTemplate); err != nil {
+		t.Fatal(err)
+	}
+	ws, err := c.createWorkspace(namespace, []byte("[]"), workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CoreV1().Pods(namespace).Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ws.UID + "-0",
+			Labels: map[string]string{"app": ws.UID},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "jupyterlab",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ws
+}
+
+func TestClient_GetWorkspaceMetrics(t *testing.T) {
+	c := DefaultTestClient().WithPodMetricsSource(&fakePodMetricsSource{
+		podMetrics: &PodMetrics{
+			Containers: []ContainerMetrics{
+				{
+					Name: "jupyterlab",
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+			},
+		},
+	})
+	c.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: metricsGroupVersion},
+	}
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	ws := createRunningWorkspacePod(t, c, namespace)
+
+	metrics, err := c.GetWorkspaceMetrics(namespace, ws.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, ws.UID+"-0", metrics.PodName)
+	assert.Len(t, metrics.Containers, 1)
+
+	container := metrics.Containers[0]
+	assert.Equal(t, "jupyterlab", container.Name)
+	assert.Equal(t, int64(250), container.CPUUsage)
+	assert.Equal(t, int64(500), container.CPURequest)
+	assert.Equal(t, int64(2000), container.CPULimit)
+	assert.Equal(t, int64(512*1024*1024), container.MemoryUsage)
+	assert.Equal(t, int64(1024*1024*1024), container.MemoryRequest)
+	assert.Equal(t, int64(4*1024*1024*1024), container.MemoryLimit)
+}
+
+// TestClient_GetWorkspaceMetrics_Unavailable makes sure codes.Unavailable is returned when there is no metrics server
+func TestClient_GetWorkspaceMetrics_Unavailable(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	ws := createRunningWorkspacePod(t, c, namespace)
+
+	_, err := c.GetWorkspaceMetrics(namespace, ws.UID)
+	userErr, ok := err.(*util.UserError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, userErr.Code)
+}
+
+// setWorkspacePodReady sets the phase and ContainersReady condition of the workspace pod
+func setWorkspacePodReady(t *testing.T, c *Client, namespace, name string, ready bool) {
+	pod, err := c.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+		{Type: corev1.ContainersReady, Status: status},
+	}
+
+	if _, err := c.CoreV1().Pods(namespace).UpdateStatus(pod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClient_GetWorkspaceReady makes sure the workspace is only ready once the containers of all its pods are ready,
+// and that GetWorkspace does not list the pods for it
+func TestClient_GetWorkspaceReady(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	ws := createRunningWorkspacePod(t, c, namespace)
+
+	assert.False(t, c.GetWorkspaceReady(namespace, ws.UID))
+
+	setWorkspacePodReady(t, c, namespace, ws.UID+"-0", true)
+	assert.True(t, c.GetWorkspaceReady(namespace, ws.UID))
+
+	workspace, err := c.GetWorkspace(namespace, ws.UID)
+	assert.Nil(t, err)
+	assert.False(t, workspace.Ready)
+
+	_, err = c.CoreV1().Pods(namespace).Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ws.UID + "-1",
+			Labels: map[string]string{"app": ws.UID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setWorkspacePodReady(t, c, namespace, ws.UID+"-1", false)
+
+	assert.False(t, c.GetWorkspaceReady(namespace, ws.UID))
+}
+
+func Test_workspacePodsReady(t *testing.T) {
+	readyPod := corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	notReadyPod := *readyPod.DeepCopy()
+	notReadyPod.Status.Conditions[0].Status = corev1.ConditionFalse
+	pendingPod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}
+
+	assert.False(t, workspacePodsReady(nil))
+	assert.True(t, workspacePodsReady([]corev1.Pod{readyPod}))
+	assert.True(t, workspacePodsReady([]corev1.Pod{readyPod, readyPod}))
+	assert.False(t, workspacePodsReady([]corev1.Pod{readyPod, notReadyPod}))
+	assert.False(t, workspacePodsReady([]corev1.Pod{pendingPod}))
+}
+
 func TestClient_createWorkspace(t *testing.T) {
 	testClientPrivateCreateWorkspaceNoWorkflowTemplate(t)
 	testClientPrivateCreateWorkspaceSuccess(t)
@@ -156,8 +418,359 @@ func testClientCreateWorkspaceSuccess(t *testing.T) {
 	assert.NotNil(t, createdWorkspace)
 }
 
+// fakeVolumeSnapshotSource returns the snapshots it was created with
+type fakeVolumeSnapshotSource struct {
+	snapshots []VolumeSnapshot
+}
+
+func (f *fakeVolumeSnapshotSource) GetVolumeSnapshot(namespace, name string) (*VolumeSnapshot, error) {
+	for i := range f.snapshots {
+		if f.snapshots[i].Namespace == namespace && f.snapshots[i].Name == name {
+			return &f.snapshots[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func testClientCreateWorkspaceFromSnapshot(t *testing.T) {
+	c := DefaultTestClient().WithVolumeSnapshotSource(&fakeVolumeSnapshotSource{
+		snapshots: []VolumeSnapshot{
+			{Name: "data-snapshot", Namespace: "onepanel", PersistentVolumeClaimName: "data-previous-0"},
+			{Name: "other-snapshot", Namespace: "other", PersistentVolumeClaimName: "data-previous-0"},
+		},
+	})
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	testTemplate, err := c.CreateWorkspaceTemplate(namespace, &WorkspaceTemplate{
+		Name:     "test",
+		Manifest: jupyterLabWorkspaceManifest,
+	})
+	assert.Nil(t, err)
+
+	newWorkspace := func(name, snapshot string) *Workspace {
+		return &Workspace{
+			Name: name,
+			WorkspaceTemplate: &WorkspaceTemplate{
+				UID:     testTemplate.UID,
+				Version: testTemplate.Version,
+			},
+			Parameters: []Parameter{
+				{
+					Name:  "sys-data-volume-size",
+					Value: ptr.String("1024"),
+				},
+			},
+			SourceSnapshotID: snapshot,
+		}
+	}
+
+	_, err = c.CreateWorkspace(namespace, newWorkspace("missing", "missing-snapshot"))
+	assertUserErrorCode(t, err, codes.NotFound)
+
+	_, err = c.CreateWorkspace(namespace, newWorkspace("other", "other/other-snapshot"))
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	createdWorkspace, err := c.CreateWorkspace(namespace, newWorkspace("restored", "onepanel/data-snapshot"))
+	assert.Nil(t, err)
+	assert.NotNil(t, createdWorkspace)
+
+	pvc, err := c.CoreV1().PersistentVolumeClaims(namespace).Get("data-"+createdWorkspace.UID+"-0", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.String("snapshot.storage.k8s.io"),
+		Kind:     "VolumeSnapshot",
+		Name:     "data-snapshot",
+	}, pvc.Spec.DataSource)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("1024Mi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+}
+
+// testClientCreateWorkspaceFromSnapshotFailed makes sure the volume created from a snapshot is deleted
+// if the workspace can not be created
+func testClientCreateWorkspaceFromSnapshotFailed(t *testing.T) {
+	argoFakeClient := argoFake.NewSimpleClientset()
+	argoFakeClient.PrependReactor("create", "workflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("workflows are not accepted")
+	})
+
+	c := DefaultTestClient().WithVolumeSnapshotSource(&fakeVolumeSnapshotSource{
+		snapshots: []VolumeSnapshot{
+			{Name: "data-snapshot", Namespace: "onepanel", PersistentVolumeClaimName: "data-previous-0"},
+		},
+	})
+	c.argoprojV1alpha1 = argoFakeClient.ArgoprojV1alpha1()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	testTemplate, err := c.CreateWorkspaceTemplate(namespace, &WorkspaceTemplate{
+		Name:     "test",
+		Manifest: jupyterLabWorkspaceManifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateWorkspace(namespace, &Workspace{
+		Name: "restored",
+		WorkspaceTemplate: &WorkspaceTemplate{
+			UID:     testTemplate.UID,
+			Version: testTemplate.Version,
+		},
+		Parameters: []Parameter{
+			{
+				Name:  "sys-data-volume-size",
+				Value: ptr.String("1024"),
+			},
+		},
+		SourceSnapshotID: "onepanel/data-snapshot",
+	})
+	assert.NotNil(t, err)
+
+	claims, err := c.CoreV1().PersistentVolumeClaims(namespace).List(metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, claims.Items)
+}
+
 func TestClient_CreateWorkspace(t *testing.T) {
 	testClientCreateWorkspaceSuccess(t)
+	testClientCreateWorkspaceFromSnapshot(t)
+	testClientCreateWorkspaceFromSnapshotFailed(t)
+}
+
+// workspaceStatefulSetReplicas returns the replicas of the statefulset applied by the workflow of the workspace action
+func workspaceStatefulSetReplicas(t *testing.T, c *Client, namespace, workspaceAction string) interface{} {
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, wf := range workflows.Items {
+		parameters := make(map[string]string)
+		for _, p := range wf.Spec.Arguments.Parameters {
+			if p.Value != nil {
+				parameters[p.Name] = *p.Value
+			}
+		}
+		if parameters["sys-workspace-action"] != workspaceAction {
+			continue
+		}
+
+		for _, template := range wf.Spec.Templates {
+			if template.Name != WorkspaceStatefulSetResource {
+				continue
+			}
+
+			statefulSet := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(template.Resource.Manifest), &statefulSet); err != nil {
+				t.Fatal(err)
+			}
+
+			return statefulSet["spec"].(map[string]interface{})["replicas"]
+		}
+	}
+
+	t.Fatalf("no workflow for workspace action %v", workspaceAction)
+	return nil
+}
+
+// TestClient_CreateWorkspace_Replicas makes sure the replicas of a workspace are applied to its statefulset,
+// and updating them scales the workspace instead of recreating it
+func TestClient_CreateWorkspace_Replicas(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	workspaceTemplate, err := c.CreateWorkspaceTemplate(namespace, &WorkspaceTemplate{
+		Name:     "test",
+		Manifest: jupyterLabWorkspaceManifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newWorkspace := func(name string, replicas int32) *Workspace {
+		return &Workspace{
+			Name: name,
+			WorkspaceTemplate: &WorkspaceTemplate{
+				UID:     workspaceTemplate.UID,
+				Version: workspaceTemplate.Version,
+			},
+			Parameters: []Parameter{
+				{
+					Name:  "workflow-execution-name",
+					Value: ptr.String(name),
+				},
+			},
+			Replicas: replicas,
+		}
+	}
+
+	_, err = c.CreateWorkspace(namespace, newWorkspace("invalid", -1))
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	created, err := c.CreateWorkspace(namespace, newWorkspace("test", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, float64(3), workspaceStatefulSetReplicas(t, c, namespace, "create"))
+
+	workspace, err := c.GetWorkspace(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), workspace.Replicas)
+
+	err = c.UpdateWorkspace(namespace, created.UID, nil, -1)
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	if err := c.UpdateWorkspace(namespace, created.UID, nil, 2); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, float64(2), workspaceStatefulSetReplicas(t, c, namespace, "update"))
+
+	updated, err := c.GetWorkspace(namespace, created.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, workspace.ID, updated.ID)
+	assert.Equal(t, int32(2), updated.Replicas)
+	assert.Equal(t, WorkspaceUpdating, updated.Status.Phase)
+}
+
+// TestClient_UpdateWorkspace_Parameters makes sure the parameters passed to UpdateWorkspace are saved,
+// replacing the ones with the same name and keeping the others
+func TestClient_UpdateWorkspace_Parameters(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	created := createTestWorkspace(t, c, namespace, "test")
+
+	if err := c.UpdateWorkspace(namespace, created.UID, []Parameter{{Name: "sys-node-pool", Value: ptr.String("Standard_D4s_v3")}}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateWorkspace(namespace, created.UID, []Parameter{{Name: "gpu-count", Value: ptr.String("1")}}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := c.GetWorkspace(namespace, created.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameters := make(map[string]string)
+	for _, parameter := range updated.Parameters {
+		if parameter.Value != nil {
+			parameters[parameter.Name] = *parameter.Value
+		}
+	}
+	assert.Equal(t, "Standard_D4s_v3", parameters["sys-node-pool"])
+	assert.Equal(t, "1", parameters["gpu-count"])
+}
+
+// workspaceTemplateContainer returns the workspace template container of the statefulset created for the workspace,
+// the one after the node capturer
+func workspaceTemplateContainer(t *testing.T, c *Client, namespace, uid string) map[string]interface{} {
+	workflows, err := c.ArgoprojV1alpha1().Workflows(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, wf := range workflows.Items {
+		parameters := make(map[string]string)
+		for _, p := range wf.Spec.Arguments.Parameters {
+			if p.Value != nil {
+				parameters[p.Name] = *p.Value
+			}
+		}
+		if parameters["sys-uid"] != uid || parameters["sys-workspace-action"] != "create" {
+			continue
+		}
+
+		for _, template := range wf.Spec.Templates {
+			if template.Name != WorkspaceStatefulSetResource {
+				continue
+			}
+
+			statefulSet := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(template.Resource.Manifest), &statefulSet); err != nil {
+				t.Fatal(err)
+			}
+
+			templateSpec := statefulSet["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+			containers := templateSpec["containers"].([]interface{})
+			if len(containers) != 2 {
+				t.Fatalf("expected 2 containers, got %v", len(containers))
+			}
+
+			return containers[1].(map[string]interface{})
+		}
+	}
+
+	t.Fatalf("no create workflow for workspace %v", uid)
+	return nil
+}
+
+// TestClient_CreateWorkspace_Command makes sure the command and args of a workspace override the ones of the
+// workspace template container, and the template ones are kept if the workspace does not set them
+func TestClient_CreateWorkspace_Command(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+
+	workspaceTemplate, err := c.CreateWorkspaceTemplate(namespace, &WorkspaceTemplate{
+		Name:     "test",
+		Manifest: jupyterLabWorkspaceManifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newWorkspace := func(name string, command, args []string) *Workspace {
+		return &Workspace{
+			Name: name,
+			WorkspaceTemplate: &WorkspaceTemplate{
+				UID:     workspaceTemplate.UID,
+				Version: workspaceTemplate.Version,
+			},
+			Parameters: []Parameter{
+				{
+					Name:  "workflow-execution-name",
+					Value: ptr.String(name),
+				},
+			},
+			Command: command,
+			Args:    args,
+		}
+	}
+
+	_, err = c.CreateWorkspace(namespace, newWorkspace("invalid", []string{"python", ""}, nil))
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	_, err = c.CreateWorkspace(namespace, newWorkspace("invalid", nil, []string{" "}))
+	assertUserErrorCode(t, err, codes.InvalidArgument)
+
+	defaultWorkspace, err := c.CreateWorkspace(namespace, newWorkspace("default", nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	container := workspaceTemplateContainer(t, c, namespace, defaultWorkspace.UID)
+	assert.Equal(t, []interface{}{"start.sh", "jupyter"}, container["command"])
+	assert.Equal(t, "lab", container["args"].([]interface{})[0])
+
+	overridden, err := c.CreateWorkspace(namespace, newWorkspace("overridden", []string{"python"}, []string{"-m", "http.server"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	container = workspaceTemplateContainer(t, c, namespace, overridden.UID)
+	assert.Equal(t, []interface{}{"python"}, container["command"])
+	assert.Equal(t, []interface{}{"-m", "http.server"}, container["args"])
+
+	workspace, err := c.GetWorkspace(namespace, overridden.UID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"python"}, []string(workspace.Command))
+	assert.Equal(t, []string{"-m", "http.server"}, []string(workspace.Args))
 }
 
 func TestClient_ArchiveWorkspace(t *testing.T) {
@@ -312,3 +925,143 @@ func Test_UpdateWorkspaceStatus(t *testing.T) {
 	testUpdateWorkspaceStatusSuccess(t)
 	testUpdateWorkspaceStatusNotFound(t)
 }
+
+// createTestWorkspace creates a workspace named name, along with its workspace template, in the namespace
+func createTestWorkspace(t *testing.T, c *Client, namespace, name string) *Workspace {
+	workspaceTemplate, err := c.CreateWorkspaceTemplate(namespace, &WorkspaceTemplate{
+		Name:     name + "-template",
+		Manifest: jupyterLabWorkspaceManifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workspace := &Workspace{
+		Name:              name,
+		WorkspaceTemplate: workspaceTemplate,
+		Parameters: []Parameter{
+			{
+				Name:  "workflow-execution-name",
+				Value: ptr.String(name),
+			},
+		},
+	}
+	workspace.GenerateUID(name)
+
+	createdWorkspace, err := c.createWorkspace(namespace, []byte("[]"), workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return createdWorkspace
+}
+
+// TestClient_GetWorkspaceStatisticsForNamespaces tests the statistics are reported separately for each namespace
+func TestClient_GetWorkspaceStatisticsForNamespaces(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	createTestWorkspace(t, c, "onepanel", "test")
+	paused := createTestWorkspace(t, c, "onepanel", "test2")
+	createTestWorkspace(t, c, "other", "test3")
+
+	if err := c.UpdateWorkspaceStatus("onepanel", paused.UID, &WorkspaceStatus{Phase: WorkspacePaused}); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := c.GetWorkspaceStatisticsForNamespaces([]string{"onepanel", "other", "empty"})
+	assert.Nil(t, err)
+	assert.Len(t, reports, 3)
+
+	assert.Equal(t, "onepanel", reports[0].Namespace)
+	assert.Equal(t, int32(2), reports[0].Total)
+	assert.Equal(t, int32(1), reports[0].Launching)
+	assert.Equal(t, int32(1), reports[0].Paused)
+
+	assert.Equal(t, "other", reports[1].Namespace)
+	assert.Equal(t, int32(1), reports[1].Total)
+	assert.Equal(t, int32(1), reports[1].Launching)
+	assert.Equal(t, int32(0), reports[1].Paused)
+
+	assert.Equal(t, "empty", reports[2].Namespace)
+	assert.Equal(t, int32(0), reports[2].Total)
+
+	reports, err = c.GetWorkspaceStatisticsForNamespaces(nil)
+	assert.Nil(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "onepanel", reports[0].Namespace)
+	assert.Equal(t, int32(2), reports[0].Total)
+	assert.Equal(t, "other", reports[1].Namespace)
+	assert.Equal(t, int32(1), reports[1].Total)
+}
+
+// TestClient_GetWorkspace_Uptime makes sure the uptime of a running workspace increases, and that a paused one has none
+func TestClient_GetWorkspace_Uptime(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	ws := createTestWorkspace(t, c, namespace, "uptime")
+
+	workspace, err := c.GetWorkspace(namespace, ws.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.NotNil(t, workspace.Status.StartedAt) {
+		return
+	}
+
+	now := time.Now().UTC()
+	uptime := workspace.Uptime(now)
+	assert.True(t, uptime >= 0)
+	assert.Equal(t, uptime+time.Hour, workspace.Uptime(now.Add(time.Hour)))
+	assert.Equal(t, time.Duration(0), workspace.Uptime(workspace.Status.StartedAt.Add(-time.Minute)))
+
+	if err := c.UpdateWorkspaceStatus(namespace, ws.UID, &WorkspaceStatus{Phase: WorkspacePausing}); err != nil {
+		t.Fatal(err)
+	}
+	workspace, err = c.GetWorkspace(namespace, ws.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, workspace.Status.StartedAt)
+	assert.Equal(t, time.Duration(0), workspace.Uptime(now))
+}
+
+// TestClient_RecordWorkspaceActivity makes sure every heartbeat updates when the workspace was last used
+func TestClient_RecordWorkspaceActivity(t *testing.T) {
+	c := DefaultTestClient()
+	clearDatabase(t)
+
+	namespace := "onepanel"
+	ws := createTestWorkspace(t, c, namespace, "activity")
+
+	workspace, err := c.GetWorkspace(namespace, ws.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, workspace.LastActivityAt)
+
+	// An hour ago, so the next heartbeat is known to move it forward
+	hourAgo := time.Now().UTC().Add(-time.Hour)
+	_, err = sb.Update("workspaces").
+		Set("last_activity_at", hourAgo).
+		Where(sq.Eq{"uid": ws.UID}).
+		RunWith(c.DB).
+		Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, c.RecordWorkspaceActivity(namespace, ws.UID))
+	workspace, err = c.GetWorkspace(namespace, ws.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, workspace.LastActivityAt) {
+		assert.True(t, workspace.LastActivityAt.After(hourAgo.Add(time.Minute)))
+	}
+
+	err = c.RecordWorkspaceActivity(namespace, "not-found")
+	assertUserErrorCode(t, err, codes.NotFound)
+}
@@ -3,11 +3,15 @@ package v1
 import (
 	"fmt"
 	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/lib/pq"
+	"github.com/onepanelio/core/pkg/util"
 	"github.com/onepanelio/core/pkg/util/sql"
 	"github.com/onepanelio/core/pkg/util/types"
 	uid2 "github.com/onepanelio/core/pkg/util/uid"
+	"google.golang.org/grpc/codes"
 	networking "istio.io/api/networking/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
+	"strings"
 	"time"
 )
 
@@ -52,6 +56,69 @@ type Workspace struct {
 	WorkspaceTemplateID      uint64                   `db:"workspace_template_id"`
 	WorkspaceTemplateVersion uint64                   `db:"workspace_template_version"`
 	WorkflowTemplateVersion  *WorkflowTemplateVersion `db:"workflow_template_version"` // helper to store data from workflow template version
+	Replicas                 int32                    `db:"replicas"`                  // number of replicas of the workspace statefulset, at least 1
+	Command                  pq.StringArray           `db:"command"`                   // overrides the command of the workspace template container, if set
+	Args                     pq.StringArray           `db:"args"`                      // overrides the args of the workspace template container, if set
+	ScheduleStart            string                   `db:"schedule_start"`            // cron expression of when the workspace is resumed, if it runs on a schedule
+	ScheduleStop             string                   `db:"schedule_stop"`             // cron expression of when the workspace is paused, if it runs on a schedule
+	ScheduleTimezone         string                   `db:"schedule_timezone"`         // IANA time zone of the schedule, like Europe/Berlin. Defaults to UTC.
+	LastActivityAt           *time.Time               `db:"last_activity_at"`          // when the workspace was last used, see Client.RecordWorkspaceActivity
+	ScheduleReconciledAt     *time.Time               `db:"schedule_reconciled_at"`    // when the schedule was last checked, see Client.ReconcileWorkspaceSchedules
+	SourceSnapshotID         string                   `db:"-"`                         // volume snapshot to provision the workspace volume from
+	Ready                    bool                     `db:"-"`                         // true if all the containers of the workspace pass their readiness probes, see Client.GetWorkspaceReady
+}
+
+// Uptime returns how long the workspace has been running at now, since it was last launched or resumed.
+// A workspace that is not running, like a paused one, has no uptime.
+func (w *Workspace) Uptime(now time.Time) time.Duration {
+	if w.Status.StartedAt == nil || now.Before(*w.Status.StartedAt) {
+		return 0
+	}
+
+	return now.Sub(*w.Status.StartedAt)
+}
+
+// WorkspaceEvent is a status transition of a workspace, recorded each time its phase changes
+type WorkspaceEvent struct {
+	ID          uint64
+	WorkspaceID uint64         `db:"workspace_id"`
+	Phase       WorkspacePhase `db:"phase"`
+	Message     string
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// workspaceEventMessages are the messages recorded with a workspace event for each phase
+var workspaceEventMessages = map[WorkspacePhase]string{
+	WorkspaceLaunching:         "Workspace is launching.",
+	WorkspaceRunning:           "Workspace is running.",
+	WorkspaceUpdating:          "Workspace is updating.",
+	WorkspacePausing:           "Workspace is pausing.",
+	WorkspacePaused:            "Workspace is paused.",
+	WorkspaceTerminating:       "Workspace is terminating.",
+	WorkspaceTerminated:        "Workspace is terminated.",
+	WorkspaceFailedToPause:     "Workspace failed to pause.",
+	WorkspaceFailedToResume:    "Workspace failed to resume.",
+	WorkspaceFailedToTerminate: "Workspace failed to terminate.",
+	WorkspaceFailedToLaunch:    "Workspace failed to launch.",
+	WorkspaceFailedToUpdate:    "Workspace failed to update.",
+}
+
+// WorkspaceContainerMetrics is the current resource usage of a workspace container along with its requests and limits.
+// CPU values are in millicores and memory values are in bytes. A value of 0 means it is not set.
+type WorkspaceContainerMetrics struct {
+	Name          string
+	CPUUsage      int64
+	CPURequest    int64
+	CPULimit      int64
+	MemoryUsage   int64
+	MemoryRequest int64
+	MemoryLimit   int64
+}
+
+// WorkspaceMetrics is the current resource usage of the pod running a workspace
+type WorkspaceMetrics struct {
+	PodName    string
+	Containers []*WorkspaceContainerMetrics
 }
 
 type WorkspaceSpec struct {
@@ -70,6 +137,95 @@ func (w *Workspace) GetURL(protocol, domain string) string {
 	return fmt.Sprintf("%v%v--%v.%v", protocol, w.UID, w.Namespace, domain)
 }
 
+// WorkspacePort is a port exposed by a workspace along with the url it can be reached at
+type WorkspacePort struct {
+	Name string
+	Port int32
+	URL  string
+}
+
+// GetPorts returns the ports exposed by the workspace template, with the url each can be accessed at.
+// The url is the workspace url with the uri prefix of the route that targets the port.
+// Ports that have no route are not reachable from outside the cluster and have an empty url.
+func (w *Workspace) GetPorts(protocol, domain string) (ports []*WorkspacePort, err error) {
+	if w.WorkspaceTemplate == nil {
+		return nil, fmt.Errorf("workspace template is nil for workspace")
+	}
+
+	spec, err := parseWorkspaceSpec(w.WorkspaceTemplate.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	url := w.GetURL(protocol, domain)
+	for _, port := range spec.Ports {
+		workspacePort := &WorkspacePort{
+			Name: port.Name,
+			Port: port.Port,
+		}
+
+		for _, route := range spec.Routes {
+			if prefix, ok := routePrefixForPort(route, port.Port); ok {
+				workspacePort.URL = url + strings.TrimSuffix(prefix, "/")
+				break
+			}
+		}
+
+		ports = append(ports, workspacePort)
+	}
+
+	return
+}
+
+// routePrefixForPort returns the uri prefix of the route if one of its destinations is the port
+func routePrefixForPort(route *networking.HTTPRoute, port int32) (prefix string, ok bool) {
+	if route == nil {
+		return "", false
+	}
+
+	for _, destination := range route.Route {
+		if destination.GetDestination().GetPort().GetNumber() != uint32(port) {
+			continue
+		}
+
+		for _, match := range route.Match {
+			if match.GetUri().GetPrefix() != "" {
+				return match.GetUri().GetPrefix(), true
+			}
+		}
+
+		return "/", true
+	}
+
+	return "", false
+}
+
+// validateWorkspaceReplicas makes sure a workspace has at least one replica
+func validateWorkspaceReplicas(replicas int32) error {
+	if replicas < 1 {
+		return util.NewUserError(codes.InvalidArgument, "Replicas must be at least 1.")
+	}
+
+	return nil
+}
+
+// validateWorkspaceCommand makes sure the command and args overriding the ones of the workspace template container
+// do not have empty strings
+func validateWorkspaceCommand(command, args []string) error {
+	for _, value := range command {
+		if strings.TrimSpace(value) == "" {
+			return util.NewUserError(codes.InvalidArgument, "Command can not have empty strings.")
+		}
+	}
+	for _, value := range args {
+		if strings.TrimSpace(value) == "" {
+			return util.NewUserError(codes.InvalidArgument, "Args can not have empty strings.")
+		}
+	}
+
+	return nil
+}
+
 // GetParameterValue returns the value of the parameter with the given name, or nil if there is no such parameter
 func (w *Workspace) GetParameterValue(name string) *string {
 	for _, p := range w.Parameters {
@@ -96,7 +252,7 @@ func (w *Workspace) GenerateUID(name string) error {
 // getWorkspaceColumns returns all of the columns for workspace modified by alias, destination.
 // see formatColumnSelect
 func getWorkspaceColumns(aliasAndDestination ...string) []string {
-	columns := []string{"id", "created_at", "modified_at", "uid", "name", "namespace", "parameters", "workspace_template_id", "workspace_template_version", "labels"}
+	columns := []string{"id", "created_at", "modified_at", "uid", "name", "namespace", "parameters", "workspace_template_id", "workspace_template_version", "labels", "replicas", "command", "args", "schedule_start", "schedule_stop", "schedule_timezone", "last_activity_at"}
 	return sql.FormatColumnSelect(columns, aliasAndDestination...)
 }
 
@@ -38,3 +38,59 @@ func Test_WorkspaceNameValidation_RegexValid(t *testing.T) {
 	assertWorkspaceNameValid(t, "My CVAT Workspace")
 	assertWorkspaceNameValid(t, "CVAT Workspace 1")
 }
+
+func Test_Workspace_GetPorts(t *testing.T) {
+	manifest := `
+containers:
+- name: jupyterlab
+  image: onepanel/jupyterlab
+  ports:
+  - containerPort: 8888
+    name: jupyterlab
+  - containerPort: 6006
+    name: tensorboard
+ports:
+- name: jupyterlab
+  port: 80
+  protocol: TCP
+  targetPort: 8888
+- name: tensorboard
+  port: 6006
+  protocol: TCP
+  targetPort: 6006
+routes:
+- match:
+  - uri:
+      prefix: /tensorboard
+  route:
+  - destination:
+      port:
+        number: 6006
+- match:
+  - uri:
+      prefix: /
+  route:
+  - destination:
+      port:
+        number: 80
+`
+	ws := Workspace{
+		UID:       "jupyter",
+		Namespace: "onepanel",
+		WorkspaceTemplate: &WorkspaceTemplate{
+			Manifest: manifest,
+		},
+	}
+
+	ports, err := ws.GetPorts("https://", "test.onepanel.io")
+	assert.Nil(t, err)
+	assert.Len(t, ports, 2)
+
+	assert.Equal(t, "jupyterlab", ports[0].Name)
+	assert.Equal(t, int32(80), ports[0].Port)
+	assert.Equal(t, "https://jupyter--onepanel.test.onepanel.io", ports[0].URL)
+
+	assert.Equal(t, "tensorboard", ports[1].Name)
+	assert.Equal(t, int32(6006), ports[1].Port)
+	assert.Equal(t, "https://jupyter--onepanel.test.onepanel.io/tensorboard", ports[1].URL)
+}
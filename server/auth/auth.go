@@ -3,7 +3,9 @@ package auth
 import (
 	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/onepanelio/core/api"
@@ -73,7 +75,28 @@ func getBearerToken(ctx context.Context) (*string, bool) {
 	return nil, false
 }
 
-func getClient(ctx context.Context, kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig) (context.Context, error) {
+// getTokenIdentity returns who the bearer token belongs to, which is recorded as the caller in audit events.
+// Service account tokens are JWTs with the service account as the subject, like system:serviceaccount:onepanel:admin.
+// The token is not verified here, kubernetes does so on every call made with it.
+// Other tokens are identified by their hash, so the token itself is never recorded.
+func getTokenIdentity(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			claims := struct {
+				Subject string `json:"sub"`
+			}{}
+			if err := json.Unmarshal(payload, &claims); err == nil && claims.Subject != "" {
+				return claims.Subject
+			}
+		}
+	}
+
+	tokenHash := md5.Sum([]byte(token))
+	return "token:" + hex.EncodeToString(tokenHash[:])
+}
+
+func getClient(ctx context.Context, kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig, watchGroup *v1.WatchGroup) (context.Context, error) {
 	if kubeConfig == nil {
 		return nil, fmt.Errorf("getClient - nil passed in for kubeConfig")
 	}
@@ -96,6 +119,10 @@ func getClient(ctx context.Context, kubeConfig *v1.Config, db *v1.DB, sysConfig
 		return nil, err
 	}
 	client.Token = kubeConfig.BearerToken
+	client.Identity = getTokenIdentity(client.Token)
+	// Kubernetes calls made by the client stop once the request is cancelled or its deadline passes,
+	// and its watchers also stop when the watch group is closed on shutdown.
+	client = client.WithContext(ctx).WithWatchGroup(watchGroup)
 
 	return context.WithValue(ctx, ContextClientKey, client), nil
 }
@@ -166,7 +193,7 @@ func verifyLogin(client *v1.Client, tokenRequest *api.GetAccessTokenRequest) (ra
 // The two main cases are:
 //   1. Is the token valid? This is used for logging in.
 //   2. Is there a token? There should be a token for everything except logging in.
-func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig) grpc.UnaryServerInterceptor {
+func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig, watchGroup *v1.WatchGroup) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		// Check if the provided token is valid. This does not require a token in the header.
 		if info.FullMethod == "/api.AuthService/GetAccessToken" {
@@ -197,7 +224,7 @@ func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfi
 
 			md.Set("authorization", "Bearer "+rawToken)
 
-			ctx, err = getClient(ctx, kubeConfig, db, sysConfig)
+			ctx, err = getClient(ctx, kubeConfig, db, sysConfig, watchGroup)
 			if err != nil {
 				ctx = nil
 			}
@@ -236,7 +263,7 @@ func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfi
 
 			md.Set("authorization", "Bearer "+rawToken)
 
-			ctx, err = getClient(ctx, kubeConfig, db, sysConfig)
+			ctx, err = getClient(ctx, kubeConfig, db, sysConfig, watchGroup)
 			if err != nil {
 				ctx = nil
 			}
@@ -275,7 +302,7 @@ func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfi
 		}
 
 		// This guy checks for the token
-		ctx, err = getClient(ctx, kubeConfig, db, sysConfig)
+		ctx, err = getClient(ctx, kubeConfig, db, sysConfig, watchGroup)
 		if err != nil {
 			return
 		}
@@ -285,9 +312,9 @@ func UnaryInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfi
 }
 
 // StreamingInterceptor provides an authentication wrapper around streaming requests.
-func StreamingInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig) grpc.StreamServerInterceptor {
+func StreamingInterceptor(kubeConfig *v1.Config, db *v1.DB, sysConfig v1.SystemConfig, watchGroup *v1.WatchGroup) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
-		ctx, err := getClient(ss.Context(), kubeConfig, db, sysConfig)
+		ctx, err := getClient(ss.Context(), kubeConfig, db, sysConfig, watchGroup)
 		if err != nil {
 			return
 		}
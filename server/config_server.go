@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/onepanelio/core/api"
+	v1 "github.com/onepanelio/core/pkg"
 	"github.com/onepanelio/core/server/auth"
 )
 
@@ -52,3 +53,109 @@ func (c *ConfigServer) GetConfig(ctx context.Context, req *empty.Empty) (*api.Ge
 		NodePool: nodePool,
 	}, err
 }
+
+func apiArtifactRepositorySecretRef(secret v1.ArtifactRepositorySecret) *api.ArtifactRepositorySecretRef {
+	return &api.ArtifactRepositorySecretRef{
+		Name: secret.Name,
+		Key:  secret.Key,
+	}
+}
+
+func apiArtifactRepositoryConfig(config *v1.ArtifactRepositoryProvider) *api.ArtifactRepositoryConfig {
+	res := &api.ArtifactRepositoryConfig{}
+
+	if config.S3 != nil {
+		res.S3 = &api.ArtifactRepositoryS3Config{
+			KeyFormat:       config.S3.KeyFormat,
+			Bucket:          config.S3.Bucket,
+			Endpoint:        config.S3.Endpoint,
+			Insecure:        config.S3.Insecure,
+			Region:          config.S3.Region,
+			AccessKeySecret: apiArtifactRepositorySecretRef(config.S3.AccessKeySecret),
+			SecretKeySecret: apiArtifactRepositorySecretRef(config.S3.SecretKeySecret),
+		}
+	}
+
+	if config.GCS != nil {
+		res.Gcs = &api.ArtifactRepositoryGCSConfig{
+			KeyFormat:               config.GCS.KeyFormat,
+			Bucket:                  config.GCS.Bucket,
+			Endpoint:                config.GCS.Endpoint,
+			Insecure:                config.GCS.Insecure,
+			ServiceAccountKeySecret: apiArtifactRepositorySecretRef(config.GCS.ServiceAccountKeySecret),
+		}
+	}
+
+	return res
+}
+
+func artifactRepositorySecret(secret *api.ArtifactRepositorySecretRef) v1.ArtifactRepositorySecret {
+	if secret == nil {
+		return v1.ArtifactRepositorySecret{}
+	}
+
+	return v1.ArtifactRepositorySecret{
+		Name: secret.Name,
+		Key:  secret.Key,
+	}
+}
+
+// GetArtifactRepositoryConfig returns the artifact repository of the namespace
+func (c *ConfigServer) GetArtifactRepositoryConfig(ctx context.Context, req *api.GetArtifactRepositoryConfigRequest) (*api.ArtifactRepositoryConfig, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "", "configmaps", "onepanel")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	config, err := client.GetArtifactRepositoryConfig(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiArtifactRepositoryConfig(config), nil
+}
+
+// SetArtifactRepositoryConfig sets the artifact repository workflows of the namespace store their artifacts in
+func (c *ConfigServer) SetArtifactRepositoryConfig(ctx context.Context, req *api.SetArtifactRepositoryConfigRequest) (*api.ArtifactRepositoryConfig, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "", "configmaps", "onepanel")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	config := &v1.ArtifactRepositoryProvider{}
+	if req.ArtifactRepository != nil && req.ArtifactRepository.S3 != nil {
+		s3 := req.ArtifactRepository.S3
+		config.S3 = &v1.ArtifactRepositoryS3Provider{
+			KeyFormat:       s3.KeyFormat,
+			Bucket:          s3.Bucket,
+			Endpoint:        s3.Endpoint,
+			Insecure:        s3.Insecure,
+			Region:          s3.Region,
+			AccessKeySecret: artifactRepositorySecret(s3.AccessKeySecret),
+			SecretKeySecret: artifactRepositorySecret(s3.SecretKeySecret),
+		}
+	}
+	if req.ArtifactRepository != nil && req.ArtifactRepository.Gcs != nil {
+		gcs := req.ArtifactRepository.Gcs
+		config.GCS = &v1.ArtifactRepositoryGCSProvider{
+			KeyFormat:               gcs.KeyFormat,
+			Bucket:                  gcs.Bucket,
+			Endpoint:                gcs.Endpoint,
+			Insecure:                gcs.Insecure,
+			ServiceAccountKeySecret: artifactRepositorySecret(gcs.ServiceAccountKeySecret),
+		}
+	}
+
+	if err := client.SetArtifactRepositoryConfig(req.Namespace, config); err != nil {
+		return nil, err
+	}
+
+	config, err = client.GetArtifactRepositoryConfig(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiArtifactRepositoryConfig(config), nil
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	"github.com/onepanelio/core/api"
 	v1 "github.com/onepanelio/core/pkg"
 	"sort"
@@ -158,6 +159,9 @@ func ParameterToAPI(param v1.Parameter) *api.Parameter {
 	if param.Options != nil {
 		apiParam.Options = ParameterOptionsToAPI(param.Options)
 	}
+	if param.SecretRef != nil {
+		apiParam.SecretRef = *param.SecretRef
+	}
 
 	return apiParam
 }
@@ -189,6 +193,9 @@ func APIParameterToInternal(param *api.Parameter) *v1.Parameter {
 	if param.Hint != "" {
 		result.Hint = &param.Hint
 	}
+	if param.SecretRef != "" {
+		result.SecretRef = &param.SecretRef
+	}
 
 	if param.Options != nil {
 		result.Options = APIParameterOptionsToInternal(param.Options)
@@ -197,10 +204,10 @@ func APIParameterToInternal(param *api.Parameter) *v1.Parameter {
 	return result
 }
 
-// TimestampToAPIString converts a *time.Time to an API string in the RFC3339 format
-// if ts is nil, an empty string is returned
+// TimestampToAPIString converts a *time.Time to an API string in the RFC3339 format, in UTC
+// if ts is nil or the zero time, an empty string is returned
 func TimestampToAPIString(ts *time.Time) string {
-	if ts == nil {
+	if ts == nil || ts.IsZero() {
 		return ""
 	}
 
@@ -251,3 +258,48 @@ func WorkspaceStatisticsReportToAPI(report *v1.WorkspaceStatisticReport) *api.Wo
 
 	return stats
 }
+
+// WorkflowPhaseToAPI converts an argo workflow phase to the API enum.
+// Phases that are not recognized, including an empty phase, are api.WorkflowPhase_Unknown
+func WorkflowPhaseToAPI(phase wfv1.NodePhase) api.WorkflowPhase {
+	value, ok := api.WorkflowPhase_value[string(phase)]
+	if !ok {
+		return api.WorkflowPhase_Unknown
+	}
+
+	return api.WorkflowPhase(value)
+}
+
+// WorkflowProgress returns the fraction of the nodes of the argo workflow that are done, from 0 to 1.
+// Skipped and omitted nodes count as done. A workflow with no nodes yet has a progress of 0.
+func WorkflowProgress(wf *wfv1.Workflow) float64 {
+	if wf == nil || len(wf.Status.Nodes) == 0 {
+		return 0
+	}
+
+	fulfilled := 0
+	for _, node := range wf.Status.Nodes {
+		if node.Fulfilled() {
+			fulfilled++
+		}
+	}
+
+	return float64(fulfilled) / float64(len(wf.Status.Nodes))
+}
+
+// WorkflowConditionsToAPI converts the status conditions of the argo workflow, in the order argo reports them
+func WorkflowConditionsToAPI(wf *wfv1.Workflow) (conditions []*api.WorkflowCondition) {
+	if wf == nil {
+		return nil
+	}
+
+	for _, condition := range wf.Status.Conditions {
+		conditions = append(conditions, &api.WorkflowCondition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Message: condition.Message,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package converter
+
+import (
+	wfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/onepanelio/core/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestWorkflowPhaseToAPI(t *testing.T) {
+	phases := map[wfv1.NodePhase]api.WorkflowPhase{
+		wfv1.NodePending:   api.WorkflowPhase_Pending,
+		wfv1.NodeRunning:   api.WorkflowPhase_Running,
+		wfv1.NodeSucceeded: api.WorkflowPhase_Succeeded,
+		wfv1.NodeSkipped:   api.WorkflowPhase_Skipped,
+		wfv1.NodeFailed:    api.WorkflowPhase_Failed,
+		wfv1.NodeError:     api.WorkflowPhase_Error,
+		wfv1.NodeOmitted:   api.WorkflowPhase_Omitted,
+		"Terminated":       api.WorkflowPhase_Terminated,
+	}
+
+	for phase, expected := range phases {
+		assert.Equal(t, expected, WorkflowPhaseToAPI(phase), string(phase))
+	}
+
+	assert.Equal(t, api.WorkflowPhase_Unknown, WorkflowPhaseToAPI(""))
+	assert.Equal(t, api.WorkflowPhase_Unknown, WorkflowPhaseToAPI("Exploded"))
+}
+
+func TestTimestampToAPIString(t *testing.T) {
+	location := time.FixedZone("UTC+5", 5*60*60)
+	ts := time.Date(2020, 8, 21, 15, 30, 0, 0, location)
+
+	assert.Equal(t, "2020-08-21T10:30:00Z", TimestampToAPIString(&ts))
+
+	utc := ts.UTC()
+	assert.Equal(t, TimestampToAPIString(&utc), TimestampToAPIString(&ts))
+
+	zero := time.Time{}
+	assert.Equal(t, "", TimestampToAPIString(&zero))
+	assert.Equal(t, "", TimestampToAPIString(nil))
+}
+
+func TestWorkflowConditionsToAPI(t *testing.T) {
+	assert.Nil(t, WorkflowConditionsToAPI(nil))
+	assert.Nil(t, WorkflowConditionsToAPI(&wfv1.Workflow{}))
+
+	wf := &wfv1.Workflow{
+		Status: wfv1.WorkflowStatus{
+			Conditions: wfv1.Conditions{
+				{Type: wfv1.ConditionTypeSpecWarning, Status: metav1.ConditionTrue, Message: "Template main is deprecated"},
+				{Type: wfv1.ConditionTypeCompleted, Status: metav1.ConditionTrue, Message: "Workflow finished"},
+			},
+		},
+	}
+
+	assert.Equal(t, []*api.WorkflowCondition{
+		{Type: "SpecWarning", Status: "True", Message: "Template main is deprecated"},
+		{Type: "Completed", Status: "True", Message: "Workflow finished"},
+	}, WorkflowConditionsToAPI(wf))
+}
+
+func TestWorkflowProgress(t *testing.T) {
+	assert.Equal(t, float64(0), WorkflowProgress(nil))
+	assert.Equal(t, float64(0), WorkflowProgress(&wfv1.Workflow{}))
+
+	wf := &wfv1.Workflow{
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"a": {Phase: wfv1.NodeSucceeded},
+				"b": {Phase: wfv1.NodeSkipped},
+				"c": {Phase: wfv1.NodeFailed},
+				"d": {Phase: wfv1.NodeRunning},
+				"e": {Phase: wfv1.NodePending},
+			},
+		},
+	}
+	assert.Equal(t, 0.6, WorkflowProgress(wf))
+
+	for name, node := range wf.Status.Nodes {
+		node.Phase = wfv1.NodeSucceeded
+		wf.Status.Nodes[name] = node
+	}
+	assert.Equal(t, float64(1), WorkflowProgress(wf))
+}
@@ -176,12 +176,13 @@ func (c *CronWorkflowServer) ListCronWorkflows(ctx context.Context, req *api.Lis
 		return nil, err
 	}
 
+	page := pagination.NewMetadata(&paginator, len(apiCronWorkflows), count)
 	return &api.ListCronWorkflowsResponse{
-		Count:         int32(len(apiCronWorkflows)),
+		Count:         page.Count,
 		CronWorkflows: apiCronWorkflows,
-		Page:          int32(paginator.Page),
-		Pages:         paginator.CalculatePages(count),
-		TotalCount:    int32(count),
+		Page:          page.Page,
+		Pages:         page.Pages,
+		TotalCount:    page.TotalCount,
 	}, nil
 }
 
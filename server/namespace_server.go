@@ -6,8 +6,12 @@ import (
 	"strings"
 
 	"github.com/onepanelio/core/api"
+	migrations "github.com/onepanelio/core/db/go"
 	v1 "github.com/onepanelio/core/pkg"
+	"github.com/onepanelio/core/pkg/util"
+	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/onepanelio/core/server/auth"
+	"google.golang.org/grpc/codes"
 )
 
 type NamespaceServer struct{}
@@ -31,9 +35,7 @@ func (s *NamespaceServer) ListNamespaces(ctx context.Context, req *api.ListNames
 		return nil, err
 	}
 
-	if req.PageSize <= 0 {
-		req.PageSize = 15
-	}
+	req.PageSize = int32(pagination.NewRequest(req.Page, req.PageSize).PageSize)
 
 	namespaces, err := client.ListNamespaces()
 	if err != nil {
@@ -87,3 +89,28 @@ func (s *NamespaceServer) CreateNamespace(ctx context.Context, createNamespace *
 		Name: namespace.Name,
 	}, nil
 }
+
+// OnboardNamespace sets up the namespace for onepanel with the default configuration and workflow templates
+func (s *NamespaceServer) OnboardNamespace(ctx context.Context, req *api.OnboardNamespaceRequest) (*api.Namespace, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, "", "create", "", "namespaces", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+	allowed, err = auth.IsAuthorized(client, req.Name, "create", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	workflowTemplates, err := migrations.DefaultWorkflowTemplates()
+	if err != nil {
+		return nil, util.NewUserError(codes.Internal, "Unable to load the default workflow templates.")
+	}
+
+	namespace, err := client.OnboardNamespace(req.Name, workflowTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiNamespace(namespace), nil
+}
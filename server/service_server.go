@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/onepanelio/core/api"
 	v1 "github.com/onepanelio/core/pkg"
+	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/onepanelio/core/server/auth"
 )
 
@@ -40,12 +41,14 @@ func (c *ServiceServer) ListServices(ctx context.Context, req *api.ListServicesR
 		apiServices[i] = apiService(service)
 	}
 
+	// Services are not paginated, they are all on the first page
+	page := pagination.NewMetadata(nil, len(services), len(services))
 	return &api.ListServicesResponse{
-		Count:      int32(len(services)),
+		Count:      page.Count,
 		Services:   apiServices,
-		Page:       1,
-		Pages:      1,
-		TotalCount: int32(len(services)),
+		Page:       page.Page,
+		Pages:      page.Pages,
+		TotalCount: page.TotalCount,
 	}, nil
 }
 
@@ -10,8 +10,10 @@ import (
 	"github.com/onepanelio/core/pkg/util/router"
 	"github.com/onepanelio/core/server/converter"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/onepanelio/core/server/auth"
 
 	requestSort "github.com/onepanelio/core/pkg/util/request/sort"
+	log "github.com/sirupsen/logrus"
 )
 
 type WorkflowServer struct{}
@@ -34,31 +37,105 @@ func NewWorkflowServer() *WorkflowServer {
 // router is optional
 func apiWorkflowExecution(wf *v1.WorkflowExecution, router router.Web) (workflow *api.WorkflowExecution) {
 	workflow = &api.WorkflowExecution{
-		CreatedAt: wf.CreatedAt.Format(time.RFC3339),
-		Uid:       wf.UID,
-		Name:      wf.Name,
-		Phase:     string(wf.Phase),
-		Manifest:  wf.Manifest,
-		Labels:    converter.MappingToKeyValue(wf.Labels),
-		Metrics:   converter.MetricsToAPI(wf.Metrics),
+		CreatedAt:         converter.TimestampToAPIString(&wf.CreatedAt),
+		Uid:               wf.UID,
+		Name:              wf.Name,
+		Phase:             converter.WorkflowPhaseToAPI(wf.Phase),
+		Manifest:          wf.Manifest,
+		Labels:            converter.MappingToKeyValue(wf.Labels),
+		Metrics:           converter.MetricsToAPI(wf.Metrics),
+		ResourceVersion:   wf.GetResourceVersion(),
+		StartedAt:         converter.TimestampToAPIString(wf.StartedAt),
+		FinishedAt:        converter.TimestampToAPIString(wf.FinishedAt),
+		Retries:           wf.Retries,
+		Progress:          converter.WorkflowProgress(wf.ArgoWorkflow),
+		Conditions:        converter.WorkflowConditionsToAPI(wf.ArgoWorkflow),
+		Archived:          wf.Archived,
+		EstimatedFinishAt: converter.TimestampToAPIString(wf.EstimatedFinishAt),
+		TerminationReason: wf.TerminationReason,
+		CreatedBy:         wf.CreatedBy,
 	}
 
-	if wf.StartedAt != nil && !wf.StartedAt.IsZero() {
-		workflow.StartedAt = wf.StartedAt.Format(time.RFC3339)
+	// Resources are only known once the argo workflow is loaded
+	if wf.ArgoWorkflow != nil {
+		workflow.Resources = &api.WorkflowResources{
+			CpuRequest:    wf.Resources.CPURequest,
+			CpuLimit:      wf.Resources.CPULimit,
+			MemoryRequest: wf.Resources.MemoryRequest,
+			MemoryLimit:   wf.Resources.MemoryLimit,
+		}
+	}
+
+	for _, pod := range wf.UnschedulablePods {
+		workflow.UnschedulablePods = append(workflow.UnschedulablePods, &api.UnschedulablePod{
+			PodName: pod.PodName,
+			Reason:  pod.Reason,
+			Message: pod.Message,
+		})
+	}
+
+	for _, volume := range wf.ProvisioningVolumes {
+		workflow.ProvisioningVolumes = append(workflow.ProvisioningVolumes, &api.ProvisioningVolume{
+			ClaimName: volume.ClaimName,
+			Phase:     string(volume.Phase),
+			Reason:    volume.Reason,
+			Message:   volume.Message,
+		})
+	}
+
+	for _, attempt := range wf.Attempts {
+		workflow.Attempts = append(workflow.Attempts, &api.WorkflowExecutionAttempt{
+			Phase:      converter.WorkflowPhaseToAPI(attempt.Phase),
+			StartedAt:  converter.TimestampToAPIString(attempt.StartedAt),
+			FinishedAt: converter.TimestampToAPIString(attempt.FinishedAt),
+		})
+	}
+
+	for _, node := range wf.Nodes {
+		apiNode := &api.WorkflowNode{
+			Id:          node.ID,
+			Name:        node.Name,
+			DisplayName: node.DisplayName,
+			Type:        string(node.Type),
+			Phase:       converter.WorkflowPhaseToAPI(node.Phase),
+			Children:    node.Children,
+			Memoized:    node.Memoized,
+		}
+		if node.ExitCode != nil {
+			apiNode.ExitCode = strconv.Itoa(int(*node.ExitCode))
+		}
+		workflow.Nodes = append(workflow.Nodes, apiNode)
+	}
+
+	workflow.Warnings = wf.Warnings
+
+	if wf.EstimatedCost != nil {
+		workflow.EstimatedCost = *wf.EstimatedCost
 	}
-	if wf.FinishedAt != nil && !wf.FinishedAt.IsZero() {
-		workflow.FinishedAt = wf.FinishedAt.Format(time.RFC3339)
+
+	for _, child := range wf.ChildWorkflows {
+		workflow.ChildWorkflows = append(workflow.ChildWorkflows, &api.ChildWorkflow{
+			Uid:       child.UID,
+			Phase:     converter.WorkflowPhaseToAPI(child.Phase),
+			CreatedAt: converter.TimestampToAPIString(&child.CreatedAt),
+		})
 	}
+
 	if wf.WorkflowTemplate != nil {
 		workflow.WorkflowTemplate = apiWorkflowTemplate(wf.WorkflowTemplate)
 	}
+	// A workflow execution whose parameters can not be loaded is still sent, without them
 	if wf.ParametersBytes != nil {
 		parameters, err := wf.LoadParametersFromBytes()
 		if err != nil {
-			return nil
+			log.WithFields(log.Fields{
+				"Namespace": wf.Namespace,
+				"UID":       wf.UID,
+				"Error":     err.Error(),
+			}).Error("Unable to load workflow execution parameters.")
+		} else {
+			workflow.Parameters = converter.ParametersToAPI(parameters)
 		}
-
-		workflow.Parameters = converter.ParametersToAPI(parameters)
 	}
 
 	if router != nil {
@@ -70,6 +147,70 @@ func apiWorkflowExecution(wf *v1.WorkflowExecution, router router.Web) (workflow
 	return
 }
 
+// apiListedWorkflowExecution converts a workflow execution from ListWorkflowExecutions to the api version.
+// The workflow template loaded by the list query is sent as is. If includeTemplate is set, the uid, name and version
+// of the workflow template are embedded, even when the rest of it is not.
+func apiListedWorkflowExecution(wf *v1.WorkflowExecution, router router.Web, includeTemplate bool) *api.WorkflowExecution {
+	workflow := apiWorkflowExecution(wf, router)
+	if !includeTemplate || wf.WorkflowTemplate == nil {
+		return workflow
+	}
+
+	if workflow.WorkflowTemplate == nil {
+		workflow.WorkflowTemplate = &api.WorkflowTemplate{}
+	}
+	workflow.WorkflowTemplate.Uid = wf.WorkflowTemplate.UID
+	workflow.WorkflowTemplate.Name = wf.WorkflowTemplate.Name
+	workflow.WorkflowTemplate.Version = wf.WorkflowTemplate.Version
+
+	return workflow
+}
+
+// apiWorkflowExecutionPhase converts a workflow execution from WatchWorkflowExecutionPhase to the api version,
+// with only its uid, phase and timestamps
+func apiWorkflowExecutionPhase(wf *v1.WorkflowExecution) *api.WorkflowExecution {
+	return &api.WorkflowExecution{
+		Uid:        wf.UID,
+		Phase:      converter.WorkflowPhaseToAPI(wf.Phase),
+		CreatedAt:  converter.TimestampToAPIString(&wf.CreatedAt),
+		StartedAt:  converter.TimestampToAPIString(wf.StartedAt),
+		FinishedAt: converter.TimestampToAPIString(wf.FinishedAt),
+	}
+}
+
+// workflowExecutionParameter converts a parameter passed to create a workflow execution.
+// A parameter with a secretRef only has a value if one was passed, which is rejected when the workflow execution is created.
+func workflowExecutionParameter(param *api.Parameter) v1.Parameter {
+	parameter := v1.Parameter{
+		Name:  param.Name,
+		Value: ptr.String(param.Value),
+	}
+	if param.SecretRef != "" {
+		parameter.SecretRef = ptr.String(param.SecretRef)
+		if param.Value == "" {
+			parameter.Value = nil
+		}
+	}
+
+	return parameter
+}
+
+// workflowExecutionToleration converts a toleration passed to create a workflow execution.
+// tolerationSeconds of 0 is not set, so the taint is tolerated forever.
+func workflowExecutionToleration(toleration *api.Toleration) corev1.Toleration {
+	result := corev1.Toleration{
+		Key:      toleration.Key,
+		Operator: corev1.TolerationOperator(toleration.Operator),
+		Value:    toleration.Value,
+		Effect:   corev1.TaintEffect(toleration.Effect),
+	}
+	if toleration.TolerationSeconds != 0 {
+		result.TolerationSeconds = ptr.Int64(toleration.TolerationSeconds)
+	}
+
+	return result
+}
+
 func (s *WorkflowServer) CreateWorkflowExecution(ctx context.Context, req *api.CreateWorkflowExecutionRequest) (*api.WorkflowExecution, error) {
 	client := getClient(ctx)
 	allowed, err := auth.IsAuthorized(client, req.Namespace, "create", "argoproj.io", "workflows", "")
@@ -83,31 +224,146 @@ func (s *WorkflowServer) CreateWorkflowExecution(ctx context.Context, req *api.C
 			UID:     req.Body.WorkflowTemplateUid,
 			Version: req.Body.WorkflowTemplateVersion,
 		},
+		IdempotencyKey: req.Body.IdempotencyKey,
+		Priority:       req.Body.Priority,
+		NodeSelector:   converter.APIKeyValueToLabel(req.Body.NodeSelector),
+		FailFast:       req.Body.FailFast,
+	}
+	if ttl := req.Body.TtlSecondsAfterFinished; ttl != nil {
+		workflow.TTLSecondsAfterFinished = ptr.Int32(ttl.Value)
+	}
+	if req.Body.ActiveDeadlineSeconds != 0 {
+		workflow.ActiveDeadlineSeconds = ptr.Int64(req.Body.ActiveDeadlineSeconds)
 	}
 	for _, param := range req.Body.Parameters {
-		workflow.Parameters = append(workflow.Parameters, v1.Parameter{
-			Name:  param.Name,
-			Value: ptr.String(param.Value),
-		})
+		workflow.Parameters = append(workflow.Parameters, workflowExecutionParameter(param))
+	}
+	for _, toleration := range req.Body.Tolerations {
+		workflow.Tolerations = append(workflow.Tolerations, workflowExecutionToleration(toleration))
+	}
+
+	if req.Body.WorkflowTemplateUid != "" && req.Body.Manifest != "" {
+		return nil, util.NewUserError(codes.InvalidArgument, "Only one of workflowTemplateUid or manifest can be set.")
+	}
+
+	if req.Body.Manifest != "" && req.Body.IdempotencyKey != "" {
+		return nil, util.NewUserError(codes.InvalidArgument, "idempotencyKey is not supported when creating from a manifest.")
+	}
+
+	if req.Body.WorkflowTemplateVersionAlias != "" {
+		if req.Body.WorkflowTemplateVersion != 0 {
+			return nil, util.NewUserError(codes.InvalidArgument, "Only one of workflowTemplateVersion or workflowTemplateVersionAlias can be set.")
+		}
+		workflow.WorkflowTemplate.Version, err = v1.ParseWorkflowTemplateVersionAlias(req.Body.WorkflowTemplateVersionAlias)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var wf *v1.WorkflowExecution
+	if req.Body.WorkflowTemplateUid == "" && req.Body.Manifest != "" {
+		workflow.WorkflowTemplate = nil
+		wf, err = client.CreateWorkflowExecutionFromManifest(req.Namespace, workflow, []byte(req.Body.Manifest))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		workflowTemplate, err := client.GetWorkflowTemplate(req.Namespace, req.Body.WorkflowTemplateUid, workflow.WorkflowTemplate.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		wf, err = client.CreateWorkflowExecution(req.Namespace, workflow, workflowTemplate)
+		if err != nil {
+			return nil, err
+		}
 	}
+	wf.Namespace = req.Namespace
 
-	workflowTemplate, err := client.GetWorkflowTemplate(req.Namespace, req.Body.WorkflowTemplateUid, req.Body.WorkflowTemplateVersion)
+	webRouter, err := client.GetWebRouter()
 	if err != nil {
 		return nil, err
 	}
 
-	wf, err := client.CreateWorkflowExecution(req.Namespace, workflow, workflowTemplate)
+	return apiWorkflowExecution(wf, webRouter), nil
+}
+
+// PreviewWorkflowExecutions returns the manifests that would be submitted for each parameter set, without submitting them
+func (s *WorkflowServer) PreviewWorkflowExecutions(ctx context.Context, req *api.PreviewWorkflowExecutionsRequest) (*api.PreviewWorkflowExecutionsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	workflowTemplate, err := client.GetWorkflowTemplate(req.Namespace, req.WorkflowTemplateUid, req.WorkflowTemplateVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameterSets [][]v1.Parameter
+	for _, parameterSet := range req.ParameterSets {
+		var parameters []v1.Parameter
+		for _, param := range parameterSet.Parameters {
+			parameters = append(parameters, v1.Parameter{
+				Name:  param.Name,
+				Value: ptr.String(param.Value),
+			})
+		}
+		parameterSets = append(parameterSets, parameters)
+	}
+
+	manifests, err := client.PreviewWorkflowExecutions(req.Namespace, workflowTemplate, parameterSets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.PreviewWorkflowExecutionsResponse{Manifests: manifests}, nil
+}
+
+// CreateWorkflowExecutions creates a workflow execution for each parameter set, continuing past the ones that fail
+func (s *WorkflowServer) CreateWorkflowExecutions(ctx context.Context, req *api.CreateWorkflowExecutionsRequest) (*api.CreateWorkflowExecutionsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "create", "argoproj.io", "workflows", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	workflowTemplate, err := client.GetWorkflowTemplate(req.Namespace, req.WorkflowTemplateUid, req.WorkflowTemplateVersion)
 	if err != nil {
 		return nil, err
 	}
-	wf.Namespace = req.Namespace
+
+	var workflows []*v1.WorkflowExecution
+	for _, parameterSet := range req.ParameterSets {
+		workflow := &v1.WorkflowExecution{
+			Labels: converter.APIKeyValueToLabel(req.Labels),
+		}
+		for _, param := range parameterSet.Parameters {
+			workflow.Parameters = append(workflow.Parameters, workflowExecutionParameter(param))
+		}
+		workflows = append(workflows, workflow)
+	}
 
 	webRouter, err := client.GetWebRouter()
 	if err != nil {
 		return nil, err
 	}
 
-	return apiWorkflowExecution(wf, webRouter), nil
+	res := &api.CreateWorkflowExecutionsResponse{}
+	for _, result := range client.CreateWorkflowExecutions(req.Namespace, workflows, workflowTemplate) {
+		if result.Err != nil {
+			res.Results = append(res.Results, &api.CreateWorkflowExecutionResult{Error: result.Err.Error()})
+			continue
+		}
+
+		result.WorkflowExecution.Namespace = req.Namespace
+		res.Results = append(res.Results, &api.CreateWorkflowExecutionResult{
+			WorkflowExecution: apiWorkflowExecution(result.WorkflowExecution, webRouter),
+		})
+	}
+
+	return res, nil
 }
 
 func (s *WorkflowServer) CloneWorkflowExecution(ctx context.Context, req *api.CloneWorkflowExecutionRequest) (*api.WorkflowExecution, error) {
@@ -177,15 +433,25 @@ func (s *WorkflowServer) GetWorkflowExecution(ctx context.Context, req *api.GetW
 		return nil, err
 	}
 
-	wf, err := client.GetWorkflowExecution(req.Namespace, req.Uid)
+	wf, modified, err := client.GetWorkflowExecutionIfModified(req.Namespace, req.Uid, req.IfNoneMatch)
 	if err != nil {
 		return nil, err
 	}
+	if !modified {
+		return &api.WorkflowExecution{
+			Uid:             req.Uid,
+			ResourceVersion: req.IfNoneMatch,
+			NotModified:     true,
+		}, nil
+	}
 	if wf == nil {
 		return nil, util.NewUserError(codes.NotFound, "Workflow not found")
 	}
 
 	wf.Namespace = req.Namespace
+	if wf.ArgoWorkflow != nil {
+		wf.ChildWorkflows = client.GetWorkflowExecutionChildWorkflows(req.Namespace, wf.ArgoWorkflow)
+	}
 
 	webRouter, err := client.GetWebRouter()
 	if err != nil {
@@ -201,6 +467,21 @@ func (s *WorkflowServer) WatchWorkflowExecution(req *api.WatchWorkflowExecutionR
 		return err
 	}
 
+	if req.Minimal {
+		watcher, err := client.WatchWorkflowExecutionPhase(req.Namespace, req.Uid)
+		if err != nil {
+			return err
+		}
+
+		for wf := range watcher {
+			if err := stream.Send(apiWorkflowExecutionPhase(wf)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
 	watcher, err := client.WatchWorkflowExecution(req.Namespace, req.Uid)
 	if err != nil {
 		return err
@@ -231,11 +512,44 @@ func (s *WorkflowServer) GetWorkflowExecutionLogs(req *api.GetWorkflowExecutionL
 		return err
 	}
 
-	watcher, err := client.GetWorkflowExecutionLogs(req.Namespace, req.Uid, req.PodName, req.ContainerName)
+	// The filter is compiled first so an invalid pattern is rejected before any logs are streamed
+	var filter *v1.LogFilter
+	if req.Grep != "" {
+		filter, err = v1.NewLogFilter(req.Grep)
+		if err != nil {
+			return err
+		}
+	}
+
+	var since time.Time
+	if req.SinceDuration != "" {
+		since, err = v1.ParseLogSinceDuration(req.SinceDuration)
+		if err != nil {
+			return err
+		}
+	}
+
+	watcher, err := client.GetWorkflowExecutionLogs(req.Namespace, req.Uid, req.PodName, req.ContainerName, since)
 	if err != nil {
 		return err
 	}
 
+	send := func(le *v1.LogEntry) error {
+		// Only compress when asked, so existing clients keep receiving plain text. Heartbeats have no content to compress.
+		if req.Compress && !le.Heartbeat {
+			if err := le.Compress(); err != nil {
+				return err
+			}
+		}
+
+		return stream.Send(&api.LogEntry{
+			Timestamp:       le.Timestamp.String(),
+			Content:         le.Content,
+			ContentEncoding: le.ContentEncoding,
+			Heartbeat:       le.Heartbeat,
+		})
+	}
+
 	le := &v1.LogEntry{}
 	for {
 		le = <-watcher
@@ -243,14 +557,24 @@ func (s *WorkflowServer) GetWorkflowExecutionLogs(req *api.GetWorkflowExecutionL
 			break
 		}
 
-		if err := stream.Send(&api.LogEntry{
-			Timestamp: le.Timestamp.String(),
-			Content:   le.Content,
-		}); err != nil {
+		// Heartbeats have no lines to match, but are sent so the client knows the stream is alive
+		if filter != nil && !le.Heartbeat {
+			if le = filter.Filter(le); le == nil {
+				continue
+			}
+		}
+
+		if err := send(le); err != nil {
 			return err
 		}
 	}
 
+	if filter != nil {
+		if le := filter.Flush(); le != nil {
+			return send(le)
+		}
+	}
+
 	return nil
 }
 
@@ -261,6 +585,10 @@ func (s *WorkflowServer) GetWorkflowExecutionMetrics(ctx context.Context, req *a
 		return nil, err
 	}
 
+	if req.Start != "" || req.End != "" || req.Step != 0 {
+		return getWorkflowExecutionMetricsRange(client, req)
+	}
+
 	metrics, err := client.GetWorkflowExecutionMetrics(req.Namespace, req.Uid, req.PodName)
 	if err != nil {
 		return nil, err
@@ -281,6 +609,117 @@ func (s *WorkflowServer) GetWorkflowExecutionMetrics(ctx context.Context, req *a
 	return &api.GetWorkflowExecutionMetricsResponse{Metrics: apiMetrics}, nil
 }
 
+// getWorkflowExecutionMetricsRange returns the metrics of the pod over the time range of the request as series.
+// The range ends now and has a point every minute, unless the request says otherwise.
+func getWorkflowExecutionMetricsRange(client *v1.Client, req *api.GetWorkflowExecutionMetricsRequest) (*api.GetWorkflowExecutionMetricsResponse, error) {
+	if req.Start == "" {
+		return nil, util.NewUserError(codes.InvalidArgument, "Start is required with end or step.")
+	}
+
+	start, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, util.NewUserError(codes.InvalidArgument, "Start must be in the RFC3339 format.")
+	}
+
+	end := time.Now().UTC()
+	if req.End != "" {
+		end, err = time.Parse(time.RFC3339, req.End)
+		if err != nil {
+			return nil, util.NewUserError(codes.InvalidArgument, "End must be in the RFC3339 format.")
+		}
+	}
+
+	step := time.Minute
+	if req.Step != 0 {
+		step = time.Duration(req.Step) * time.Second
+	}
+
+	series, err := client.GetWorkflowExecutionMetricsRange(req.Namespace, req.Uid, req.PodName, v1.MetricsRange{
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiSeries := make([]*api.MetricSeries, 0, len(series))
+	for _, s := range series {
+		apiPoints := make([]*api.MetricPoint, 0, len(s.Points))
+		for _, point := range s.Points {
+			apiPoints = append(apiPoints, &api.MetricPoint{
+				Timestamp: converter.TimestampToAPIString(&point.Timestamp),
+				Value:     point.Value,
+			})
+		}
+
+		apiSeries = append(apiSeries, &api.MetricSeries{
+			Name:   s.Name,
+			Format: s.Format,
+			Points: apiPoints,
+		})
+	}
+
+	return &api.GetWorkflowExecutionMetricsResponse{Series: apiSeries}, nil
+}
+
+// GetWorkflowExecutionStepMetrics returns the metrics of each step of the workflow execution that ran in a pod
+func (s *WorkflowServer) GetWorkflowExecutionStepMetrics(ctx context.Context, req *api.GetWorkflowExecutionStepMetricsRequest) (*api.GetWorkflowExecutionStepMetricsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "argoproj.io", "workflows", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	steps, err := client.GetWorkflowExecutionStepMetrics(req.Namespace, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	apiSteps := make([]*api.WorkflowStepMetrics, 0, len(steps))
+	for _, step := range steps {
+		apiStep := &api.WorkflowStepMetrics{
+			NodeName:    step.NodeName,
+			DisplayName: step.DisplayName,
+			PodName:     step.PodName,
+		}
+		for _, m := range step.Metrics {
+			apiStep.Metrics = append(apiStep.Metrics, &api.Metric{
+				Name:   m.Name,
+				Value:  m.Value,
+				Format: m.Format,
+			})
+		}
+		apiSteps = append(apiSteps, apiStep)
+	}
+
+	return &api.GetWorkflowExecutionStepMetricsResponse{Steps: apiSteps}, nil
+}
+
+// GetWorkflowExecutionOutputs returns the global output parameters of a finished workflow execution
+func (s *WorkflowServer) GetWorkflowExecutionOutputs(ctx context.Context, req *api.GetWorkflowExecutionOutputsRequest) (*api.GetWorkflowExecutionOutputsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "argoproj.io", "workflows", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	outputs, err := client.GetWorkflowExecutionOutputs(req.Namespace, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.GetWorkflowExecutionOutputsResponse{}
+	for _, output := range outputs {
+		res.Outputs = append(res.Outputs, &api.KeyValue{
+			Key:   output.Name,
+			Value: output.Value,
+		})
+	}
+
+	return res, nil
+}
+
 // ListWorkflowExecutions returns a list of workflow executions that are specified by the criteria in the ListWorkflowExecutionsRequest
 func (s *WorkflowServer) ListWorkflowExecutions(ctx context.Context, req *api.ListWorkflowExecutionsRequest) (*api.ListWorkflowExecutionsResponse, error) {
 	client := getClient(ctx)
@@ -298,13 +737,19 @@ func (s *WorkflowServer) ListWorkflowExecutions(ctx context.Context, req *api.Li
 		return nil, err
 	}
 
+	filter := v1.WorkflowExecutionFilter{
+		Labels:       labelFilter,
+		Phase:        req.Phase,
+		NameContains: req.NameContains,
+	}
+	if req.OnlyMine {
+		filter.CreatedBy = client.Identity
+	}
+
 	resourceRequest := &request.Request{
 		Pagination: pagination.New(req.Page, req.PageSize),
-		Filter: v1.WorkflowExecutionFilter{
-			Labels: labelFilter,
-			Phase:  req.Phase,
-		},
-		Sort: reqSort,
+		Filter:     filter,
+		Sort:       reqSort,
 	}
 
 	workflows, err := client.ListWorkflowExecutions(req.Namespace, req.WorkflowTemplateUid, req.WorkflowTemplateVersion, req.IncludeSystem, resourceRequest)
@@ -320,7 +765,7 @@ func (s *WorkflowServer) ListWorkflowExecutions(ctx context.Context, req *api.Li
 	var apiWorkflowExecutions []*api.WorkflowExecution
 	for _, wf := range workflows {
 		wf.Namespace = req.Namespace
-		apiWorkflowExecutions = append(apiWorkflowExecutions, apiWorkflowExecution(wf, webRouter))
+		apiWorkflowExecutions = append(apiWorkflowExecutions, apiListedWorkflowExecution(wf, webRouter, req.IncludeTemplate))
 	}
 
 	count, err := client.CountWorkflowExecutions(req.Namespace, req.WorkflowTemplateUid, req.WorkflowTemplateVersion, req.IncludeSystem, resourceRequest)
@@ -328,13 +773,13 @@ func (s *WorkflowServer) ListWorkflowExecutions(ctx context.Context, req *api.Li
 		return nil, err
 	}
 
-	paginator := resourceRequest.Pagination
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(apiWorkflowExecutions), count)
 	return &api.ListWorkflowExecutionsResponse{
-		Count:              int32(len(apiWorkflowExecutions)),
+		Count:              page.Count,
 		WorkflowExecutions: apiWorkflowExecutions,
-		Page:               int32(paginator.Page),
-		Pages:              paginator.CalculatePages(count),
-		TotalCount:         int32(count),
+		Page:               page.Page,
+		Pages:              page.Pages,
+		TotalCount:         page.TotalCount,
 	}, nil
 }
 
@@ -366,11 +811,57 @@ func (s *WorkflowServer) TerminateWorkflowExecution(ctx context.Context, req *ap
 		return nil, err
 	}
 
-	err = client.TerminateWorkflowExecution(req.Namespace, req.Uid)
+	err = client.TerminateWorkflowExecution(req.Namespace, req.Uid, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// TerminateWorkflowExecutions terminates every workflow execution of the namespace in the phase, see v1.Client.TerminateWorkflowExecutions
+func (s *WorkflowServer) TerminateWorkflowExecutions(ctx context.Context, req *api.TerminateWorkflowExecutionsRequest) (*api.TerminateWorkflowExecutionsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "argoproj.io", "workflows", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	count, err := client.TerminateWorkflowExecutions(req.Namespace, wfv1.NodePhase(req.Phase), wfv1.NodePhase(req.ConfirmPhase), req.Reason)
 	if err != nil {
 		return nil, err
 	}
 
+	return &api.TerminateWorkflowExecutionsResponse{
+		Count: int32(count),
+	}, nil
+}
+
+func (s *WorkflowServer) SuspendWorkflowExecution(ctx context.Context, req *api.SuspendWorkflowExecutionRequest) (*empty.Empty, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "argoproj.io", "workflows", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	if err := client.SuspendWorkflowExecution(req.Namespace, req.Uid); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *WorkflowServer) ResumeWorkflowExecution(ctx context.Context, req *api.ResumeWorkflowExecutionRequest) (*empty.Empty, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "argoproj.io", "workflows", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	if _, err := client.ResumeWorkflowExecution(req.Namespace, req.Uid); err != nil {
+		return nil, err
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -502,3 +993,46 @@ func (s *WorkflowServer) UpdateWorkflowExecutionMetrics(ctx context.Context, req
 
 	return resp, nil
 }
+
+// ListAuditEvents returns who created, terminated and resubmitted workflow executions in the namespace, newest first
+func (s *WorkflowServer) ListAuditEvents(ctx context.Context, req *api.ListAuditEventsRequest) (*api.ListAuditEventsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "list", "argoproj.io", "workflows", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	resourceRequest := &request.Request{
+		Pagination: pagination.New(req.Page, req.PageSize),
+	}
+
+	events, err := client.ListAuditEvents(req.Namespace, req.ResourceUid, resourceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := client.CountAuditEvents(req.Namespace, req.ResourceUid)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(events), count)
+	res := &api.ListAuditEventsResponse{
+		Count:      page.Count,
+		Page:       page.Page,
+		Pages:      page.Pages,
+		TotalCount: page.TotalCount,
+	}
+	for _, event := range events {
+		res.AuditEvents = append(res.AuditEvents, &api.AuditEvent{
+			CreatedAt:   converter.TimestampToAPIString(&event.CreatedAt),
+			Action:      string(event.Action),
+			Identity:    event.Identity,
+			ResourceUid: event.ResourceUID,
+			Parameters:  converter.ParametersToAPI(event.Parameters),
+			Reason:      event.Reason,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/onepanelio/core/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_apiListedWorkflowExecution_IncludeTemplate makes sure the workflow template loaded by the list query is sent
+// whether or not includeTemplate is set, so clients that do not set it keep getting it
+func Test_apiListedWorkflowExecution_IncludeTemplate(t *testing.T) {
+	createdAt := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	wf := &v1.WorkflowExecution{
+		UID:  "train-abc12",
+		Name: "train-abc12",
+		WorkflowTemplate: &v1.WorkflowTemplate{
+			UID:       "train",
+			Name:      "train",
+			Version:   1606816800,
+			CreatedAt: createdAt,
+		},
+	}
+
+	for _, includeTemplate := range []bool{false, true} {
+		workflow := apiListedWorkflowExecution(wf, nil, includeTemplate)
+		if !assert.NotNil(t, workflow) || !assert.NotNil(t, workflow.WorkflowTemplate, "includeTemplate: %v", includeTemplate) {
+			continue
+		}
+		assert.Equal(t, "train", workflow.WorkflowTemplate.Uid)
+		assert.Equal(t, "train", workflow.WorkflowTemplate.Name)
+		assert.Equal(t, int64(1606816800), workflow.WorkflowTemplate.Version)
+		assert.NotEmpty(t, workflow.WorkflowTemplate.CreatedAt)
+	}
+
+	// Workflow executions created from a manifest have no workflow template to embed
+	workflow := apiListedWorkflowExecution(&v1.WorkflowExecution{UID: "manifest-abc12"}, nil, true)
+	if assert.NotNil(t, workflow) {
+		assert.Nil(t, workflow.WorkflowTemplate)
+	}
+}
+
+// Test_apiWorkflowExecution_InvalidParameters makes sure a workflow execution whose parameters can not be loaded
+// is still converted, without its parameters, so it is not left out of lists
+func Test_apiWorkflowExecution_InvalidParameters(t *testing.T) {
+	wf := &v1.WorkflowExecution{
+		UID:             "train-abc12",
+		Name:            "train-abc12",
+		ParametersBytes: []byte("{not json"),
+	}
+
+	workflow := apiListedWorkflowExecution(wf, nil, false)
+	if assert.NotNil(t, workflow) {
+		assert.Equal(t, "train-abc12", workflow.Uid)
+		assert.Empty(t, workflow.Parameters)
+	}
+}
@@ -3,12 +3,15 @@ package server
 import (
 	"context"
 	"errors"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/onepanelio/core/api"
 	v1 "github.com/onepanelio/core/pkg"
+	"github.com/onepanelio/core/pkg/util/ptr"
 	"github.com/onepanelio/core/pkg/util/request"
 	"github.com/onepanelio/core/pkg/util/request/pagination"
 	"github.com/onepanelio/core/server/auth"
 	"github.com/onepanelio/core/server/converter"
+	log "github.com/sirupsen/logrus"
 )
 
 type WorkflowTemplateServer struct{}
@@ -32,6 +35,17 @@ func apiWorkflowTemplate(wft *v1.WorkflowTemplate) *api.WorkflowTemplate {
 		Labels:     converter.MappingToKeyValue(wft.Labels),
 		Parameters: converter.ParametersToAPI(wft.Parameters),
 		Stats:      converter.WorkflowExecutionStatisticsReportToAPI(wft.WorkflowExecutionStatisticReport),
+		CreatedBy:  wft.CreatedBy,
+		UpdatedBy:  wft.UpdatedBy,
+		Category:   wft.Category,
+	}
+
+	if wft.VersionLabel != nil {
+		res.VersionLabel = *wft.VersionLabel
+	}
+
+	if wft.StableVersion != nil {
+		res.StableVersion = *wft.StableVersion
 	}
 
 	if wft.CronWorkflowsStatisticsReport != nil {
@@ -54,6 +68,7 @@ func (s *WorkflowTemplateServer) CreateWorkflowTemplate(ctx context.Context, req
 		Name:     req.WorkflowTemplate.Name,
 		Manifest: req.WorkflowTemplate.Manifest,
 		Labels:   converter.APIKeyValueToLabel(req.WorkflowTemplate.Labels),
+		Category: req.WorkflowTemplate.Category,
 	}
 	workflowTemplate, err = client.CreateWorkflowTemplate(req.Namespace, workflowTemplate)
 	if err != nil {
@@ -61,10 +76,34 @@ func (s *WorkflowTemplateServer) CreateWorkflowTemplate(ctx context.Context, req
 	}
 	req.WorkflowTemplate.Uid = workflowTemplate.UID
 	req.WorkflowTemplate.Version = workflowTemplate.Version
+	req.WorkflowTemplate.CreatedBy = workflowTemplate.CreatedBy
+	req.WorkflowTemplate.UpdatedBy = workflowTemplate.UpdatedBy
+	req.WorkflowTemplate.Category = workflowTemplate.Category
+	req.WorkflowTemplate.Warnings = workflowTemplate.Warnings
 
 	return req.WorkflowTemplate, nil
 }
 
+// ValidateWorkflowTemplate validates a workflow template manifest without creating anything
+func (s *WorkflowTemplateServer) ValidateWorkflowTemplate(ctx context.Context, req *api.ValidateWorkflowTemplateRequest) (*api.ValidateWorkflowTemplateResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "create", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	res := &api.ValidateWorkflowTemplateResponse{}
+	for _, validationError := range client.ValidateWorkflowTemplate(req.Namespace, req.Manifest) {
+		res.Errors = append(res.Errors, &api.WorkflowTemplateValidationError{
+			Message: validationError.Message,
+			Field:   validationError.Field,
+			Line:    validationError.Line,
+		})
+	}
+
+	return res, nil
+}
+
 func (s *WorkflowTemplateServer) CreateWorkflowTemplateVersion(ctx context.Context, req *api.CreateWorkflowTemplateRequest) (*api.WorkflowTemplate, error) {
 	client := getClient(ctx)
 	allowed, err := auth.IsAuthorized(client, req.Namespace, "create", "argoproj.io", "workflowtemplates", req.WorkflowTemplate.Name)
@@ -78,8 +117,11 @@ func (s *WorkflowTemplateServer) CreateWorkflowTemplateVersion(ctx context.Conte
 		Manifest: req.WorkflowTemplate.Manifest,
 		Labels:   converter.APIKeyValueToLabel(req.WorkflowTemplate.Labels),
 	}
+	if req.WorkflowTemplate.VersionLabel != "" {
+		workflowTemplate.VersionLabel = ptr.String(req.WorkflowTemplate.VersionLabel)
+	}
 
-	workflowTemplate, err = client.CreateWorkflowTemplateVersion(req.Namespace, workflowTemplate)
+	workflowTemplate, err = client.CreateWorkflowTemplateVersionIfChanged(req.Namespace, workflowTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +129,9 @@ func (s *WorkflowTemplateServer) CreateWorkflowTemplateVersion(ctx context.Conte
 	req.WorkflowTemplate.Uid = workflowTemplate.UID
 	req.WorkflowTemplate.Name = workflowTemplate.Name
 	req.WorkflowTemplate.Version = workflowTemplate.Version
+	req.WorkflowTemplate.CreatedBy = workflowTemplate.CreatedBy
+	req.WorkflowTemplate.UpdatedBy = workflowTemplate.UpdatedBy
+	req.WorkflowTemplate.Category = workflowTemplate.Category
 
 	return req.WorkflowTemplate, nil
 }
@@ -98,7 +143,12 @@ func (s *WorkflowTemplateServer) GetWorkflowTemplate(ctx context.Context, req *a
 		return nil, err
 	}
 
-	workflowTemplate, err := client.GetWorkflowTemplate(req.Namespace, req.Uid, req.Version)
+	var workflowTemplate *v1.WorkflowTemplate
+	if req.VersionLabel != "" {
+		workflowTemplate, err = client.GetWorkflowTemplateByVersionLabel(req.Namespace, req.Uid, req.VersionLabel)
+	} else {
+		workflowTemplate, err = client.GetWorkflowTemplate(req.Namespace, req.Uid, req.Version)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +159,42 @@ func (s *WorkflowTemplateServer) GetWorkflowTemplate(ctx context.Context, req *a
 	}
 	workflowTemplate.Versions = int64(versionsCount)
 
-	return apiWorkflowTemplate(workflowTemplate), nil
+	if req.ResolvePlaceholders {
+		workflowTemplate.Manifest = client.ResolveWorkflowTemplatePlaceholders(req.Namespace, workflowTemplate.Manifest)
+	}
+
+	client.ResolveParameterOptions(req.Namespace, workflowTemplate.Parameters)
+
+	res := apiWorkflowTemplate(workflowTemplate)
+
+	// The usage count is informational, so the template is still sent without it if the workflows can not be counted
+	usageCount, err := client.CountWorkflowTemplateUsage(req.Namespace, req.Uid, 0)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"Namespace": req.Namespace,
+			"UID":       req.Uid,
+			"Error":     err.Error(),
+		}).Error("Unable to count workflow template usage.")
+	} else {
+		res.UsageCount = int64(usageCount)
+	}
+
+	if req.IncludeVersions {
+		versions, err := client.ListWorkflowTemplateVersionSummaries(req.Namespace, req.Uid)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range versions {
+			res.VersionSummaries = append(res.VersionSummaries, &api.WorkflowTemplateVersionSummary{
+				Version:   version.Version,
+				CreatedAt: converter.TimestampToAPIString(&version.CreatedAt),
+				IsLatest:  version.IsLatest,
+			})
+		}
+	}
+
+	return res, nil
 }
 
 func (s *WorkflowTemplateServer) CloneWorkflowTemplate(ctx context.Context, req *api.CloneWorkflowTemplateRequest) (*api.WorkflowTemplate, error) {
@@ -156,7 +241,8 @@ func (s *WorkflowTemplateServer) ListWorkflowTemplateVersions(ctx context.Contex
 		return nil, err
 	}
 
-	workflowTemplateVersions, err := client.ListWorkflowTemplateVersions(req.Namespace, req.Uid)
+	paginator := pagination.New(req.Page, req.PageSize)
+	workflowTemplateVersions, err := client.ListWorkflowTemplateVersions(req.Namespace, req.Uid, paginator)
 	if err != nil {
 		return nil, err
 	}
@@ -166,12 +252,37 @@ func (s *WorkflowTemplateServer) ListWorkflowTemplateVersions(ctx context.Contex
 		workflowTemplates = append(workflowTemplates, apiWorkflowTemplate(wtv))
 	}
 
+	count, err := client.CountWorkflowTemplateVersions(req.Namespace, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewMetadata(paginator, len(workflowTemplateVersions), int(count))
 	return &api.ListWorkflowTemplateVersionsResponse{
-		Count:             int32(len(workflowTemplateVersions)),
+		Count:             page.Count,
 		WorkflowTemplates: workflowTemplates,
+		Page:              page.Page,
+		Pages:             page.Pages,
+		TotalCount:        page.TotalCount,
 	}, nil
 }
 
+// DiffWorkflowTemplateVersions returns a unified diff of the manifests of two versions of a workflow template
+func (s *WorkflowTemplateServer) DiffWorkflowTemplateVersions(ctx context.Context, req *api.DiffWorkflowTemplateVersionsRequest) (*api.DiffWorkflowTemplateVersionsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	diff, err := client.DiffWorkflowTemplateVersions(req.Namespace, req.Uid, req.FromVersion, req.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DiffWorkflowTemplateVersionsResponse{Diff: diff}, nil
+}
+
 func (s *WorkflowTemplateServer) ListWorkflowTemplates(ctx context.Context, req *api.ListWorkflowTemplatesRequest) (*api.ListWorkflowTemplatesResponse, error) {
 	client := getClient(ctx)
 	allowed, err := auth.IsAuthorized(client, req.Namespace, "list", "argoproj.io", "workflowtemplates", "")
@@ -187,7 +298,8 @@ func (s *WorkflowTemplateServer) ListWorkflowTemplates(ctx context.Context, req
 	resourceRequest := &request.Request{
 		Pagination: pagination.New(req.Page, req.PageSize),
 		Filter: v1.WorkflowTemplateFilter{
-			Labels: labelFilter,
+			Labels:   labelFilter,
+			Category: req.Category,
 		},
 	}
 
@@ -206,13 +318,13 @@ func (s *WorkflowTemplateServer) ListWorkflowTemplates(ctx context.Context, req
 		return nil, err
 	}
 
-	paginator := resourceRequest.Pagination
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(apiWorkflowTemplates), count)
 	return &api.ListWorkflowTemplatesResponse{
-		Count:             int32(len(apiWorkflowTemplates)),
+		Count:             page.Count,
 		WorkflowTemplates: apiWorkflowTemplates,
-		Page:              int32(paginator.Page),
-		Pages:             paginator.CalculatePages(count),
-		TotalCount:        int32(count),
+		Page:              page.Page,
+		Pages:             page.Pages,
+		TotalCount:        page.TotalCount,
 	}, nil
 }
 
@@ -223,7 +335,7 @@ func (s *WorkflowTemplateServer) ArchiveWorkflowTemplate(ctx context.Context, re
 		return nil, err
 	}
 
-	archived, err := client.ArchiveWorkflowTemplate(req.Namespace, req.Uid)
+	archived, err := client.ArchiveWorkflowTemplate(req.Namespace, req.Uid, req.TerminateRunning)
 	if err != nil {
 		return nil, err
 	}
@@ -234,3 +346,56 @@ func (s *WorkflowTemplateServer) ArchiveWorkflowTemplate(ctx context.Context, re
 		},
 	}, nil
 }
+
+// DeleteWorkflowTemplate permanently deletes a workflow template. Forcing the deletion also deletes the workflow executions
+// of the template and their history, so it is for admins: it requires permission to delete workflows in every namespace.
+func (s *WorkflowTemplateServer) DeleteWorkflowTemplate(ctx context.Context, req *api.DeleteWorkflowTemplateRequest) (*empty.Empty, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "delete", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	if req.Force {
+		allowed, err := auth.IsAuthorized(client, "", "delete", "argoproj.io", "workflows", "")
+		if err != nil || !allowed {
+			return nil, err
+		}
+	}
+
+	if err := client.DeleteWorkflowTemplate(req.Namespace, req.Uid, req.Force); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *WorkflowTemplateServer) TransferWorkflowTemplateOwnership(ctx context.Context, req *api.TransferWorkflowTemplateOwnershipRequest) (*api.WorkflowTemplate, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	workflowTemplate, err := client.TransferWorkflowTemplateOwnership(req.Namespace, req.Uid, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiWorkflowTemplate(workflowTemplate), nil
+}
+
+func (s *WorkflowTemplateServer) SetWorkflowTemplateStableVersion(ctx context.Context, req *api.SetWorkflowTemplateStableVersionRequest) (*api.WorkflowTemplate, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "argoproj.io", "workflowtemplates", "")
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	workflowTemplate, err := client.SetWorkflowTemplateStableVersion(req.Namespace, req.Uid, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiWorkflowTemplate(workflowTemplate), nil
+}
@@ -46,10 +46,15 @@ func apiWorkspace(wt *v1.Workspace, config v1.SystemConfig) *api.Workspace {
 	}
 
 	res := &api.Workspace{
-		Uid:       wt.UID,
-		Name:      wt.Name,
-		CreatedAt: wt.CreatedAt.UTC().Format(time.RFC3339),
-		Url:       wt.GetURL(*protocol, *domain),
+		Uid:              wt.UID,
+		Name:             wt.Name,
+		CreatedAt:        wt.CreatedAt.UTC().Format(time.RFC3339),
+		Url:              wt.GetURL(*protocol, *domain),
+		Ready:            wt.Ready,
+		Replicas:         wt.Replicas,
+		ScheduleStart:    wt.ScheduleStart,
+		ScheduleStop:     wt.ScheduleStop,
+		ScheduleTimezone: wt.ScheduleTimezone,
 	}
 	res.Parameters = converter.ParametersToAPI(wt.Parameters)
 
@@ -59,6 +64,12 @@ func apiWorkspace(wt *v1.Workspace, config v1.SystemConfig) *api.Workspace {
 
 	if wt.Status.StartedAt != nil {
 		res.Status.StartedAt = wt.Status.StartedAt.UTC().Format(time.RFC3339)
+		res.StartedAt = res.Status.StartedAt
+		res.UptimeSeconds = int64(wt.Uptime(time.Now().UTC()).Seconds())
+	}
+
+	if wt.LastActivityAt != nil {
+		res.LastActivityAt = wt.LastActivityAt.UTC().Format(time.RFC3339)
 	}
 
 	if wt.Status.PausedAt != nil {
@@ -96,7 +107,14 @@ func (s *WorkspaceServer) CreateWorkspace(ctx context.Context, req *api.CreateWo
 			UID:     req.Body.WorkspaceTemplateUid,
 			Version: req.Body.WorkspaceTemplateVersion,
 		},
-		Labels: converter.APIKeyValueToLabel(req.Body.Labels),
+		Labels:           converter.APIKeyValueToLabel(req.Body.Labels),
+		SourceSnapshotID: req.Body.SourceSnapshotId,
+		Replicas:         req.Body.Replicas,
+		Command:          req.Body.Command,
+		Args:             req.Body.Args,
+		ScheduleStart:    req.Body.ScheduleStart,
+		ScheduleStop:     req.Body.ScheduleStop,
+		ScheduleTimezone: req.Body.ScheduleTimezone,
 	}
 
 	for _, param := range req.Body.Parameters {
@@ -144,6 +162,7 @@ func (s *WorkspaceServer) GetWorkspace(ctx context.Context, req *api.GetWorkspac
 	if err != nil {
 		return nil, err
 	}
+	workspace.Ready = client.GetWorkspaceReady(req.Namespace, req.Uid)
 
 	sysConfig, err := client.GetSystemConfig()
 	if err != nil {
@@ -166,9 +185,91 @@ func (s *WorkspaceServer) GetWorkspace(ctx context.Context, req *api.GetWorkspac
 
 	apiWorkspace.TemplateParameters = converter.ParametersToAPI(templateParameters)
 
+	ports, err := workspace.GetPorts(*sysConfig.APIProtocol(), *sysConfig.Domain())
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range ports {
+		apiWorkspace.Ports = append(apiWorkspace.Ports, &api.WorkspacePort{
+			Name: port.Name,
+			Port: port.Port,
+			Url:  port.URL,
+		})
+	}
+
 	return apiWorkspace, nil
 }
 
+// GetWorkspaceEvents returns the status transitions of a workspace, oldest first
+func (s *WorkspaceServer) GetWorkspaceEvents(ctx context.Context, req *api.GetWorkspaceEventsRequest) (*api.GetWorkspaceEventsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "onepanel.io", "workspaces", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	resourceRequest := &request.Request{
+		Pagination: pagination.New(req.Page, req.PageSize),
+	}
+
+	events, err := client.GetWorkspaceEvents(req.Namespace, req.Uid, resourceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := client.CountWorkspaceEvents(req.Namespace, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(events), count)
+	res := &api.GetWorkspaceEventsResponse{
+		Count:      page.Count,
+		Page:       page.Page,
+		Pages:      page.Pages,
+		TotalCount: page.TotalCount,
+	}
+	for _, event := range events {
+		res.Events = append(res.Events, &api.WorkspaceEvent{
+			Phase:     string(event.Phase),
+			Message:   event.Message,
+			CreatedAt: converter.TimestampToAPIString(&event.CreatedAt),
+		})
+	}
+
+	return res, nil
+}
+
+func (s *WorkspaceServer) GetWorkspaceMetrics(ctx context.Context, req *api.GetWorkspaceMetricsRequest) (*api.GetWorkspaceMetricsResponse, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "get", "onepanel.io", "workspaces", req.Uid)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	metrics, err := client.GetWorkspaceMetrics(req.Namespace, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.GetWorkspaceMetricsResponse{
+		PodName: metrics.PodName,
+	}
+	for _, container := range metrics.Containers {
+		res.Containers = append(res.Containers, &api.WorkspaceContainerMetrics{
+			Name:          container.Name,
+			CpuUsage:      container.CPUUsage,
+			CpuRequest:    container.CPURequest,
+			CpuLimit:      container.CPULimit,
+			MemoryUsage:   container.MemoryUsage,
+			MemoryRequest: container.MemoryRequest,
+			MemoryLimit:   container.MemoryLimit,
+		})
+	}
+
+	return res, nil
+}
+
 func (s *WorkspaceServer) UpdateWorkspaceStatus(ctx context.Context, req *api.UpdateWorkspaceStatusRequest) (*empty.Empty, error) {
 	client := getClient(ctx)
 	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "onepanel.io", "workspaces", req.Uid)
@@ -203,7 +304,7 @@ func (s *WorkspaceServer) UpdateWorkspace(ctx context.Context, req *api.UpdateWo
 			Value: ptr.String(param.Value),
 		})
 	}
-	err = client.UpdateWorkspace(req.Namespace, req.Uid, parameters)
+	err = client.UpdateWorkspace(req.Namespace, req.Uid, parameters, req.Body.Replicas)
 
 	return &empty.Empty{}, err
 }
@@ -252,13 +353,13 @@ func (s *WorkspaceServer) ListWorkspaces(ctx context.Context, req *api.ListWorks
 		return nil, err
 	}
 
-	paginator := resourceRequest.Pagination
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(apiWorkspaces), count)
 	return &api.ListWorkspaceResponse{
-		Count:      int32(len(apiWorkspaces)),
+		Count:      page.Count,
 		Workspaces: apiWorkspaces,
-		Page:       int32(paginator.Page),
-		Pages:      paginator.CalculatePages(count),
-		TotalCount: int32(count),
+		Page:       page.Page,
+		Pages:      page.Pages,
+		TotalCount: page.TotalCount,
 	}, nil
 }
 
@@ -274,6 +375,19 @@ func (s *WorkspaceServer) PauseWorkspace(ctx context.Context, req *api.PauseWork
 	return &empty.Empty{}, err
 }
 
+// RecordWorkspaceActivity records that the workspace is being used, see GetWorkspace for when it was last used
+func (s *WorkspaceServer) RecordWorkspaceActivity(ctx context.Context, req *api.RecordWorkspaceActivityRequest) (*empty.Empty, error) {
+	client := getClient(ctx)
+	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "onepanel.io", "workspaces", req.Uid)
+	if err != nil || !allowed {
+		return &empty.Empty{}, err
+	}
+
+	err = client.RecordWorkspaceActivity(req.Namespace, req.Uid)
+
+	return &empty.Empty{}, err
+}
+
 func (s *WorkspaceServer) ResumeWorkspace(ctx context.Context, req *api.ResumeWorkspaceRequest) (*empty.Empty, error) {
 	client := getClient(ctx)
 	allowed, err := auth.IsAuthorized(client, req.Namespace, "update", "onepanel.io", "workspaces", req.Uid)
@@ -349,7 +463,7 @@ func (s *WorkspaceServer) RetryLastWorkspaceAction(ctx context.Context, req *api
 			return nil, err
 		}
 	case v1.WorkspaceFailedToUpdate:
-		if err := client.UpdateWorkspace(req.Namespace, workspace.UID, workspace.Parameters); err != nil {
+		if err := client.UpdateWorkspace(req.Namespace, workspace.UID, workspace.Parameters, 0); err != nil {
 			return nil, err
 		}
 	default:
@@ -377,3 +491,39 @@ func (s *WorkspaceServer) GetWorkspaceStatisticsForNamespace(ctx context.Context
 		Stats: converter.WorkspaceStatisticsReportToAPI(report),
 	}, nil
 }
+
+// GetWorkspaceStatisticsForNamespaces returns statistics on workspaces for each of the given namespaces.
+// If no namespaces are given, statistics are returned for all namespaces.
+func (s *WorkspaceServer) GetWorkspaceStatisticsForNamespaces(ctx context.Context, req *api.GetWorkspaceStatisticsForNamespacesRequest) (*api.GetWorkspaceStatisticsForNamespacesResponse, error) {
+	client := getClient(ctx)
+
+	if len(req.Namespaces) == 0 {
+		allowed, err := auth.IsAuthorized(client, "", "list", "onepanel.io", "workspaces", "")
+		if err != nil || !allowed {
+			return nil, err
+		}
+	}
+	for _, namespace := range req.Namespaces {
+		allowed, err := auth.IsAuthorized(client, namespace, "list", "onepanel.io", "workspaces", "")
+		if err != nil || !allowed {
+			return nil, err
+		}
+	}
+
+	reports, err := client.GetWorkspaceStatisticsForNamespaces(req.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReports := make([]*api.NamespaceWorkspaceStatisticReport, len(reports))
+	for i, report := range reports {
+		apiReports[i] = &api.NamespaceWorkspaceStatisticReport{
+			Namespace: report.Namespace,
+			Stats:     converter.WorkspaceStatisticsReportToAPI(&report.WorkspaceStatisticReport),
+		}
+	}
+
+	return &api.GetWorkspaceStatisticsForNamespacesResponse{
+		Reports: apiReports,
+	}, nil
+}
@@ -170,13 +170,13 @@ func (s *WorkspaceTemplateServer) ListWorkspaceTemplates(ctx context.Context, re
 		return nil, err
 	}
 
-	paginator := resourceRequest.Pagination
+	page := pagination.NewMetadata(resourceRequest.Pagination, len(apiWorkspaceTemplates), count)
 	return &api.ListWorkspaceTemplatesResponse{
-		Count:              int32(len(apiWorkspaceTemplates)),
+		Count:              page.Count,
 		WorkspaceTemplates: apiWorkspaceTemplates,
-		Page:               int32(paginator.Page),
-		Pages:              paginator.CalculatePages(count),
-		TotalCount:         int32(count),
+		Page:               page.Page,
+		Pages:              page.Pages,
+		TotalCount:         page.TotalCount,
 	}, nil
 }
 